@@ -2,13 +2,28 @@ package resourceproviders
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/resources/mgmt/resources"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 )
 
-func availableResourceProviders(ctx context.Context, client *resources.ProvidersClient) (*[]string, error) {
+type availableProviders struct {
+	// ResourceProviders are the namespaces of the Resource Providers available on this Azure Stack Hub stamp
+	ResourceProviders []string
+
+	// Locations are the Locations which any Resource Type is available in, used when the
+	// Metadata Service doesn't return the list of supported Locations
+	Locations []string
+}
+
+func availableResourceProviders(ctx context.Context, client *resources.ProvidersClient) (*availableProviders, error) {
 	providerNames := make([]string, 0)
+	locations := make(map[string]struct{})
 	providers, err := client.ListComplete(ctx, nil, "")
 	if err != nil {
 		return nil, fmt.Errorf("listing Resource Providers: %+v", err)
@@ -19,10 +34,82 @@ func availableResourceProviders(ctx context.Context, client *resources.Providers
 			providerNames = append(providerNames, *provider.Namespace)
 		}
 
+		if provider.ResourceTypes != nil {
+			for _, resourceType := range *provider.ResourceTypes {
+				if resourceType.Locations == nil {
+					continue
+				}
+				for _, loc := range *resourceType.Locations {
+					if v := location.Normalize(loc); v != "" {
+						locations[v] = struct{}{}
+					}
+				}
+			}
+		}
+
 		if err := providers.NextWithContext(ctx); err != nil {
 			return nil, err
 		}
 	}
 
-	return &providerNames, nil
+	return &availableProviders{
+		ResourceProviders: providerNames,
+		Locations:         sortedKeys(locations),
+	}, nil
+}
+
+type cloudEndpoint struct {
+	Endpoint  string    `json:"endpoint"`
+	Locations *[]string `json:"locations"`
+}
+
+type metaDataResponse struct {
+	CloudEndpoint map[string]cloudEndpoint `json:"cloudEndpoint"`
+}
+
+// availableLocations returns the Locations supported by the specified Resource Manager Endpoint, as
+// returned from the Metadata Service. This returns nil when the Metadata Service doesn't return them.
+func availableLocations(ctx context.Context, client *http.Client, resourceManagerEndpoint string) (*[]string, error) {
+	// e.g. https://management.local.azurestack.external/ but we need management.local.azurestack.external
+	endpoint := strings.TrimPrefix(resourceManagerEndpoint, "https://")
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	uri := fmt.Sprintf("https://%s/metadata/endpoints?api-version=2018-01-01", endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving supported locations from Azure MetaData service: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("retrieving supported locations from Azure MetaData service: unexpected status %d", resp.StatusCode)
+	}
+
+	var out metaDataResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("deserializing JSON from Azure MetaData service: %+v", err)
+	}
+
+	// one of the endpoints should reference itself, however this is best-effort
+	for _, v := range out.CloudEndpoint {
+		if strings.EqualFold(v.Endpoint, endpoint) && v.Locations != nil && len(*v.Locations) > 0 {
+			return v.Locations, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func sortedKeys(input map[string]struct{}) []string {
+	output := make([]string, 0, len(input))
+	for k := range input {
+		output = append(output, k)
+	}
+	sort.Strings(output)
+	return output
 }
@@ -3,6 +3,7 @@ package resourceproviders
 import (
 	"context"
 	"log"
+	"net/http"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/resources/mgmt/resources"
 )
@@ -10,8 +11,26 @@ import (
 // cachedResourceProviders can be (validly) nil - as such this shouldn't be relied on
 var cachedResourceProviders *[]string
 
+// cachedLocations can be (validly) nil - as such this shouldn't be relied on
+var cachedLocations *[]string
+
+// CacheSupportedLocations attempts to retrieve the supported Locations from the Metadata Service of the
+// Resource Manager Endpoint and caches them, for use in enhanced validation
+func CacheSupportedLocations(ctx context.Context, client *http.Client, resourceManagerEndpoint string) {
+	locations, err := availableLocations(ctx, client, resourceManagerEndpoint)
+	if err != nil {
+		log.Printf("[DEBUG] error retrieving locations: %s. Enhanced validation will be unavailable", err)
+		return
+	}
+
+	cachedLocations = locations
+}
+
 // CacheSupportedProviders attempts to retrieve the supported Resource Providers from the Resource Manager API
 // and caches them, for used in enhanced validation
+//
+// Since Azure Stack Hub's Metadata Service doesn't always return the supported Locations, when these haven't
+// been cached the Locations the Resource Types are available in are cached instead
 func CacheSupportedProviders(ctx context.Context, client *resources.ProvidersClient) {
 	providers, err := availableResourceProviders(ctx, client)
 	if err != nil {
@@ -19,5 +38,49 @@ func CacheSupportedProviders(ctx context.Context, client *resources.ProvidersCli
 		return
 	}
 
-	cachedResourceProviders = providers
+	cachedResourceProviders = &providers.ResourceProviders
+	if cachedLocations == nil && len(providers.Locations) > 0 {
+		cachedLocations = &providers.Locations
+	}
+}
+
+// CacheSupportedLocationsAndProviders caches the supported Locations and Resource Providers for use in
+// enhanced validation. When a cache file is specified, a recent copy is used rather than querying the
+// Azure Stack Hub stamp - and an older copy is used if the stamp can't be queried - which allows plans
+// in air-gapped environments to be validated without re-querying the stamp each time.
+func CacheSupportedLocationsAndProviders(ctx context.Context, httpClient *http.Client, resourceManagerEndpoint string, providersClient *resources.ProvidersClient, cacheFilePath string) {
+	var existing *cacheFile
+	if cacheFilePath != "" {
+		v, err := readCacheFile(cacheFilePath)
+		if err != nil {
+			log.Printf("[DEBUG] unable to read the enhanced validation cache from %q: %+v", cacheFilePath, err)
+		} else if v != nil && v.isFor(resourceManagerEndpoint) {
+			existing = v
+		}
+
+		if existing != nil && !existing.expired() {
+			log.Printf("[DEBUG] using the enhanced validation cache from %q retrieved at %s", cacheFilePath, existing.RetrievedAt)
+			existing.populate()
+			return
+		}
+	}
+
+	CacheSupportedLocations(ctx, httpClient, resourceManagerEndpoint)
+	CacheSupportedProviders(ctx, providersClient)
+
+	if cacheFilePath == "" {
+		return
+	}
+
+	if cachedLocations == nil || cachedResourceProviders == nil {
+		if existing != nil {
+			log.Printf("[DEBUG] unable to retrieve the supported Locations and Resource Providers, using the enhanced validation cache from %q retrieved at %s", cacheFilePath, existing.RetrievedAt)
+			existing.populate()
+		}
+		return
+	}
+
+	if err := writeCacheFile(cacheFilePath, newCacheFile(resourceManagerEndpoint, *cachedLocations, *cachedResourceProviders)); err != nil {
+		log.Printf("[DEBUG] unable to write the enhanced validation cache to %q: %+v", cacheFilePath, err)
+	}
 }
@@ -0,0 +1,103 @@
+package resourceproviders
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cacheFileMaxAge is how long a cache file is used for before the Azure Stack Hub stamp is queried again
+const cacheFileMaxAge = 24 * time.Hour
+
+// this is only here to aid testing
+var now = time.Now
+
+type cacheFile struct {
+	ResourceManagerEndpoint string    `json:"resourceManagerEndpoint"`
+	RetrievedAt             time.Time `json:"retrievedAt"`
+	Locations               []string  `json:"locations"`
+	ResourceProviders       []string  `json:"resourceProviders"`
+}
+
+func newCacheFile(resourceManagerEndpoint string, locations []string, resourceProviders []string) cacheFile {
+	return cacheFile{
+		ResourceManagerEndpoint: resourceManagerEndpoint,
+		RetrievedAt:             now().UTC(),
+		Locations:               locations,
+		ResourceProviders:       resourceProviders,
+	}
+}
+
+// isFor returns whether the cache file contains the values for the specified Resource Manager Endpoint
+func (c cacheFile) isFor(resourceManagerEndpoint string) bool {
+	normalize := func(input string) string {
+		return strings.TrimSuffix(strings.ToLower(input), "/")
+	}
+	return normalize(c.ResourceManagerEndpoint) == normalize(resourceManagerEndpoint)
+}
+
+func (c cacheFile) expired() bool {
+	return now().Sub(c.RetrievedAt) > cacheFileMaxAge
+}
+
+func (c cacheFile) populate() {
+	locations := c.Locations
+	cachedLocations = &locations
+
+	resourceProviders := c.ResourceProviders
+	cachedResourceProviders = &resourceProviders
+}
+
+// readCacheFile reads the cache file from the specified path, returning nil if it doesn't exist
+func readCacheFile(path string) (*cacheFile, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading %q: %+v", path, err)
+	}
+
+	var out cacheFile
+	if err := json.Unmarshal(contents, &out); err != nil {
+		return nil, fmt.Errorf("deserializing %q: %+v", path, err)
+	}
+
+	return &out, nil
+}
+
+func writeCacheFile(path string, input cacheFile) error {
+	contents, err := json.MarshalIndent(input, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing: %+v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating directory for %q: %+v", path, err)
+	}
+
+	// write to a temporary file first so that concurrent runs never read a partially written file
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary file for %q: %+v", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing %q: %+v", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing %q: %+v", tmp.Name(), err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("renaming %q to %q: %+v", tmp.Name(), path, err)
+	}
+
+	return nil
+}
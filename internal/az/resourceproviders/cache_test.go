@@ -0,0 +1,243 @@
+package resourceproviders
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/resources/mgmt/resources"
+)
+
+const testResourceProviders = `{
+  "value": [
+    {
+      "namespace": "Microsoft.Compute",
+      "resourceTypes": [
+        {
+          "resourceType": "virtualMachines",
+          "locations": ["Local"]
+        }
+      ]
+    },
+    {
+      "namespace": "Microsoft.Network",
+      "resourceTypes": [
+        {
+          "resourceType": "virtualNetworks",
+          "locations": ["Local", "West US 2"]
+        }
+      ]
+    }
+  ]
+}`
+
+type testStamp struct {
+	Server   *httptest.Server
+	Requests *int32
+}
+
+func newTestStamp(t *testing.T, available bool, metadataLocations []string) testStamp {
+	var requests int32
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/metadata/endpoints":
+			locations := "null"
+			if metadataLocations != nil {
+				locations = fmt.Sprintf(`["%s"]`, strings.Join(metadataLocations, `", "`))
+			}
+			endpoint := strings.TrimPrefix(server.URL, "https://")
+			_, _ = fmt.Fprintf(w, `{"cloudEndpoint": {"stamp": {"endpoint": %q, "locations": %s}}}`, endpoint, locations)
+		case strings.HasSuffix(r.URL.Path, "/providers"):
+			_, _ = w.Write([]byte(testResourceProviders))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return testStamp{
+		Server:   server,
+		Requests: &requests,
+	}
+}
+
+func (s testStamp) providersClient() *resources.ProvidersClient {
+	client := resources.NewProvidersClientWithBaseURI(s.Server.URL, "00000000-0000-0000-0000-000000000000")
+	client.Sender = s.Server.Client()
+	client.RetryAttempts = 1
+	client.RetryDuration = time.Millisecond
+	return &client
+}
+
+func TestCacheSupportedLocationsAndProviders(t *testing.T) {
+	retrievedAt := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	existingCache := func(endpoint string, age time.Duration) *cacheFile {
+		return &cacheFile{
+			ResourceManagerEndpoint: endpoint,
+			RetrievedAt:             retrievedAt.Add(-age),
+			Locations:               []string{"cachedlocation"},
+			ResourceProviders:       []string{"Cached.Provider"},
+		}
+	}
+
+	testData := []struct {
+		Name                      string
+		Available                 bool
+		MetadataLocations         []string
+		UseCacheFile              bool
+		ExistingCache             func(endpoint string) *cacheFile
+		ExpectedRequests          bool
+		ExpectedLocations         *[]string
+		ExpectedResourceProviders *[]string
+		ExpectCacheFileWritten    bool
+	}{
+		{
+			Name:                      "Locations from the Metadata Service",
+			Available:                 true,
+			MetadataLocations:         []string{"local"},
+			ExpectedRequests:          true,
+			ExpectedLocations:         &[]string{"local"},
+			ExpectedResourceProviders: &[]string{"Microsoft.Compute", "Microsoft.Network"},
+		},
+		{
+			Name:                      "Locations from the Resource Types",
+			Available:                 true,
+			ExpectedRequests:          true,
+			ExpectedLocations:         &[]string{"local", "westus2"},
+			ExpectedResourceProviders: &[]string{"Microsoft.Compute", "Microsoft.Network"},
+		},
+		{
+			Name:             "Stamp Unavailable",
+			Available:        false,
+			ExpectedRequests: true,
+		},
+		{
+			Name:                      "Cache File Written",
+			Available:                 true,
+			MetadataLocations:         []string{"local"},
+			UseCacheFile:              true,
+			ExpectedRequests:          true,
+			ExpectedLocations:         &[]string{"local"},
+			ExpectedResourceProviders: &[]string{"Microsoft.Compute", "Microsoft.Network"},
+			ExpectCacheFileWritten:    true,
+		},
+		{
+			Name:              "Recent Cache File",
+			Available:         true,
+			MetadataLocations: []string{"local"},
+			UseCacheFile:      true,
+			ExistingCache: func(endpoint string) *cacheFile {
+				return existingCache(endpoint, time.Hour)
+			},
+			ExpectedRequests:          false,
+			ExpectedLocations:         &[]string{"cachedlocation"},
+			ExpectedResourceProviders: &[]string{"Cached.Provider"},
+		},
+		{
+			Name:              "Expired Cache File",
+			Available:         true,
+			MetadataLocations: []string{"local"},
+			UseCacheFile:      true,
+			ExistingCache: func(endpoint string) *cacheFile {
+				return existingCache(endpoint, 48*time.Hour)
+			},
+			ExpectedRequests:          true,
+			ExpectedLocations:         &[]string{"local"},
+			ExpectedResourceProviders: &[]string{"Microsoft.Compute", "Microsoft.Network"},
+			ExpectCacheFileWritten:    true,
+		},
+		{
+			Name:         "Expired Cache File with the Stamp Unavailable",
+			Available:    false,
+			UseCacheFile: true,
+			ExistingCache: func(endpoint string) *cacheFile {
+				return existingCache(endpoint, 48*time.Hour)
+			},
+			ExpectedRequests:          true,
+			ExpectedLocations:         &[]string{"cachedlocation"},
+			ExpectedResourceProviders: &[]string{"Cached.Provider"},
+		},
+		{
+			Name:              "Cache File for another Stamp",
+			Available:         true,
+			MetadataLocations: []string{"local"},
+			UseCacheFile:      true,
+			ExistingCache: func(_ string) *cacheFile {
+				return existingCache("https://management.other.azurestack.external/", time.Hour)
+			},
+			ExpectedRequests:          true,
+			ExpectedLocations:         &[]string{"local"},
+			ExpectedResourceProviders: &[]string{"Microsoft.Compute", "Microsoft.Network"},
+			ExpectCacheFileWritten:    true,
+		},
+	}
+
+	now = func() time.Time {
+		return retrievedAt
+	}
+	defer func() {
+		now = time.Now
+		cachedLocations = nil
+		cachedResourceProviders = nil
+	}()
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		cachedLocations = nil
+		cachedResourceProviders = nil
+
+		stamp := newTestStamp(t, v.Available, v.MetadataLocations)
+		endpoint := stamp.Server.URL + "/"
+
+		cacheFilePath := ""
+		if v.UseCacheFile {
+			cacheFilePath = filepath.Join(t.TempDir(), "cache", "enhanced-validation.json")
+			if v.ExistingCache != nil {
+				if err := writeCacheFile(cacheFilePath, *v.ExistingCache(endpoint)); err != nil {
+					t.Fatalf("writing existing cache file: %+v", err)
+				}
+			}
+		}
+
+		CacheSupportedLocationsAndProviders(context.TODO(), stamp.Server.Client(), endpoint, stamp.providersClient(), cacheFilePath)
+
+		if requests := atomic.LoadInt32(stamp.Requests); (requests > 0) != v.ExpectedRequests {
+			t.Fatalf("expected requests to be sent to the stamp to be %t but got %d requests", v.ExpectedRequests, requests)
+		}
+		if !reflect.DeepEqual(cachedLocations, v.ExpectedLocations) {
+			t.Fatalf("expected the cached Locations to be %+v but got %+v", v.ExpectedLocations, cachedLocations)
+		}
+		if !reflect.DeepEqual(cachedResourceProviders, v.ExpectedResourceProviders) {
+			t.Fatalf("expected the cached Resource Providers to be %+v but got %+v", v.ExpectedResourceProviders, cachedResourceProviders)
+		}
+
+		if !v.ExpectCacheFileWritten {
+			continue
+		}
+
+		written, err := readCacheFile(cacheFilePath)
+		if err != nil {
+			t.Fatalf("reading cache file: %+v", err)
+		}
+		expected := newCacheFile(endpoint, *v.ExpectedLocations, *v.ExpectedResourceProviders)
+		if written == nil || !reflect.DeepEqual(*written, expected) {
+			t.Fatalf("expected the cache file to contain %+v but got %+v", expected, written)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/features"
 )
@@ -55,3 +56,49 @@ func enhancedValidation(i interface{}, k string) ([]string, []error) {
 
 	return nil, nil
 }
+
+// EnhancedValidateLocation returns a validation function which attempts to validate the Location
+// against the list of Locations supported by this Azure Stack Hub stamp.
+//
+// NOTE: this is best-effort - if the users offline, or the API doesn't return it we'll
+// fall back to the original approach
+func EnhancedValidateLocation(i interface{}, k string) ([]string, []error) {
+	if !enhancedEnabled || cachedLocations == nil {
+		return validation.StringIsNotEmpty(i, k)
+	}
+
+	return enhancedLocationValidation(i, k)
+}
+
+func enhancedLocationValidation(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	normalizedUserInput := location.Normalize(v)
+	if normalizedUserInput == "" {
+		return nil, []error{fmt.Errorf("%q must not be empty", k)}
+	}
+
+	// enhanced validation is unavailable, but we're in this method..
+	if cachedLocations == nil {
+		return nil, nil
+	}
+
+	for _, loc := range *cachedLocations {
+		if normalizedUserInput == location.Normalize(loc) {
+			return nil, nil
+		}
+	}
+
+	// Some resources use a location named "global".
+	if normalizedUserInput == "global" {
+		return nil, nil
+	}
+
+	locationsJoined := strings.Join(*cachedLocations, ", ")
+	return nil, []error{
+		fmt.Errorf("%q was not found in the list of supported Locations: %q", normalizedUserInput, locationsJoined),
+	}
+}
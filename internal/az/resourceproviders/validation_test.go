@@ -84,3 +84,91 @@ func TestEnhancedValidationEnabled(t *testing.T) {
 		}
 	}
 }
+
+func TestEnhancedLocationValidationDisabled(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+	}{
+		{
+			input: "",
+			valid: false,
+		},
+		{
+			input: "local",
+			valid: true,
+		},
+		{
+			input: "westus",
+			valid: true,
+		},
+	}
+	enhancedEnabled = false
+	cachedLocations = &[]string{"local"}
+	defer func() {
+		enhancedEnabled = features.EnhancedValidationEnabled()
+		cachedLocations = nil
+	}()
+
+	for _, testCase := range testCases {
+		t.Logf("Testing %q..", testCase.input)
+
+		warnings, errors := EnhancedValidateLocation(testCase.input, "location")
+		valid := len(warnings) == 0 && len(errors) == 0
+		if testCase.valid != valid {
+			t.Errorf("Expected %t but got %t", testCase.valid, valid)
+		}
+	}
+}
+
+func TestEnhancedLocationValidationEnabled(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+	}{
+		{
+			input: "",
+			valid: false,
+		},
+		{
+			input: "local",
+			valid: true,
+		},
+		{
+			input: "Local",
+			valid: true,
+		},
+		{
+			input: "west us 2",
+			valid: true,
+		},
+		{
+			input: "global",
+			valid: true,
+		},
+		{
+			input: "westus",
+			valid: false,
+		},
+		{
+			input: "lcoal",
+			valid: false,
+		},
+	}
+	enhancedEnabled = true
+	cachedLocations = &[]string{"local", "westus2"}
+	defer func() {
+		enhancedEnabled = features.EnhancedValidationEnabled()
+		cachedLocations = nil
+	}()
+
+	for _, testCase := range testCases {
+		t.Logf("Testing %q..", testCase.input)
+
+		warnings, errors := EnhancedValidateLocation(testCase.input, "location")
+		valid := len(warnings) == 0 && len(errors) == 0
+		if testCase.valid != valid {
+			t.Errorf("Expected %t but got %t", testCase.valid, valid)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package tags
+
+import "strings"
+
+// ProviderConfig is the Provider-level tag configuration, defined using the
+// `default_tags` and `ignore_tags` blocks within the Provider block
+type ProviderConfig struct {
+	// DefaultTags are assigned to every taggable resource, the resource's own
+	// `tags` take precedence where the same key is defined in both
+	DefaultTags map[string]*string
+
+	// IgnoreKeys and IgnoreKeyPrefixes are (case-insensitively) removed from the
+	// tags returned from the API, for example tags assigned by Azure Policy
+	IgnoreKeys        []string
+	IgnoreKeyPrefixes []string
+}
+
+// providerMeta is implemented by the Provider's meta to expose the ProviderConfig
+type providerMeta interface {
+	TagsConfig() ProviderConfig
+}
+
+func providerConfigFromMeta(meta interface{}) ProviderConfig {
+	if v, ok := meta.(providerMeta); ok {
+		return v.TagsConfig()
+	}
+
+	return ProviderConfig{}
+}
+
+// Merge returns the DefaultTags merged with the specified tags, which take precedence
+func (c ProviderConfig) Merge(tagsMap map[string]*string) map[string]*string {
+	output := make(map[string]*string, len(c.DefaultTags)+len(tagsMap))
+
+	for k, v := range c.DefaultTags {
+		output[k] = v
+	}
+	for k, v := range tagsMap {
+		output[k] = v
+	}
+
+	return output
+}
+
+// Ignore returns the specified tags without those matching IgnoreKeys or IgnoreKeyPrefixes
+func (c ProviderConfig) Ignore(tagsMap map[string]*string) map[string]*string {
+	filtered := Filter(tagsMap, c.IgnoreKeys...)
+	if len(c.IgnoreKeyPrefixes) == 0 {
+		return filtered
+	}
+
+	output := make(map[string]*string, len(filtered))
+	for k, v := range filtered {
+		if !hasIgnoredPrefix(k, c.IgnoreKeyPrefixes) {
+			output[k] = v
+		}
+	}
+
+	return output
+}
+
+// RemoveDefaults returns the specified tags without those which are inherited from DefaultTags,
+// unless they're also defined in configured (the resource's own tags)
+func (c ProviderConfig) RemoveDefaults(tagsMap map[string]*string, configured map[string]*string) map[string]*string {
+	output := make(map[string]*string, len(tagsMap))
+
+	for k, v := range tagsMap {
+		if _, ok := configured[k]; !ok {
+			if defaultValue, ok := c.DefaultTags[k]; ok && stringValue(defaultValue) == stringValue(v) {
+				continue
+			}
+		}
+
+		output[k] = v
+	}
+
+	return output
+}
+
+func hasIgnoredPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(strings.ToLower(key), strings.ToLower(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stringValue(input *string) string {
+	if input == nil {
+		return ""
+	}
+
+	return *input
+}
@@ -0,0 +1,194 @@
+package tags
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+)
+
+func TestProviderConfigMerge(t *testing.T) {
+	testData := []struct {
+		Name        string
+		DefaultTags map[string]*string
+		Input       map[string]*string
+		Expected    map[string]interface{}
+	}{
+		{
+			Name:        "No Default Tags",
+			DefaultTags: nil,
+			Input: map[string]*string{
+				"hello": pointer.FromString("there"),
+			},
+			Expected: map[string]interface{}{
+				"hello": "there",
+			},
+		},
+		{
+			Name: "Only Default Tags",
+			DefaultTags: map[string]*string{
+				"cost-centre": pointer.FromString("1234"),
+			},
+			Input: map[string]*string{},
+			Expected: map[string]interface{}{
+				"cost-centre": "1234",
+			},
+		},
+		{
+			Name: "Resource Tags Take Precedence",
+			DefaultTags: map[string]*string{
+				"cost-centre": pointer.FromString("1234"),
+				"owner":       pointer.FromString("platform"),
+			},
+			Input: map[string]*string{
+				"owner": pointer.FromString("networking"),
+			},
+			Expected: map[string]interface{}{
+				"cost-centre": "1234",
+				"owner":       "networking",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		config := ProviderConfig{
+			DefaultTags: v.DefaultTags,
+		}
+		actual := Flatten(config.Merge(v.Input))
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestProviderConfigIgnore(t *testing.T) {
+	input := map[string]*string{
+		"cost-centre":        pointer.FromString("1234"),
+		"CreatedBy":          pointer.FromString("policy"),
+		"hidden-link:/stamp": pointer.FromString("Resource"),
+		"owner":              pointer.FromString("platform"),
+	}
+
+	testData := []struct {
+		Name              string
+		IgnoreKeys        []string
+		IgnoreKeyPrefixes []string
+		Expected          map[string]interface{}
+	}{
+		{
+			Name: "Nothing Ignored",
+			Expected: map[string]interface{}{
+				"cost-centre":        "1234",
+				"CreatedBy":          "policy",
+				"hidden-link:/stamp": "Resource",
+				"owner":              "platform",
+			},
+		},
+		{
+			Name:       "Keys Are Case Insensitive",
+			IgnoreKeys: []string{"createdby"},
+			Expected: map[string]interface{}{
+				"cost-centre":        "1234",
+				"hidden-link:/stamp": "Resource",
+				"owner":              "platform",
+			},
+		},
+		{
+			Name:              "Key Prefixes",
+			IgnoreKeyPrefixes: []string{"Hidden-", ""},
+			Expected: map[string]interface{}{
+				"cost-centre": "1234",
+				"CreatedBy":   "policy",
+				"owner":       "platform",
+			},
+		},
+		{
+			Name:              "Keys And Key Prefixes",
+			IgnoreKeys:        []string{"CreatedBy"},
+			IgnoreKeyPrefixes: []string{"hidden-"},
+			Expected: map[string]interface{}{
+				"cost-centre": "1234",
+				"owner":       "platform",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		config := ProviderConfig{
+			IgnoreKeys:        v.IgnoreKeys,
+			IgnoreKeyPrefixes: v.IgnoreKeyPrefixes,
+		}
+		actual := Flatten(config.Ignore(input))
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestProviderConfigRemoveDefaults(t *testing.T) {
+	config := ProviderConfig{
+		DefaultTags: map[string]*string{
+			"cost-centre": pointer.FromString("1234"),
+			"owner":       pointer.FromString("platform"),
+		},
+	}
+
+	testData := []struct {
+		Name       string
+		Input      map[string]*string
+		Configured map[string]*string
+		Expected   map[string]interface{}
+	}{
+		{
+			Name: "Inherited Tags Removed",
+			Input: map[string]*string{
+				"cost-centre": pointer.FromString("1234"),
+				"owner":       pointer.FromString("platform"),
+				"hello":       pointer.FromString("there"),
+			},
+			Configured: map[string]*string{
+				"hello": pointer.FromString("there"),
+			},
+			Expected: map[string]interface{}{
+				"hello": "there",
+			},
+		},
+		{
+			Name: "Overridden Default Retained",
+			Input: map[string]*string{
+				"cost-centre": pointer.FromString("1234"),
+				"owner":       pointer.FromString("networking"),
+			},
+			Configured: map[string]*string{},
+			Expected: map[string]interface{}{
+				"owner": "networking",
+			},
+		},
+		{
+			Name: "Identical Default Configured On Resource Retained",
+			Input: map[string]*string{
+				"cost-centre": pointer.FromString("1234"),
+				"owner":       pointer.FromString("platform"),
+			},
+			Configured: map[string]*string{
+				"owner": pointer.FromString("platform"),
+			},
+			Expected: map[string]interface{}{
+				"owner": "platform",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := Flatten(config.RemoveDefaults(v.Input, v.Configured))
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+// WrapResource applies the Provider-level tag configuration to a Resource which exposes `tags`:
+//
+// * `tags_all` is added, containing the effective set of tags (populated during the plan via CustomizeDiff)
+// * the `default_tags` are merged into `tags` prior to the Create and Update functions being called
+// * `ignore_tags` and any inherited `default_tags` are removed from `tags` once the Resource has been read
+//
+// as such individual Resources can continue to use Expand and FlattenAndSet as before.
+func WrapResource(resource *pluginsdk.Resource) {
+	if resource == nil || resource.Schema == nil {
+		return
+	}
+	if _, ok := resource.Schema["tags"]; !ok {
+		return
+	}
+	if _, ok := resource.Schema["tags_all"]; ok {
+		return
+	}
+
+	resource.Schema["tags_all"] = SchemaAll()
+
+	if resource.Create != nil {
+		resource.Create = wrapCreateOrUpdate(resource.Create)
+	}
+	if resource.CreateContext != nil {
+		resource.CreateContext = wrapCreateOrUpdateContext(resource.CreateContext)
+	}
+
+	if resource.Read != nil {
+		resource.Read = wrapRead(resource.Read)
+	}
+	if resource.ReadContext != nil {
+		resource.ReadContext = wrapReadContext(resource.ReadContext)
+	}
+
+	supportsUpdate := resource.Update != nil || resource.UpdateContext != nil
+	if resource.Update != nil {
+		resource.Update = wrapCreateOrUpdate(resource.Update)
+	}
+	if resource.UpdateContext != nil {
+		resource.UpdateContext = wrapCreateOrUpdateContext(resource.UpdateContext)
+	}
+
+	customizeDiff := CustomizeDiff
+	if !supportsUpdate {
+		customizeDiff = pluginsdk.CustomDiffInSequence(CustomizeDiff, forceNewWhenTagsAllChange)
+	}
+	if resource.CustomizeDiff != nil {
+		customizeDiff = pluginsdk.CustomDiffInSequence(resource.CustomizeDiff, customizeDiff)
+	}
+	resource.CustomizeDiff = customizeDiff
+}
+
+// CustomizeDiff populates `tags_all` with the effective set of tags for the Resource - that is the
+// Provider's `default_tags` merged with the Resource's `tags`, less any `ignore_tags` - so that
+// the plan shows the tags which will be assigned
+func CustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	config := providerConfigFromMeta(meta)
+	tagsAll := config.Ignore(config.Merge(Expand(d.Get("tags").(map[string]interface{}))))
+	if err := d.SetNew("tags_all", Flatten(tagsAll)); err != nil {
+		return fmt.Errorf("setting `tags_all`: %+v", err)
+	}
+
+	return nil
+}
+
+// forceNewWhenTagsAllChange recreates Resources which can't be updated when their effective set of tags changes
+func forceNewWhenTagsAllChange(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("tags_all") {
+		return nil
+	}
+
+	return d.ForceNew("tags_all")
+}
+
+func wrapCreateOrUpdate(f func(*pluginsdk.ResourceData, interface{}) error) func(*pluginsdk.ResourceData, interface{}) error {
+	return func(d *pluginsdk.ResourceData, meta interface{}) error {
+		config := providerConfigFromMeta(meta)
+		configured := Expand(d.Get("tags").(map[string]interface{}))
+
+		if err := d.Set("tags", Flatten(config.Merge(configured))); err != nil {
+			return fmt.Errorf("setting `tags`: %+v", err)
+		}
+
+		if err := f(d, meta); err != nil {
+			// ensure the inherited tags don't end up in the State if this is partially applied
+			if setErr := d.Set("tags", Flatten(configured)); setErr != nil {
+				return fmt.Errorf("%+v\n\nsetting `tags`: %+v", err, setErr)
+			}
+			return err
+		}
+
+		if d.Id() == "" {
+			return nil
+		}
+
+		return setEffectiveTags(d, config, configured)
+	}
+}
+
+func wrapCreateOrUpdateContext(f func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		wrapped := wrapCreateOrUpdate(func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return diagnosticsAsError(f(ctx, d, meta))
+		})
+		return diag.FromErr(wrapped(d, meta))
+	}
+}
+
+func wrapRead(f func(*pluginsdk.ResourceData, interface{}) error) func(*pluginsdk.ResourceData, interface{}) error {
+	return func(d *pluginsdk.ResourceData, meta interface{}) error {
+		// the tags within the State (or Config, during an Import this'll be empty) are those
+		// defined on the Resource, rather than those inherited from the Provider
+		configured := Expand(d.Get("tags").(map[string]interface{}))
+
+		if err := f(d, meta); err != nil {
+			return err
+		}
+
+		if d.Id() == "" {
+			return nil
+		}
+
+		return setEffectiveTags(d, providerConfigFromMeta(meta), configured)
+	}
+}
+
+func wrapReadContext(f func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		wrapped := wrapRead(func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return diagnosticsAsError(f(ctx, d, meta))
+		})
+		return diag.FromErr(wrapped(d, meta))
+	}
+}
+
+// setEffectiveTags splits the tags returned from the API into `tags_all` and `tags`, removing
+// any `ignore_tags` from both and any inherited `default_tags` from `tags`
+func setEffectiveTags(d *pluginsdk.ResourceData, config ProviderConfig, configured map[string]*string) error {
+	tagsAll := config.Ignore(Expand(d.Get("tags").(map[string]interface{})))
+
+	if err := d.Set("tags_all", Flatten(tagsAll)); err != nil {
+		return fmt.Errorf("setting `tags_all`: %+v", err)
+	}
+
+	return FlattenAndSet(d, config.RemoveDefaults(tagsAll, configured))
+}
+
+func diagnosticsAsError(diags diag.Diagnostics) error {
+	if !diags.HasError() {
+		return nil
+	}
+
+	for _, v := range diags {
+		if v.Severity == diag.Error {
+			if v.Detail != "" {
+				return fmt.Errorf("%s: %s", v.Summary, v.Detail)
+			}
+			return fmt.Errorf("%s", v.Summary)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package tags
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+type testProviderMeta struct {
+	config ProviderConfig
+}
+
+func (m testProviderMeta) TagsConfig() ProviderConfig {
+	return m.config
+}
+
+func TestWrapResource(t *testing.T) {
+	meta := testProviderMeta{
+		config: ProviderConfig{
+			DefaultTags: map[string]*string{
+				"cost-centre": pointer.FromString("1234"),
+				"owner":       pointer.FromString("platform"),
+			},
+			IgnoreKeyPrefixes: []string{"policy-"},
+		},
+	}
+
+	// the tags the API has been sent, which (as for a real API) have a tag added by a Policy
+	var sent map[string]*string
+	read := func(d *pluginsdk.ResourceData, meta interface{}) error {
+		apiTags := map[string]*string{
+			"policy-assignment": pointer.FromString("abc"),
+		}
+		for k, v := range sent {
+			apiTags[k] = v
+		}
+		return FlattenAndSet(d, apiTags)
+	}
+
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": Schema(),
+		},
+		Create: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			sent = Expand(d.Get("tags").(map[string]interface{}))
+			d.SetId("example")
+			return read(d, meta)
+		},
+		Read: read,
+		Delete: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+	WrapResource(resource)
+
+	if _, ok := resource.Schema["tags_all"]; !ok {
+		t.Fatalf("Expected `tags_all` to be added to the Schema")
+	}
+	if resource.CustomizeDiff == nil {
+		t.Fatalf("Expected a CustomizeDiff function to be configured")
+	}
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"owner": "networking",
+		},
+	})
+
+	if err := resource.Create(d, meta); err != nil {
+		t.Fatalf("creating: %+v", err)
+	}
+
+	expectedSent := map[string]interface{}{
+		"cost-centre": "1234",
+		"owner":       "networking",
+	}
+	if actual := Flatten(sent); !reflect.DeepEqual(actual, expectedSent) {
+		t.Fatalf("Expected %+v to be sent but got %+v", expectedSent, actual)
+	}
+
+	expectedTags := map[string]interface{}{
+		"owner": "networking",
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expectedTags) {
+		t.Fatalf("Expected `tags` to be %+v but got %+v", expectedTags, actual)
+	}
+
+	if actual := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(actual, expectedSent) {
+		t.Fatalf("Expected `tags_all` to be %+v but got %+v", expectedSent, actual)
+	}
+
+	if err := resource.Read(d, meta); err != nil {
+		t.Fatalf("reading: %+v", err)
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expectedTags) {
+		t.Fatalf("Expected `tags` to be %+v after a Read but got %+v", expectedTags, actual)
+	}
+}
+
+func TestWrapResourceWithoutTags(t *testing.T) {
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+		},
+	}
+	WrapResource(resource)
+
+	if _, ok := resource.Schema["tags_all"]; ok {
+		t.Fatalf("Expected `tags_all` not to be added to a Resource without `tags`")
+	}
+	if resource.CustomizeDiff != nil {
+		t.Fatalf("Expected no CustomizeDiff function to be configured")
+	}
+}
+
+func TestWrapResourceUpdateWhenOnlyDefaultTagsChange(t *testing.T) {
+	meta := testProviderMeta{
+		config: ProviderConfig{
+			DefaultTags: map[string]*string{
+				"owner": pointer.FromString("networking"),
+			},
+		},
+	}
+
+	var sent map[string]*string
+	read := func(d *pluginsdk.ResourceData, meta interface{}) error {
+		return FlattenAndSet(d, sent)
+	}
+
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": Schema(),
+		},
+		Create: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+		Read: read,
+		Update: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			// since the Resource's `tags` are unchanged, Resources must also check `tags_all` for changes
+			if d.HasChange("tags") {
+				t.Fatalf("Expected `tags` to be unchanged")
+			}
+			if d.HasChanges("tags", "tags_all") {
+				sent = Expand(d.Get("tags").(map[string]interface{}))
+			}
+			return read(d, meta)
+		},
+		Delete: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+	WrapResource(resource)
+
+	// the State of a Resource created when the `owner` Default Tag was `platform`
+	sent = map[string]*string{
+		"environment": pointer.FromString("acctest"),
+		"owner":       pointer.FromString("platform"),
+	}
+	state := &terraform.InstanceState{
+		ID: "example",
+		Attributes: map[string]string{
+			"id":                   "example",
+			"tags.%":               "1",
+			"tags.environment":     "acctest",
+			"tags_all.%":           "2",
+			"tags_all.environment": "acctest",
+			"tags_all.owner":       "platform",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"tags": map[string]interface{}{
+			"environment": "acctest",
+		},
+	})
+
+	diff, err := resource.Diff(context.TODO(), state, config, meta)
+	if err != nil {
+		t.Fatalf("planning: %+v", err)
+	}
+	if diff == nil || diff.Empty() {
+		t.Fatalf("Expected a diff when only the Default Tags change")
+	}
+
+	newState, diags := resource.Apply(context.TODO(), state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("applying: %+v", diags)
+	}
+
+	expectedSent := map[string]interface{}{
+		"environment": "acctest",
+		"owner":       "networking",
+	}
+	if actual := Flatten(sent); !reflect.DeepEqual(actual, expectedSent) {
+		t.Fatalf("Expected %+v to be sent but got %+v", expectedSent, actual)
+	}
+	if actual := newState.Attributes["tags_all.owner"]; actual != "networking" {
+		t.Fatalf("Expected `tags_all.owner` to be %q but got %q", "networking", actual)
+	}
+	if actual := newState.Attributes["tags.%"]; actual != "1" {
+		t.Fatalf("Expected `tags` to contain 1 item but got %s", actual)
+	}
+}
@@ -50,3 +50,15 @@ func SchemaEnforceLowerCaseKeys() *pluginsdk.Schema {
 		},
 	}
 }
+
+// SchemaAll returns the Schema used for `tags_all`, which is the effective set of
+// tags assigned to the resource - including those inherited from the Provider
+func SchemaAll() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeMap,
+		Computed: true,
+		Elem: &pluginsdk.Schema{
+			Type: pluginsdk.TypeString,
+		},
+	}
+}
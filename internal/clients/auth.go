@@ -3,9 +3,13 @@ package clients
 import (
 	"context"
 	"fmt"
+	"log"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-azure-helpers/authentication"
+	authWrapper "github.com/manicminer/hamilton-autorest/auth"
+	"github.com/manicminer/hamilton/auth"
 )
 
 type ResourceManagerAccount struct {
@@ -41,3 +45,46 @@ func NewResourceManagerAccount(ctx context.Context, config authentication.Config
 	}
 	return &account, nil
 }
+
+// objectIdFromTokenClaims returns a function which determines the Object ID of the authenticated Service Principal
+// from the claims within a token acquired using the specified Sender (and as such the Provider's TLS and Proxy
+// settings) - falling back to the default lookup if the claims can't be parsed
+func objectIdFromTokenClaims(config authentication.Config, sender autorest.Sender, oauthConfig *authentication.OAuthConfig, endpoint string, fallback func(ctx context.Context) (*string, error)) func(ctx context.Context) (*string, error) {
+	return func(ctx context.Context) (*string, error) {
+		authorizer, err := config.GetADALToken(ctx, sender, oauthConfig, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("configuring Authorizer: %+v", err)
+		}
+
+		objectId, err := objectIdFromAuthorizer(authorizer)
+		if err != nil {
+			log.Printf("[DEBUG] could not parse objectId from claims, falling back to the default lookup: %v", err)
+			return fallback(ctx)
+		}
+
+		return objectId, nil
+	}
+}
+
+func objectIdFromAuthorizer(authorizer autorest.Authorizer) (*string, error) {
+	wrapper, err := authWrapper.NewAuthorizerWrapper(authorizer)
+	if err != nil {
+		return nil, fmt.Errorf("wrapping autorest.Authorizer: %+v", err)
+	}
+
+	token, err := wrapper.Token()
+	if err != nil {
+		return nil, fmt.Errorf("acquiring access token: %+v", err)
+	}
+
+	claims, err := auth.ParseClaims(token)
+	if err != nil {
+		return nil, fmt.Errorf("parsing claims from access token: %+v", err)
+	}
+
+	if claims.ObjectId == "" {
+		return nil, fmt.Errorf("the access token didn't contain an `oid` claim")
+	}
+
+	return &claims.ObjectId, nil
+}
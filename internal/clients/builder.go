@@ -3,10 +3,12 @@ package clients
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-helpers/authentication"
-	"github.com/hashicorp/go-azure-helpers/sender"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/common"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/features"
 )
@@ -18,22 +20,35 @@ type ClientBuilder struct {
 	SkipProviderRegistration    bool
 	TerraformVersion            string
 	Features                    features.UserFeatures
+	Tags                        tags.ProviderConfig
+	Transport                   common.TransportOptions
+	Retry                       common.RetryOptions
+
+	// UseADFS specifies that tokens should be obtained from the stamp's ADFS Identity Provider, rather than Azure Active Directory
+	UseADFS bool
 }
 
 func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
-	env, err := authentication.AzureEnvironmentByNameFromEndpoint(ctx, builder.AuthConfig.MetadataHost, builder.AuthConfig.Environment)
+	// the TLS and Proxy settings are used for all requests, including Metadata discovery and Authentication
+	httpClient, err := common.BuildHTTPClient(builder.Transport)
 	if err != nil {
-		return nil, fmt.Errorf("determining environment: %v", err)
+		return nil, fmt.Errorf("building HTTP Client: %+v", err)
 	}
 
-	// client declarations:
-	account, err := NewResourceManagerAccount(ctx, *builder.AuthConfig, *env, builder.SkipProviderRegistration)
+	// the HTTP Client is only used by the Sender when TLS or Proxy settings are specified
+	var senderHTTPClient *http.Client
+	if !builder.Transport.IsDefault() {
+		senderHTTPClient = httpClient
+	}
+	sender := common.BuildSender("Azurestack", senderHTTPClient)
+
+	env, err := environmentFromMetadataHost(ctx, httpClient, builder.AuthConfig.MetadataHost, builder.AuthConfig.Environment)
 	if err != nil {
-		return nil, fmt.Errorf("building account: %+v", err)
+		return nil, fmt.Errorf("determining environment: %v", err)
 	}
 
-	client := Client{
-		Account: account,
+	if builder.UseADFS {
+		env.ActiveDirectoryEndpoint = adfsLoginEndpoint(env.ActiveDirectoryEndpoint)
 	}
 
 	oauthConfig, err := builder.AuthConfig.BuildOAuthConfig(env.ActiveDirectoryEndpoint)
@@ -46,7 +61,22 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf("unable to configure OAuthConfig for tenant %s", builder.AuthConfig.TenantID)
 	}
 
-	sender := sender.BuildSender("Azurestack")
+	// the default Object ID lookup doesn't use the TLS and Proxy settings, so is only used as a fallback
+	accountConfig := *builder.AuthConfig
+	if fallback := accountConfig.GetAuthenticatedObjectID; fallback != nil && accountConfig.AuthenticatedAsAServicePrincipal {
+		accountConfig.GetAuthenticatedObjectID = objectIdFromTokenClaims(accountConfig, sender, oauthConfig, env.GraphEndpoint, fallback)
+	}
+
+	// client declarations:
+	account, err := NewResourceManagerAccount(ctx, accountConfig, *env, builder.SkipProviderRegistration)
+	if err != nil {
+		return nil, fmt.Errorf("building account: %+v", err)
+	}
+
+	client := Client{
+		Account: account,
+		Tags:    builder.Tags,
+	}
 
 	// Resource Manager endpoints
 	endpoint := env.ResourceManagerEndpoint
@@ -85,6 +115,9 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		DisableCorrelationRequestID: builder.DisableCorrelationRequestID,
 		CustomCorrelationRequestID:  builder.CustomCorrelationRequestID,
 		Environment:                 *env,
+		Features:                    builder.Features,
+		HTTPClient:                  senderHTTPClient,
+		Retry:                       builder.Retry,
 		TokenFunc: func(endpoint string) (autorest.Authorizer, error) {
 			authorizer, err := builder.AuthConfig.GetADALToken(ctx, sender, oauthConfig, endpoint)
 			if err != nil {
@@ -98,10 +131,9 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf("building Client: %+v", err)
 	}
 
-	/*if features.EnhancedValidationEnabled() {
-		location.CacheSupportedLocations(ctx, env.ResourceManagerEndpoint)
-		resourceproviders.CacheSupportedProviders(ctx, client.Resource.ProvidersClient)
-	}*/
+	if features.EnhancedValidationEnabled() {
+		resourceproviders.CacheSupportedLocationsAndProviders(ctx, httpClient, env.ResourceManagerEndpoint, client.Resource.ProvidersClient, features.EnhancedValidationCacheFile())
+	}
 
 	return &client, nil
 }
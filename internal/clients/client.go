@@ -3,8 +3,8 @@ package clients
 import (
 	"context"
 
-	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/common"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/features"
 	authorization "github.com/hashicorp/terraform-provider-azurestack/internal/services/authorization/client"
@@ -32,16 +32,24 @@ type Client struct {
 	Storage       *storage.Client
 
 	Features features.UserFeatures
+
+	// Tags is the Provider-level tag configuration, applied to all taggable resources
+	Tags tags.ProviderConfig
+}
+
+// TagsConfig exposes the Provider-level tag configuration to the `tags` package
+func (client *Client) TagsConfig() tags.ProviderConfig {
+	return client.Tags
 }
 
 // NOTE: it should be possible for this method to become Private once the top level Client's removed
 
 func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error {
-	autorest.Count429AsRetry = false
 	// Disable the Azure SDK for Go's validation since it's unhelpful for our use-case
 	validation.Disabled = true
 
 	client.StopContext = ctx
+	client.Features = o.Features
 
 	client.Authorization = authorization.NewClient(o)
 	client.Compute = compute.NewClient(o)
@@ -0,0 +1,37 @@
+package clients
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/common"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/features"
+)
+
+func TestClientBuildPropagatesFeatures(t *testing.T) {
+	expected := features.Default()
+	expected.KeyVault.PurgeSoftDeleteOnDestroy = true
+	expected.KeyVault.RecoverSoftDeletedKeyVaults = true
+	expected.ManagedDisk.ExpandWithoutDowntime = true
+	expected.ResourceGroup.PreventDeletionIfContainsResources = true
+	expected.TemplateDeployment.DeleteNestedItemsDuringDeletion = true
+	expected.VirtualMachine.DeleteOSDiskOnDeletion = false
+	expected.VirtualMachineScaleSet.RollInstancesWhenRequired = false
+	expected.VirtualMachineScaleSet.ScaleToZeroOnDelete = false
+
+	o := &common.ClientOptions{
+		ResourceManagerEndpoint: "https://management.local.azurestack.external/",
+		SubscriptionId:          "12345678-1234-9876-4563-123456789012",
+		Features:                expected,
+	}
+
+	client := Client{}
+	if err := client.Build(context.TODO(), o); err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	if !reflect.DeepEqual(client.Features, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, client.Features)
+	}
+}
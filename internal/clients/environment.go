@@ -0,0 +1,103 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+)
+
+// environmentFromMetadataHost determines the Cloud Environment from the Metadata Host, in the same manner
+// as `authentication.AzureEnvironmentByNameFromEndpoint` - but using the specified HTTP Client so that
+// the Provider's TLS and Proxy settings are used (since these endpoints are commonly signed by an internal CA)
+func environmentFromMetadataHost(ctx context.Context, client *http.Client, metadataHost string, environmentName string) (*azure.Environment, error) {
+	switch strings.ToLower(environmentName) {
+	case "public", "usgovernment", "china":
+		return authentication.AzureEnvironmentByNameFromEndpoint(ctx, metadataHost, environmentName)
+	}
+
+	if metadataHost == "" {
+		return nil, fmt.Errorf("unable to locate metadata for environment %q since no custom metadata host has been specified", environmentName)
+	}
+
+	uri := fmt.Sprintf("https://%s/metadata/endpoints?api-version=2020-06-01", metadataHost)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving environments from Azure MetaData service: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("retrieving environments from Azure MetaData service: unexpected status %d", resp.StatusCode)
+	}
+
+	var environments []authentication.Environment
+	if err := json.NewDecoder(resp.Body).Decode(&environments); err != nil {
+		return nil, fmt.Errorf("decoding environments from Azure MetaData service: %+v", err)
+	}
+
+	for _, env := range environments {
+		if strings.EqualFold(env.Name, environmentName) || (environmentName == "" && len(environments) == 1) {
+			// if resourceManager endpoint is empty, assume it's the provided endpoint
+			if env.ResourceManager == "" {
+				env.ResourceManager = fmt.Sprintf("https://%s/", metadataHost)
+			}
+
+			return buildAzureEnvironment(env)
+		}
+	}
+
+	return nil, fmt.Errorf("unable to locate metadata for environment %q from custom metadata host %q", environmentName, metadataHost)
+}
+
+func buildAzureEnvironment(env authentication.Environment) (*azure.Environment, error) {
+	if len(env.Authentication.Audiences) == 0 {
+		return nil, fmt.Errorf("unable to find token audience for environment %q", env.Name)
+	}
+
+	return &azure.Environment{
+		Name:                       env.Name,
+		ResourceManagerEndpoint:    env.ResourceManager,
+		StorageEndpointSuffix:      env.Suffixes.Storage,
+		ActiveDirectoryEndpoint:    env.Authentication.LoginEndpoint,
+		GraphEndpoint:              env.Graph,
+		KeyVaultEndpoint:           fmt.Sprintf("https://%s/", env.Suffixes.KeyVaultDns),
+		GalleryEndpoint:            env.Gallery,
+		BatchManagementEndpoint:    env.Batch,
+		SQLDatabaseDNSSuffix:       env.Suffixes.SqlServerHostname,
+		KeyVaultDNSSuffix:          env.Suffixes.KeyVaultDns,
+		ContainerRegistryDNSSuffix: env.Suffixes.AcrLoginServer,
+		TokenAudience:              env.Authentication.Audiences[0],
+		ResourceIdentifiers: azure.ResourceIdentifier{
+			// This isn't returned from the metadata url and is universal across all environments
+			Storage:             "https://storage.azure.com/",
+			Graph:               env.Graph,
+			KeyVault:            fmt.Sprintf("https://%s/", env.Suffixes.KeyVaultDns),
+			Datalake:            env.ActiveDirectoryDataLake,
+			Batch:               env.Batch,
+			Synapse:             azure.NotAvailable,
+			ServiceBus:          azure.NotAvailable,
+			OperationalInsights: azure.NotAvailable,
+		},
+	}, nil
+}
+
+// adfsLoginEndpoint returns the Login Endpoint for an ADFS Identity Provider - which may be returned from the
+// Metadata Service with or without the `/adfs` suffix - such that tokens are obtained from `{host}/adfs` when
+// combined with the `adfs` Tenant ID
+func adfsLoginEndpoint(input string) string {
+	endpoint := strings.TrimSuffix(input, "/")
+	if strings.HasSuffix(strings.ToLower(endpoint), "/adfs") {
+		endpoint = endpoint[:len(endpoint)-len("/adfs")]
+	}
+	return endpoint + "/"
+}
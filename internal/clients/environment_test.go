@@ -0,0 +1,153 @@
+package clients
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/authentication"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/common"
+)
+
+const testMetadataEndpoints = `[
+  {
+    "name": "AzureStack-User-1789",
+    "resourceManager": "https://management.local.azurestack.external/",
+    "graph": "https://graph.local.azurestack.external/",
+    "authentication": {
+      "loginEndpoint": "https://adfs.local.azurestack.external/adfs",
+      "audiences": ["https://management.adfs.azurestack.local/12345678-1234-9876-4563-123456789012"],
+      "tenant": "adfs",
+      "identityProvider": "ADFS"
+    },
+    "suffixes": {
+      "keyVaultDns": "vault.local.azurestack.external",
+      "storage": "local.azurestack.external"
+    }
+  }
+]`
+
+func TestEnvironmentFromMetadataHostUsesTransportOptions(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata/endpoints" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testMetadataEndpoints))
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parsing %q: %+v", server.URL, err)
+	}
+
+	caCertificate := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	})
+
+	testData := []struct {
+		Name        string
+		Options     common.TransportOptions
+		ShouldError bool
+	}{
+		{
+			Name:        "System Trust Store",
+			Options:     common.TransportOptions{},
+			ShouldError: true,
+		},
+		{
+			Name: "Custom CA Certificate",
+			Options: common.TransportOptions{
+				CACertificates: caCertificate,
+			},
+			ShouldError: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		client, err := common.BuildHTTPClient(v.Options)
+		if err != nil {
+			t.Fatalf("building HTTP Client: %+v", err)
+		}
+
+		env, err := environmentFromMetadataHost(context.TODO(), client, serverUrl.Host, "")
+		if v.ShouldError {
+			if err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+
+		if env.ActiveDirectoryEndpoint != "https://adfs.local.azurestack.external/adfs" {
+			t.Fatalf("Expected the Active Directory Endpoint to be the ADFS endpoint but got %q", env.ActiveDirectoryEndpoint)
+		}
+		if env.KeyVaultDNSSuffix != "vault.local.azurestack.external" {
+			t.Fatalf("Expected the Key Vault DNS Suffix to be %q but got %q", "vault.local.azurestack.external", env.KeyVaultDNSSuffix)
+		}
+		if env.TokenAudience != "https://management.adfs.azurestack.local/12345678-1234-9876-4563-123456789012" {
+			t.Fatalf("Expected the Token Audience to be the first audience but got %q", env.TokenAudience)
+		}
+	}
+}
+
+func TestADFSLoginEndpoint(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "https://adfs.local.azurestack.external/adfs",
+			Expected: "https://adfs.local.azurestack.external/",
+		},
+		{
+			Input:    "https://adfs.local.azurestack.external/adfs/",
+			Expected: "https://adfs.local.azurestack.external/",
+		},
+		{
+			Input:    "https://adfs.local.azurestack.external/ADFS",
+			Expected: "https://adfs.local.azurestack.external/",
+		},
+		{
+			Input:    "https://adfs.local.azurestack.external/",
+			Expected: "https://adfs.local.azurestack.external/",
+		},
+		{
+			Input:    "https://adfs.local.azurestack.external",
+			Expected: "https://adfs.local.azurestack.external/",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual := adfsLoginEndpoint(v.Input)
+		if actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+
+		// tokens should always be obtained from the `/adfs` endpoint, rather than `/adfs/adfs`
+		config := authentication.Config{
+			TenantID: "adfs",
+		}
+		oauthConfig, err := config.BuildOAuthConfig(actual)
+		if err != nil {
+			t.Fatalf("building OAuth Config: %+v", err)
+		}
+
+		expectedTokenEndpoint := "https://adfs.local.azurestack.external/adfs/oauth2/token?api-version=1.0"
+		if tokenEndpoint := oauthConfig.OAuth.TokenEndpoint.String(); tokenEndpoint != expectedTokenEndpoint {
+			t.Fatalf("Expected the Token Endpoint to be %q but got %q", expectedTokenEndpoint, tokenEndpoint)
+		}
+	}
+}
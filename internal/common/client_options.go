@@ -2,12 +2,12 @@ package common
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
-	"github.com/hashicorp/go-azure-helpers/sender"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/features"
 	"github.com/hashicorp/terraform-provider-azurestack/version"
@@ -36,15 +36,33 @@ type ClientOptions struct {
 	Features                    features.UserFeatures
 	StorageUseAzureAD           bool
 
+	// HTTPClient is used for all requests when the Provider's TLS and Proxy settings are specified
+	HTTPClient *http.Client
+
+	// Retry configures how requests which fail with a transient error (or are throttled) are retried
+	Retry RetryOptions
+
 	// Some Dataplane APIs require a token scoped for a specific endpoint
 	TokenFunc func(endpoint string) (autorest.Authorizer, error)
 }
 
+// Sender returns the autorest.Sender which should be used for all requests, which retries requests
+// according to the `retry` block
+func (o ClientOptions) Sender() autorest.Sender {
+	return autorest.DecorateSender(BuildSender("Azurestack", o.HTTPClient), WithRetries(o.Retry))
+}
+
 func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.Authorizer) {
 	setUserAgent(c, o.TerraformVersion, o.PartnerId, o.DisableTerraformPartnerID)
 
 	c.Authorizer = authorizer
-	c.Sender = sender.BuildSender("Azurestack")
+	c.Sender = o.Sender()
+
+	// requests are retried by the Sender using the `retry` block - which is also used when polling Long Running
+	// Operations. As such the SDK's `DoRetryWithRegistration` decorator (which retries the status codes defined
+	// in autorest) is replaced with no decorators, since the Resource Providers this Provider requires are
+	// instead registered when the Provider is configured.
+	c.SendDecorators = []autorest.SendDecorator{}
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
 	if !o.DisableCorrelationRequestID {
 		id := o.CustomCorrelationRequestID
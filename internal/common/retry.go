@@ -0,0 +1,176 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// RetryOptions configures how requests which fail with a transient error are retried
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of times a request is sent, where 0 or 1 disables retries
+	MaxAttempts int
+
+	// MinBackoff and MaxBackoff bound the exponential backoff used between attempts
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// RetryOnThrottling retries requests which are throttled (HTTP 429), honouring the `Retry-After` header
+	RetryOnThrottling bool
+
+	// RetryableStatusCodes are the additional HTTP Status Codes which should be retried
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryOptions returns the RetryOptions used when the `retry` block isn't specified
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxAttempts:       3,
+		MinBackoff:        1 * time.Second,
+		MaxBackoff:        60 * time.Second,
+		RetryOnThrottling: true,
+		RetryableStatusCodes: []int{
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithRetries returns a SendDecorator which retries requests according to the specified RetryOptions
+func WithRetries(o RetryOptions) autorest.SendDecorator {
+	return withRetries(o, sleepWithContext)
+}
+
+func withRetries(o RetryOptions, sleep func(ctx context.Context, d time.Duration) error) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		if o.MaxAttempts <= 1 {
+			return s
+		}
+
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			var resp *http.Response
+			var err error
+
+			// the request body needs to be re-readable for each attempt
+			rr := autorest.NewRetriableRequest(r)
+			for attempt := 1; ; attempt++ {
+				if err = rr.Prepare(); err != nil {
+					return resp, err
+				}
+
+				resp, err = s.Do(rr.Request())
+				if attempt >= o.MaxAttempts || !o.shouldRetry(resp, err) {
+					return resp, err
+				}
+
+				delay := o.delay(resp, attempt)
+				if resp != nil {
+					log.Printf("[DEBUG] Request to %s returned %d - retrying in %s (attempt %d of %d)", r.URL, resp.StatusCode, delay, attempt, o.MaxAttempts)
+				} else {
+					log.Printf("[DEBUG] Request to %s failed with %+v - retrying in %s (attempt %d of %d)", r.URL, err, delay, attempt, o.MaxAttempts)
+				}
+
+				// the response is discarded, so ensure the connection can be reused
+				autorest.Respond(resp, autorest.ByDiscardingBody(), autorest.ByClosing())
+
+				if sleepErr := sleep(r.Context(), delay); sleepErr != nil {
+					return nil, sleepErr
+				}
+			}
+		})
+	}
+}
+
+func (o RetryOptions) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		// a cancelled or timed out request shouldn't be retried
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	if resp == nil {
+		return false
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return o.RetryOnThrottling
+	}
+
+	for _, v := range o.RetryableStatusCodes {
+		if resp.StatusCode == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// delay returns how long to wait before the next attempt, which is the `Retry-After` header when
+// present (as sent when throttled) - otherwise an exponential backoff between MinBackoff and MaxBackoff.
+// In either case this is capped at MaxBackoff.
+func (o RetryOptions) delay(resp *http.Response, attempt int) time.Duration {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if o.MaxBackoff > 0 && retryAfter > o.MaxBackoff {
+				return o.MaxBackoff
+			}
+			return retryAfter
+		}
+	}
+
+	delay := o.MinBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= o.MaxBackoff {
+			return o.MaxBackoff
+		}
+	}
+
+	if o.MaxBackoff > 0 && delay > o.MaxBackoff {
+		return o.MaxBackoff
+	}
+
+	return delay
+}
+
+// parseRetryAfter parses the `Retry-After` header, which is either a number of seconds or a HTTP Date
+func parseRetryAfter(input string) (time.Duration, bool) {
+	if input == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(input); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(input); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,489 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+type stubResponse struct {
+	StatusCode int
+	RetryAfter string
+}
+
+// newThrottlingTestServer returns a server which returns each of the responses in turn, returning
+// the last response for any further requests - alongside the bodies of the requests it received
+func newThrottlingTestServer(t *testing.T, responses []stubResponse) (*httptest.Server, func() []string) {
+	var lock sync.Mutex
+	bodies := make([]string, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		response := responses[len(responses)-1]
+		if len(bodies) <= len(responses) {
+			response = responses[len(bodies)-1]
+		}
+		if response.RetryAfter != "" {
+			w.Header().Set("Retry-After", response.RetryAfter)
+		}
+		w.WriteHeader(response.StatusCode)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return bodies
+	}
+}
+
+func TestWithRetries(t *testing.T) {
+	testData := []struct {
+		Name               string
+		Options            RetryOptions
+		Responses          []stubResponse
+		ExpectedStatusCode int
+		ExpectedAttempts   int
+		ExpectedDelays     []time.Duration
+	}{
+		{
+			Name:    "Success",
+			Options: DefaultRetryOptions(),
+			Responses: []stubResponse{
+				{StatusCode: http.StatusOK},
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedAttempts:   1,
+			ExpectedDelays:     []time.Duration{},
+		},
+		{
+			Name:    "Throttled with Retry-After",
+			Options: DefaultRetryOptions(),
+			Responses: []stubResponse{
+				{StatusCode: http.StatusTooManyRequests, RetryAfter: "7"},
+				{StatusCode: http.StatusOK},
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedAttempts:   2,
+			ExpectedDelays:     []time.Duration{7 * time.Second},
+		},
+		{
+			Name:    "Throttled with Retry-After greater than the Max Backoff",
+			Options: DefaultRetryOptions(),
+			Responses: []stubResponse{
+				{StatusCode: http.StatusTooManyRequests, RetryAfter: "3600"},
+				{StatusCode: http.StatusOK},
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedAttempts:   2,
+			ExpectedDelays:     []time.Duration{60 * time.Second},
+		},
+		{
+			Name:    "Throttled without Retry-After",
+			Options: DefaultRetryOptions(),
+			Responses: []stubResponse{
+				{StatusCode: http.StatusTooManyRequests},
+				{StatusCode: http.StatusTooManyRequests},
+				{StatusCode: http.StatusOK},
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedAttempts:   3,
+			ExpectedDelays:     []time.Duration{1 * time.Second, 2 * time.Second},
+		},
+		{
+			Name: "Throttled with Retries on Throttling Disabled",
+			Options: RetryOptions{
+				MaxAttempts:       3,
+				MinBackoff:        1 * time.Second,
+				MaxBackoff:        60 * time.Second,
+				RetryOnThrottling: false,
+			},
+			Responses: []stubResponse{
+				{StatusCode: http.StatusTooManyRequests, RetryAfter: "1"},
+				{StatusCode: http.StatusOK},
+			},
+			ExpectedStatusCode: http.StatusTooManyRequests,
+			ExpectedAttempts:   1,
+			ExpectedDelays:     []time.Duration{},
+		},
+		{
+			Name:    "Retryable Status Code Exhausts Attempts",
+			Options: DefaultRetryOptions(),
+			Responses: []stubResponse{
+				{StatusCode: http.StatusServiceUnavailable},
+			},
+			ExpectedStatusCode: http.StatusServiceUnavailable,
+			ExpectedAttempts:   3,
+			ExpectedDelays:     []time.Duration{1 * time.Second, 2 * time.Second},
+		},
+		{
+			Name: "Backoff is capped at the Max Backoff",
+			Options: RetryOptions{
+				MaxAttempts:          5,
+				MinBackoff:           2 * time.Second,
+				MaxBackoff:           5 * time.Second,
+				RetryableStatusCodes: []int{http.StatusBadGateway},
+			},
+			Responses: []stubResponse{
+				{StatusCode: http.StatusBadGateway},
+			},
+			ExpectedStatusCode: http.StatusBadGateway,
+			ExpectedAttempts:   5,
+			ExpectedDelays:     []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		{
+			Name:    "Non-Retryable Status Code",
+			Options: DefaultRetryOptions(),
+			Responses: []stubResponse{
+				{StatusCode: http.StatusBadRequest},
+				{StatusCode: http.StatusOK},
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+			ExpectedAttempts:   1,
+			ExpectedDelays:     []time.Duration{},
+		},
+		{
+			Name: "Retries Disabled",
+			Options: RetryOptions{
+				MaxAttempts:       1,
+				RetryOnThrottling: true,
+			},
+			Responses: []stubResponse{
+				{StatusCode: http.StatusTooManyRequests},
+				{StatusCode: http.StatusOK},
+			},
+			ExpectedStatusCode: http.StatusTooManyRequests,
+			ExpectedAttempts:   1,
+			ExpectedDelays:     []time.Duration{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		server, requestBodies := newThrottlingTestServer(t, v.Responses)
+
+		delays := make([]time.Duration, 0)
+		sleep := func(_ context.Context, d time.Duration) error {
+			delays = append(delays, d)
+			return nil
+		}
+		sender := withRetries(v.Options, sleep)(server.Client())
+
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("hello"))
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		resp, err := sender.Do(req)
+		if err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != v.ExpectedStatusCode {
+			t.Fatalf("expected status code %d but got %d", v.ExpectedStatusCode, resp.StatusCode)
+		}
+
+		bodies := requestBodies()
+		if len(bodies) != v.ExpectedAttempts {
+			t.Fatalf("expected %d attempts but got %d", v.ExpectedAttempts, len(bodies))
+		}
+		for i, body := range bodies {
+			if body != "hello" {
+				t.Fatalf("expected the body of attempt %d to be %q but got %q", i+1, "hello", body)
+			}
+		}
+
+		if len(delays) != len(v.ExpectedDelays) {
+			t.Fatalf("expected %d delays but got %d (%+v)", len(v.ExpectedDelays), len(delays), delays)
+		}
+		for i := range delays {
+			if delays[i] != v.ExpectedDelays[i] {
+				t.Fatalf("expected delay %d to be %s but got %s", i+1, v.ExpectedDelays[i], delays[i])
+			}
+		}
+	}
+}
+
+// TestConfigureClientRetries sends requests in the same manner as the Azure SDK, to ensure that the `retry`
+// block is the only layer which retries requests
+func TestConfigureClientRetries(t *testing.T) {
+	testData := []struct {
+		Name               string
+		Options            RetryOptions
+		Responses          []stubResponse
+		ExpectedStatusCode int
+		ExpectedAttempts   int
+	}{
+		{
+			Name: "Retryable Status Code Exhausts Attempts",
+			Options: RetryOptions{
+				MaxAttempts:          3,
+				MinBackoff:           time.Millisecond,
+				MaxBackoff:           time.Millisecond,
+				RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+			},
+			Responses: []stubResponse{
+				{StatusCode: http.StatusServiceUnavailable},
+			},
+			ExpectedStatusCode: http.StatusServiceUnavailable,
+			ExpectedAttempts:   3,
+		},
+		{
+			Name: "Status Code retried by autorest but not by the Provider",
+			Options: RetryOptions{
+				MaxAttempts:          3,
+				MinBackoff:           time.Millisecond,
+				MaxBackoff:           time.Millisecond,
+				RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+			},
+			Responses: []stubResponse{
+				{StatusCode: http.StatusBadGateway},
+				{StatusCode: http.StatusOK},
+			},
+			ExpectedStatusCode: http.StatusBadGateway,
+			ExpectedAttempts:   1,
+		},
+		{
+			Name: "Throttled with Retries on Throttling Disabled",
+			Options: RetryOptions{
+				MaxAttempts:       3,
+				MinBackoff:        time.Millisecond,
+				MaxBackoff:        time.Millisecond,
+				RetryOnThrottling: false,
+			},
+			Responses: []stubResponse{
+				{StatusCode: http.StatusTooManyRequests, RetryAfter: "0"},
+			},
+			ExpectedStatusCode: http.StatusTooManyRequests,
+			ExpectedAttempts:   1,
+		},
+		{
+			Name: "Throttled Exhausts Attempts",
+			Options: RetryOptions{
+				MaxAttempts:       4,
+				MinBackoff:        time.Millisecond,
+				MaxBackoff:        time.Millisecond,
+				RetryOnThrottling: true,
+			},
+			Responses: []stubResponse{
+				{StatusCode: http.StatusTooManyRequests, RetryAfter: "1"},
+			},
+			ExpectedStatusCode: http.StatusTooManyRequests,
+			ExpectedAttempts:   4,
+		},
+		{
+			Name: "Retries Disabled",
+			Options: RetryOptions{
+				MaxAttempts:          1,
+				RetryOnThrottling:    true,
+				RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+			},
+			Responses: []stubResponse{
+				{StatusCode: http.StatusServiceUnavailable},
+				{StatusCode: http.StatusOK},
+			},
+			ExpectedStatusCode: http.StatusServiceUnavailable,
+			ExpectedAttempts:   1,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		server, requestBodies := newThrottlingTestServer(t, v.Responses)
+
+		options := ClientOptions{
+			HTTPClient:                  server.Client(),
+			Retry:                       v.Options,
+			DisableCorrelationRequestID: true,
+		}
+		client := autorest.NewClientWithUserAgent("")
+		options.ConfigureClient(&client, autorest.NullAuthorizer{})
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, server.URL, strings.NewReader("hello"))
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		resp, err := client.Send(req, azure.DoRetryWithRegistration(client))
+		if err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != v.ExpectedStatusCode {
+			t.Fatalf("expected status code %d but got %d", v.ExpectedStatusCode, resp.StatusCode)
+		}
+		if attempts := len(requestBodies()); attempts != v.ExpectedAttempts {
+			t.Fatalf("expected %d requests to be sent but got %d", v.ExpectedAttempts, attempts)
+		}
+	}
+}
+
+// TestConfigureClientRetriesLongRunningOperation ensures that requests made whilst polling a Long Running
+// Operation (which don't use the client's SendDecorators) are also retried using the `retry` block
+func TestConfigureClientRetriesLongRunningOperation(t *testing.T) {
+	var lock sync.Mutex
+	pollAttempts := 0
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		switch {
+		case r.Method == http.MethodPut:
+			w.Header().Set("Azure-AsyncOperation", server.URL+"/operation")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"properties":{"provisioningState":"Creating"}}`))
+
+		case r.URL.Path == "/operation":
+			// throttle more times than autorest retries a failed poll
+			pollAttempts++
+			if pollAttempts <= 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"status":"Succeeded"}`))
+
+		default:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"properties":{"provisioningState":"Succeeded"}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	options := ClientOptions{
+		HTTPClient: server.Client(),
+		Retry: RetryOptions{
+			MaxAttempts:       4,
+			MinBackoff:        time.Millisecond,
+			MaxBackoff:        time.Millisecond,
+			RetryOnThrottling: true,
+		},
+		DisableCorrelationRequestID: true,
+	}
+	client := autorest.NewClientWithUserAgent("")
+	options.ConfigureClient(&client, autorest.NullAuthorizer{})
+	client.PollingDelay = time.Millisecond
+	client.RetryDuration = time.Millisecond
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPut, server.URL+"/resource", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Send(req, azure.DoRetryWithRegistration(client))
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	future, err := azure.NewFutureFromResponse(resp)
+	if err != nil {
+		t.Fatalf("building future: %+v", err)
+	}
+	if err := future.WaitForCompletionRef(context.TODO(), client); err != nil {
+		t.Fatalf("waiting for completion: %+v", err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if pollAttempts != 4 {
+		t.Fatalf("expected the operation to be polled 4 times but got %d", pollAttempts)
+	}
+}
+
+func TestWithRetriesCancelled(t *testing.T) {
+	server, requestBodies := newThrottlingTestServer(t, []stubResponse{
+		{StatusCode: http.StatusTooManyRequests, RetryAfter: "60"},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	resp, err := WithRetries(DefaultRetryOptions())(server.Client()).Do(req)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	if len(requestBodies()) != 0 {
+		t.Fatalf("expected no requests to be sent but got %d", len(requestBodies()))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	testData := []struct {
+		Input      string
+		Expected   time.Duration
+		ShouldFail bool
+	}{
+		{
+			Input:      "",
+			ShouldFail: true,
+		},
+		{
+			Input:    "0",
+			Expected: 0,
+		},
+		{
+			Input:    "30",
+			Expected: 30 * time.Second,
+		},
+		{
+			Input:      "-1",
+			ShouldFail: true,
+		},
+		{
+			Input:      "soon",
+			ShouldFail: true,
+		},
+		{
+			// dates in the past mean the request can be retried immediately
+			Input:    "Wed, 21 Oct 2015 07:28:00 GMT",
+			Expected: 0,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, ok := parseRetryAfter(v.Input)
+		if v.ShouldFail {
+			if ok {
+				t.Fatalf("expected parsing %q to fail but got %s", v.Input, actual)
+			}
+			continue
+		}
+
+		if !ok {
+			t.Fatalf("expected parsing %q to succeed but it failed", v.Input)
+		}
+		if actual != v.Expected {
+			t.Fatalf("expected %s but got %s", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-helpers/sender"
+)
+
+// TransportOptions are the TLS and Proxy settings used for all connections made by the Provider,
+// which allows connecting to Azure Stack Hub stamps whose endpoints are signed by an internal CA
+type TransportOptions struct {
+	// CACertificates are PEM encoded CA Certificates which are trusted in addition to the system trust store
+	CACertificates []byte
+
+	// TLSMinVersion is the minimum TLS version which should be used, when zero Go's default is used
+	TLSMinVersion uint16
+
+	// ProxyURL overrides the Proxy defined in the `HTTP_PROXY`/`HTTPS_PROXY` environment variables
+	ProxyURL *url.URL
+}
+
+// BuildHTTPClient returns a HTTP Client configured using the specified TransportOptions
+func BuildHTTPClient(o TransportOptions) (*http.Client, error) {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+	}
+
+	if o.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(o.ProxyURL)
+	}
+
+	if len(o.CACertificates) > 0 || o.TLSMinVersion != 0 {
+		tlsConfig := &tls.Config{
+			MinVersion: o.TLSMinVersion,
+		}
+
+		if len(o.CACertificates) > 0 {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				log.Printf("[DEBUG] Unable to load the system trust store, only the CA Certificates specified will be trusted: %+v", err)
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(o.CACertificates) {
+				return nil, fmt.Errorf("no PEM encoded certificates were found in the CA Certificates")
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{
+		Transport: transport,
+	}, nil
+}
+
+// IsDefault returns whether no TransportOptions have been specified
+func (o TransportOptions) IsDefault() bool {
+	return len(o.CACertificates) == 0 && o.TLSMinVersion == 0 && o.ProxyURL == nil
+}
+
+// BuildSender returns an autorest.Sender which logs each request. When no HTTP Client is specified the Sender
+// from go-azure-helpers is used - otherwise the specified HTTP Client is used, since go-azure-helpers' Sender
+// doesn't allow the HTTP Client to be specified.
+func BuildSender(providerName string, client *http.Client) autorest.Sender {
+	if client == nil {
+		return sender.BuildSender(providerName)
+	}
+
+	logger := log.New(log.Writer(), fmt.Sprintf("[DEBUG] %s ", providerName), log.Flags())
+	return autorest.DecorateSender(client, autorest.WithLogging(logger))
+}
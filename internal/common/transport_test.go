@@ -0,0 +1,187 @@
+package common
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newTestTLSServer(t *testing.T, maxVersion uint16) (*httptest.Server, []byte) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		MaxVersion: maxVersion,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caCertificate := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	})
+	return server, caCertificate
+}
+
+func TestBuildHTTPClient(t *testing.T) {
+	server, caCertificate := newTestTLSServer(t, tls.VersionTLS12)
+
+	testData := []struct {
+		Name        string
+		Options     TransportOptions
+		ShouldError bool
+	}{
+		{
+			Name:        "System Trust Store",
+			Options:     TransportOptions{},
+			ShouldError: true,
+		},
+		{
+			Name: "Custom CA Certificate",
+			Options: TransportOptions{
+				CACertificates: caCertificate,
+			},
+			ShouldError: false,
+		},
+		{
+			Name: "Custom CA Certificate with TLS 1.2",
+			Options: TransportOptions{
+				CACertificates: caCertificate,
+				TLSMinVersion:  tls.VersionTLS12,
+			},
+			ShouldError: false,
+		},
+		{
+			Name: "Custom CA Certificate with TLS 1.3 unsupported by the Server",
+			Options: TransportOptions{
+				CACertificates: caCertificate,
+				TLSMinVersion:  tls.VersionTLS13,
+			},
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		client, err := BuildHTTPClient(v.Options)
+		if err != nil {
+			t.Fatalf("building HTTP Client: %+v", err)
+		}
+
+		resp, err := client.Get(server.URL)
+		if v.ShouldError {
+			if err == nil {
+				resp.Body.Close()
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+		resp.Body.Close()
+	}
+}
+
+func TestBuildHTTPClientInvalidCACertificate(t *testing.T) {
+	_, err := BuildHTTPClient(TransportOptions{
+		CACertificates: []byte("not a certificate"),
+	})
+	if err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+}
+
+func TestBuildHTTPClientProxyURL(t *testing.T) {
+	proxyUrl, _ := url.Parse("http://proxy.example.com:3128")
+	client, err := BuildHTTPClient(TransportOptions{
+		ProxyURL: proxyUrl,
+	})
+	if err != nil {
+		t.Fatalf("building HTTP Client: %+v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://management.local.azurestack.external", nil)
+	actual, err := client.Transport.(*http.Transport).Proxy(req)
+	if err != nil {
+		t.Fatalf("determining Proxy: %+v", err)
+	}
+	if actual == nil || actual.String() != proxyUrl.String() {
+		t.Fatalf("Expected the Proxy to be %q but got %v", proxyUrl.String(), actual)
+	}
+}
+
+func TestSenderUsesHTTPClient(t *testing.T) {
+	server, caCertificate := newTestTLSServer(t, tls.VersionTLS13)
+
+	client, err := BuildHTTPClient(TransportOptions{
+		CACertificates: caCertificate,
+	})
+	if err != nil {
+		t.Fatalf("building HTTP Client: %+v", err)
+	}
+
+	o := ClientOptions{
+		HTTPClient: client,
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := o.Sender().Do(req)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestBuildSender(t *testing.T) {
+	server, caCertificate := newTestTLSServer(t, tls.VersionTLS12)
+
+	client, err := BuildHTTPClient(TransportOptions{
+		CACertificates: caCertificate,
+	})
+	if err != nil {
+		t.Fatalf("building HTTP Client: %+v", err)
+	}
+
+	testData := []struct {
+		Name        string
+		Client      *http.Client
+		ShouldError bool
+	}{
+		{
+			Name:        "Default Sender",
+			Client:      nil,
+			ShouldError: true,
+		},
+		{
+			Name:        "Custom HTTP Client",
+			Client:      client,
+			ShouldError: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		resp, err := BuildSender("Azurestack", v.Client).Do(req)
+		if v.ShouldError {
+			if err == nil {
+				resp.Body.Close()
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		resp.Body.Close()
+	}
+}
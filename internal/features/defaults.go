@@ -3,10 +3,23 @@ package features
 func Default() UserFeatures {
 	return UserFeatures{
 		// NOTE: ensure all nested objects are fully populated
+		KeyVault: KeyVaultFeatures{
+			// soft delete is disabled on Key Vaults created by this provider (see `azurestack_key_vault`)
+			// so purging/recovering is opt-in rather than the default
+			PurgeSoftDeleteOnDestroy:    false,
+			RecoverSoftDeletedKeyVaults: false,
+		},
+		ManagedDisk: ManagedDiskFeatures{
+			ExpandWithoutDowntime: false,
+		},
 		ResourceGroup: ResourceGroupFeatures{
 			PreventDeletionIfContainsResources: false,
 		},
+		TemplateDeployment: TemplateDeploymentFeatures{
+			DeleteNestedItemsDuringDeletion: false,
+		},
 		VirtualMachine: VirtualMachineFeatures{
+			DeleteDataDisksOnDeletion:  true,
 			DeleteOSDiskOnDeletion:     true,
 			GracefulShutdown:           false,
 			SkipShutdownAndForceDelete: false,
@@ -21,3 +21,13 @@ func EnhancedValidationEnabled() bool {
 
 	return strings.EqualFold(value, "true")
 }
+
+// EnhancedValidationCacheFile returns the path to the file used to cache the supported Locations and
+// Resource Providers for Enhanced Validation - which allows plans in air-gapped environments to be
+// validated without querying the Azure Stack Hub stamp each time.
+//
+// This is opt-in, and can be enabled by setting the Environment Variable
+// `ARM_PROVIDER_ENHANCED_VALIDATION_CACHE_FILE` to the path of the cache file.
+func EnhancedValidationCacheFile() string {
+	return os.Getenv("ARM_PROVIDER_ENHANCED_VALIDATION_CACHE_FILE")
+}
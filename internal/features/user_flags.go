@@ -1,16 +1,33 @@
 package features
 
 type UserFeatures struct {
+	KeyVault               KeyVaultFeatures
+	ManagedDisk            ManagedDiskFeatures
 	ResourceGroup          ResourceGroupFeatures
+	TemplateDeployment     TemplateDeploymentFeatures
 	VirtualMachine         VirtualMachineFeatures
 	VirtualMachineScaleSet VirtualMachineScaleSetFeatures
 }
 
+type KeyVaultFeatures struct {
+	PurgeSoftDeleteOnDestroy    bool
+	RecoverSoftDeletedKeyVaults bool
+}
+
+type ManagedDiskFeatures struct {
+	ExpandWithoutDowntime bool
+}
+
 type ResourceGroupFeatures struct {
 	PreventDeletionIfContainsResources bool
 }
 
+type TemplateDeploymentFeatures struct {
+	DeleteNestedItemsDuringDeletion bool
+}
+
 type VirtualMachineFeatures struct {
+	DeleteDataDisksOnDeletion  bool
 	DeleteOSDiskOnDeletion     bool
 	GracefulShutdown           bool
 	SkipShutdownAndForceDelete bool
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	identityProviderAzureActiveDirectory = "aad"
+	identityProviderADFS                 = "adfs"
+
+	// adfsTenantID is the Tenant ID used to obtain tokens from an ADFS Identity Provider
+	adfsTenantID = "adfs"
+)
+
+type authMethod string
+
+const (
+	authMethodClientCertificate      authMethod = "Client Certificate"
+	authMethodClientSecret           authMethod = "Client Secret"
+	authMethodManagedServiceIdentity authMethod = "Managed Service Identity"
+	authMethodAzureCli               authMethod = "Azure CLI"
+)
+
+type authConfig struct {
+	ClientCertPath     string
+	ClientSecret       string
+	UseMSI             bool
+	UseCLI             bool
+	IdentityProvider   string
+	TenantID           string
+	AuxiliaryTenantIDs []string
+}
+
+// selectAuthMethod determines which authentication method should be used, since the authentication
+// builder silently picks the first applicable method - at most one method can be configured, with
+// the Azure CLI used when no other method is configured
+func selectAuthMethod(input authConfig) (*authMethod, error) {
+	configured := make([]string, 0)
+	methods := make([]authMethod, 0)
+	if input.ClientCertPath != "" {
+		configured = append(configured, "`client_certificate_path`")
+		methods = append(methods, authMethodClientCertificate)
+	}
+	if input.ClientSecret != "" {
+		configured = append(configured, "`client_secret`")
+		methods = append(methods, authMethodClientSecret)
+	}
+	if input.UseMSI {
+		configured = append(configured, "`use_msi`")
+		methods = append(methods, authMethodManagedServiceIdentity)
+	}
+
+	if len(methods) > 1 {
+		return nil, fmt.Errorf("only one authentication method can be configured but %s were specified - please specify only one of `client_certificate_path`, `client_secret` or `use_msi`", strings.Join(configured, ", "))
+	}
+
+	if len(methods) == 0 {
+		if !input.UseCLI {
+			return nil, fmt.Errorf("no authentication method was configured - either specify one of `client_certificate_path`, `client_secret` or `use_msi`, or enable `use_cli` to authenticate using the Azure CLI")
+		}
+
+		method := authMethodAzureCli
+		return &method, nil
+	}
+
+	return &methods[0], nil
+}
+
+// expandTenantID returns the Tenant ID which should be used to obtain tokens for the specified Identity Provider
+func expandTenantID(input authConfig) (string, error) {
+	if !strings.EqualFold(input.IdentityProvider, identityProviderADFS) {
+		return input.TenantID, nil
+	}
+
+	if input.TenantID != "" && !strings.EqualFold(input.TenantID, adfsTenantID) {
+		return "", fmt.Errorf("`tenant_id` must be either omitted or set to %q when `identity_provider` is %q but got %q", adfsTenantID, identityProviderADFS, input.TenantID)
+	}
+
+	if len(input.AuxiliaryTenantIDs) > 0 {
+		return "", fmt.Errorf("`auxiliary_tenant_ids` cannot be specified when `identity_provider` is %q", identityProviderADFS)
+	}
+
+	return adfsTenantID, nil
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestSelectAuthMethod(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Input       authConfig
+		Expected    authMethod
+		ShouldError bool
+	}{
+		{
+			Name: "Azure CLI",
+			Input: authConfig{
+				UseCLI: true,
+			},
+			Expected: authMethodAzureCli,
+		},
+		{
+			Name:        "Azure CLI Disabled",
+			Input:       authConfig{},
+			ShouldError: true,
+		},
+		{
+			Name: "Client Certificate",
+			Input: authConfig{
+				ClientCertPath: "/path/to/cert.pfx",
+				UseCLI:         true,
+			},
+			Expected: authMethodClientCertificate,
+		},
+		{
+			Name: "Client Secret",
+			Input: authConfig{
+				ClientSecret: "secret",
+				UseCLI:       true,
+			},
+			Expected: authMethodClientSecret,
+		},
+		{
+			Name: "Client Secret with Azure CLI Disabled",
+			Input: authConfig{
+				ClientSecret: "secret",
+			},
+			Expected: authMethodClientSecret,
+		},
+		{
+			Name: "Managed Service Identity",
+			Input: authConfig{
+				UseMSI: true,
+				UseCLI: true,
+			},
+			Expected: authMethodManagedServiceIdentity,
+		},
+		{
+			Name: "Managed Service Identity using ADFS",
+			Input: authConfig{
+				UseMSI:           true,
+				IdentityProvider: identityProviderADFS,
+			},
+			Expected: authMethodManagedServiceIdentity,
+		},
+		{
+			Name: "Client Certificate and Client Secret",
+			Input: authConfig{
+				ClientCertPath: "/path/to/cert.pfx",
+				ClientSecret:   "secret",
+				UseCLI:         true,
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "Client Certificate and Managed Service Identity",
+			Input: authConfig{
+				ClientCertPath: "/path/to/cert.pfx",
+				UseMSI:         true,
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "Client Secret and Managed Service Identity",
+			Input: authConfig{
+				ClientSecret: "secret",
+				UseMSI:       true,
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "All Methods",
+			Input: authConfig{
+				ClientCertPath: "/path/to/cert.pfx",
+				ClientSecret:   "secret",
+				UseMSI:         true,
+				UseCLI:         true,
+			},
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test Case: %q", v.Name)
+
+		actual, err := selectAuthMethod(v.Input)
+		if err != nil {
+			if v.ShouldError {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.ShouldError {
+			t.Fatalf("expected an error but got %q", *actual)
+		}
+
+		if *actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, *actual)
+		}
+	}
+}
+
+func TestExpandTenantID(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Input       authConfig
+		Expected    string
+		ShouldError bool
+	}{
+		{
+			Name: "Azure Active Directory",
+			Input: authConfig{
+				IdentityProvider: identityProviderAzureActiveDirectory,
+				TenantID:         "00000000-0000-0000-0000-000000000000",
+			},
+			Expected: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			Name: "Azure Active Directory from the Azure CLI",
+			Input: authConfig{
+				IdentityProvider: identityProviderAzureActiveDirectory,
+			},
+			Expected: "",
+		},
+		{
+			Name: "ADFS",
+			Input: authConfig{
+				IdentityProvider: identityProviderADFS,
+			},
+			Expected: "adfs",
+		},
+		{
+			Name: "ADFS with the ADFS Tenant",
+			Input: authConfig{
+				IdentityProvider: identityProviderADFS,
+				TenantID:         "adfs",
+			},
+			Expected: "adfs",
+		},
+		{
+			Name: "ADFS with a Tenant ID",
+			Input: authConfig{
+				IdentityProvider: identityProviderADFS,
+				TenantID:         "00000000-0000-0000-0000-000000000000",
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "ADFS with Auxiliary Tenants",
+			Input: authConfig{
+				IdentityProvider:   identityProviderADFS,
+				AuxiliaryTenantIDs: []string{"00000000-0000-0000-0000-000000000000"},
+			},
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test Case: %q", v.Name)
+
+		actual, err := expandTenantID(v.Input)
+		if err != nil {
+			if v.ShouldError {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.ShouldError {
+			t.Fatalf("expected an error but got %q", actual)
+		}
+
+		if actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
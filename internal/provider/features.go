@@ -10,12 +10,62 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 	// NOTE: if there's only one nested field these want to be Required (since there's no point
 	//       specifying the block otherwise) - however for 2+ they should be optional
 	featuresMap := map[string]*pluginsdk.Schema{
+		"key_vault": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"purge_soft_delete_on_destroy": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+					},
+					"recover_soft_deleted_key_vaults": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+					},
+				},
+			},
+		},
+
+		"managed_disk": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"expand_without_downtime": {
+						Type:     pluginsdk.TypeBool,
+						Required: true,
+					},
+				},
+			},
+		},
+
+		"template_deployment": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"delete_nested_items_during_deletion": {
+						Type:     pluginsdk.TypeBool,
+						Required: true,
+					},
+				},
+			},
+		},
+
 		"virtual_machine": {
 			Type:     pluginsdk.TypeList,
 			Optional: true,
 			MaxItems: 1,
 			Elem: &pluginsdk.Resource{
 				Schema: map[string]*pluginsdk.Schema{
+					"delete_data_disks_on_deletion": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+					},
 					"delete_os_disk_on_deletion": {
 						Type:     pluginsdk.TypeBool,
 						Optional: true,
@@ -104,10 +154,46 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 
 	val := input[0].(map[string]interface{})
 
+	if raw, ok := val["key_vault"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 && items[0] != nil {
+			keyVaultRaw := items[0].(map[string]interface{})
+			if v, ok := keyVaultRaw["purge_soft_delete_on_destroy"]; ok {
+				featuresMap.KeyVault.PurgeSoftDeleteOnDestroy = v.(bool)
+			}
+			if v, ok := keyVaultRaw["recover_soft_deleted_key_vaults"]; ok {
+				featuresMap.KeyVault.RecoverSoftDeletedKeyVaults = v.(bool)
+			}
+		}
+	}
+
+	if raw, ok := val["managed_disk"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 && items[0] != nil {
+			managedDiskRaw := items[0].(map[string]interface{})
+			if v, ok := managedDiskRaw["expand_without_downtime"]; ok {
+				featuresMap.ManagedDisk.ExpandWithoutDowntime = v.(bool)
+			}
+		}
+	}
+
+	if raw, ok := val["template_deployment"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 && items[0] != nil {
+			templateRaw := items[0].(map[string]interface{})
+			if v, ok := templateRaw["delete_nested_items_during_deletion"]; ok {
+				featuresMap.TemplateDeployment.DeleteNestedItemsDuringDeletion = v.(bool)
+			}
+		}
+	}
+
 	if raw, ok := val["virtual_machine"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 && items[0] != nil {
 			virtualMachinesRaw := items[0].(map[string]interface{})
+			if v, ok := virtualMachinesRaw["delete_data_disks_on_deletion"]; ok {
+				featuresMap.VirtualMachine.DeleteDataDisksOnDeletion = v.(bool)
+			}
 			if v, ok := virtualMachinesRaw["delete_os_disk_on_deletion"]; ok {
 				featuresMap.VirtualMachine.DeleteOSDiskOnDeletion = v.(bool)
 			}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/features"
 )
 
@@ -18,7 +19,18 @@ func TestExpandFeatures(t *testing.T) {
 			Name:  "Empty Block",
 			Input: []interface{}{},
 			Expected: features.UserFeatures{
+				KeyVault: features.KeyVaultFeatures{
+					PurgeSoftDeleteOnDestroy:    false,
+					RecoverSoftDeletedKeyVaults: false,
+				},
+				ManagedDisk: features.ManagedDiskFeatures{
+					ExpandWithoutDowntime: false,
+				},
+				TemplateDeployment: features.TemplateDeploymentFeatures{
+					DeleteNestedItemsDuringDeletion: false,
+				},
 				VirtualMachine: features.VirtualMachineFeatures{
+					DeleteDataDisksOnDeletion:  true,
 					DeleteOSDiskOnDeletion:     true,
 					GracefulShutdown:           false,
 					SkipShutdownAndForceDelete: false,
@@ -37,6 +49,17 @@ func TestExpandFeatures(t *testing.T) {
 			Name: "Complete Enabled",
 			Input: []interface{}{
 				map[string]interface{}{
+					"key_vault": []interface{}{
+						map[string]interface{}{
+							"purge_soft_delete_on_destroy":    true,
+							"recover_soft_deleted_key_vaults": true,
+						},
+					},
+					"managed_disk": []interface{}{
+						map[string]interface{}{
+							"expand_without_downtime": true,
+						},
+					},
 					"resource_group": []interface{}{
 						map[string]interface{}{
 							"prevent_deletion_if_contains_resources": true,
@@ -49,6 +72,7 @@ func TestExpandFeatures(t *testing.T) {
 					},
 					"virtual_machine": []interface{}{
 						map[string]interface{}{
+							"delete_data_disks_on_deletion":  true,
 							"delete_os_disk_on_deletion":     true,
 							"graceful_shutdown":              true,
 							"skip_shutdown_and_force_delete": true,
@@ -64,10 +88,21 @@ func TestExpandFeatures(t *testing.T) {
 				},
 			},
 			Expected: features.UserFeatures{
+				KeyVault: features.KeyVaultFeatures{
+					PurgeSoftDeleteOnDestroy:    true,
+					RecoverSoftDeletedKeyVaults: true,
+				},
+				ManagedDisk: features.ManagedDiskFeatures{
+					ExpandWithoutDowntime: true,
+				},
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: true,
 				},
+				TemplateDeployment: features.TemplateDeploymentFeatures{
+					DeleteNestedItemsDuringDeletion: true,
+				},
 				VirtualMachine: features.VirtualMachineFeatures{
+					DeleteDataDisksOnDeletion:  true,
 					DeleteOSDiskOnDeletion:     true,
 					GracefulShutdown:           true,
 					SkipShutdownAndForceDelete: true,
@@ -83,6 +118,17 @@ func TestExpandFeatures(t *testing.T) {
 			Name: "Complete Disabled",
 			Input: []interface{}{
 				map[string]interface{}{
+					"key_vault": []interface{}{
+						map[string]interface{}{
+							"purge_soft_delete_on_destroy":    false,
+							"recover_soft_deleted_key_vaults": false,
+						},
+					},
+					"managed_disk": []interface{}{
+						map[string]interface{}{
+							"expand_without_downtime": false,
+						},
+					},
 					"resource_group": []interface{}{
 						map[string]interface{}{
 							"prevent_deletion_if_contains_resources": false,
@@ -95,6 +141,7 @@ func TestExpandFeatures(t *testing.T) {
 					},
 					"virtual_machine": []interface{}{
 						map[string]interface{}{
+							"delete_data_disks_on_deletion":  false,
 							"delete_os_disk_on_deletion":     false,
 							"graceful_shutdown":              false,
 							"skip_shutdown_and_force_delete": false,
@@ -110,10 +157,21 @@ func TestExpandFeatures(t *testing.T) {
 				},
 			},
 			Expected: features.UserFeatures{
+				KeyVault: features.KeyVaultFeatures{
+					PurgeSoftDeleteOnDestroy:    false,
+					RecoverSoftDeletedKeyVaults: false,
+				},
+				ManagedDisk: features.ManagedDiskFeatures{
+					ExpandWithoutDowntime: false,
+				},
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: false,
 				},
+				TemplateDeployment: features.TemplateDeploymentFeatures{
+					DeleteNestedItemsDuringDeletion: false,
+				},
 				VirtualMachine: features.VirtualMachineFeatures{
+					DeleteDataDisksOnDeletion:  false,
 					DeleteOSDiskOnDeletion:     false,
 					GracefulShutdown:           false,
 					SkipShutdownAndForceDelete: false,
@@ -136,6 +194,141 @@ func TestExpandFeatures(t *testing.T) {
 	}
 }
 
+func TestExpandFeaturesKeyVault(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"key_vault": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				KeyVault: features.KeyVaultFeatures{
+					PurgeSoftDeleteOnDestroy:    false,
+					RecoverSoftDeletedKeyVaults: false,
+				},
+			},
+		},
+		{
+			Name: "Purge Soft Delete On Destroy Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"key_vault": []interface{}{
+						map[string]interface{}{
+							"purge_soft_delete_on_destroy":    true,
+							"recover_soft_deleted_key_vaults": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				KeyVault: features.KeyVaultFeatures{
+					PurgeSoftDeleteOnDestroy:    true,
+					RecoverSoftDeletedKeyVaults: false,
+				},
+			},
+		},
+		{
+			Name: "Recover Soft Deleted Key Vaults Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"key_vault": []interface{}{
+						map[string]interface{}{
+							"purge_soft_delete_on_destroy":    false,
+							"recover_soft_deleted_key_vaults": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				KeyVault: features.KeyVaultFeatures{
+					PurgeSoftDeleteOnDestroy:    false,
+					RecoverSoftDeletedKeyVaults: true,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.KeyVault, testCase.Expected.KeyVault) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected.KeyVault, result.KeyVault)
+		}
+	}
+}
+
+func TestExpandFeaturesManagedDisk(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"managed_disk": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				ManagedDisk: features.ManagedDiskFeatures{
+					ExpandWithoutDowntime: false,
+				},
+			},
+		},
+		{
+			Name: "Expand Without Downtime Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"managed_disk": []interface{}{
+						map[string]interface{}{
+							"expand_without_downtime": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ManagedDisk: features.ManagedDiskFeatures{
+					ExpandWithoutDowntime: true,
+				},
+			},
+		},
+		{
+			Name: "Expand Without Downtime Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"managed_disk": []interface{}{
+						map[string]interface{}{
+							"expand_without_downtime": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ManagedDisk: features.ManagedDiskFeatures{
+					ExpandWithoutDowntime: false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.ManagedDisk, testCase.Expected.ManagedDisk) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected.ManagedDisk, result.ManagedDisk)
+		}
+	}
+}
+
 func TestExpandFeaturesResourceGroup(t *testing.T) {
 	testData := []struct {
 		Name     string
@@ -201,6 +394,71 @@ func TestExpandFeaturesResourceGroup(t *testing.T) {
 	}
 }
 
+func TestExpandFeaturesTemplateDeployment(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"template_deployment": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				TemplateDeployment: features.TemplateDeploymentFeatures{
+					DeleteNestedItemsDuringDeletion: false,
+				},
+			},
+		},
+		{
+			Name: "Delete Nested Items During Deletion Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"template_deployment": []interface{}{
+						map[string]interface{}{
+							"delete_nested_items_during_deletion": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				TemplateDeployment: features.TemplateDeploymentFeatures{
+					DeleteNestedItemsDuringDeletion: true,
+				},
+			},
+		},
+		{
+			Name: "Delete Nested Items During Deletion Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"template_deployment": []interface{}{
+						map[string]interface{}{
+							"delete_nested_items_during_deletion": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				TemplateDeployment: features.TemplateDeploymentFeatures{
+					DeleteNestedItemsDuringDeletion: false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.TemplateDeployment, testCase.Expected.TemplateDeployment) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected.TemplateDeployment, result.TemplateDeployment)
+		}
+	}
+}
+
 func TestExpandFeaturesVirtualMachine(t *testing.T) {
 	testData := []struct {
 		Name     string
@@ -217,6 +475,7 @@ func TestExpandFeaturesVirtualMachine(t *testing.T) {
 			},
 			Expected: features.UserFeatures{
 				VirtualMachine: features.VirtualMachineFeatures{
+					DeleteDataDisksOnDeletion:  true,
 					DeleteOSDiskOnDeletion:     true,
 					GracefulShutdown:           false,
 					SkipShutdownAndForceDelete: false,
@@ -239,6 +498,28 @@ func TestExpandFeaturesVirtualMachine(t *testing.T) {
 			},
 			Expected: features.UserFeatures{
 				VirtualMachine: features.VirtualMachineFeatures{
+					DeleteDataDisksOnDeletion:  true,
+					DeleteOSDiskOnDeletion:     true,
+					GracefulShutdown:           false,
+					SkipShutdownAndForceDelete: false,
+				},
+			},
+		},
+		{
+			Name: "Delete Data Disks Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"virtual_machine": []interface{}{
+						map[string]interface{}{
+							"delete_data_disks_on_deletion": false,
+							"delete_os_disk_on_deletion":    true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				VirtualMachine: features.VirtualMachineFeatures{
+					DeleteDataDisksOnDeletion:  false,
 					DeleteOSDiskOnDeletion:     true,
 					GracefulShutdown:           false,
 					SkipShutdownAndForceDelete: false,
@@ -260,6 +541,7 @@ func TestExpandFeaturesVirtualMachine(t *testing.T) {
 			},
 			Expected: features.UserFeatures{
 				VirtualMachine: features.VirtualMachineFeatures{
+					DeleteDataDisksOnDeletion:  true,
 					DeleteOSDiskOnDeletion:     false,
 					GracefulShutdown:           true,
 					SkipShutdownAndForceDelete: false,
@@ -281,6 +563,7 @@ func TestExpandFeaturesVirtualMachine(t *testing.T) {
 			},
 			Expected: features.UserFeatures{
 				VirtualMachine: features.VirtualMachineFeatures{
+					DeleteDataDisksOnDeletion:  true,
 					DeleteOSDiskOnDeletion:     false,
 					GracefulShutdown:           false,
 					SkipShutdownAndForceDelete: true,
@@ -293,6 +576,7 @@ func TestExpandFeaturesVirtualMachine(t *testing.T) {
 				map[string]interface{}{
 					"virtual_machine": []interface{}{
 						map[string]interface{}{
+							"delete_data_disks_on_deletion":  false,
 							"delete_os_disk_on_deletion":     false,
 							"graceful_shutdown":              false,
 							"skip_shutdown_and_force_delete": false,
@@ -302,6 +586,7 @@ func TestExpandFeaturesVirtualMachine(t *testing.T) {
 			},
 			Expected: features.UserFeatures{
 				VirtualMachine: features.VirtualMachineFeatures{
+					DeleteDataDisksOnDeletion:  false,
 					DeleteOSDiskOnDeletion:     false,
 					GracefulShutdown:           false,
 					SkipShutdownAndForceDelete: false,
@@ -432,3 +717,62 @@ func TestExpandFeaturesVirtualMachineScaleSet(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandFeaturesFromProviderSchema(t *testing.T) {
+	raw := map[string]interface{}{
+		"features": []interface{}{
+			map[string]interface{}{
+				"key_vault": []interface{}{
+					map[string]interface{}{
+						"purge_soft_delete_on_destroy":    true,
+						"recover_soft_deleted_key_vaults": true,
+					},
+				},
+				"managed_disk": []interface{}{
+					map[string]interface{}{
+						"expand_without_downtime": true,
+					},
+				},
+				"resource_group": []interface{}{
+					map[string]interface{}{
+						"prevent_deletion_if_contains_resources": true,
+					},
+				},
+				"template_deployment": []interface{}{
+					map[string]interface{}{
+						"delete_nested_items_during_deletion": true,
+					},
+				},
+				"virtual_machine": []interface{}{
+					map[string]interface{}{
+						"delete_data_disks_on_deletion": false,
+						"delete_os_disk_on_deletion":    false,
+					},
+				},
+				"virtual_machine_scale_set": []interface{}{
+					map[string]interface{}{
+						"roll_instances_when_required":  false,
+						"scale_to_zero_before_deletion": false,
+					},
+				},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, AzureProvider().Schema, raw)
+
+	expected := features.Default()
+	expected.KeyVault.PurgeSoftDeleteOnDestroy = true
+	expected.KeyVault.RecoverSoftDeletedKeyVaults = true
+	expected.ManagedDisk.ExpandWithoutDowntime = true
+	expected.ResourceGroup.PreventDeletionIfContainsResources = true
+	expected.TemplateDeployment.DeleteNestedItemsDuringDeletion = true
+	expected.VirtualMachine.DeleteDataDisksOnDeletion = false
+	expected.VirtualMachine.DeleteOSDiskOnDeletion = false
+	expected.VirtualMachineScaleSet.RollInstancesWhenRequired = false
+	expected.VirtualMachineScaleSet.ScaleToZeroOnDelete = false
+
+	result := expandFeatures(d.Get("features").([]interface{}))
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, result)
+	}
+}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceproviders"
+)
+
+// withLocationValidation validates each configurable `location` field, including those within nested blocks,
+// against the Locations supported by the Azure Stack Hub stamp when Enhanced Validation is enabled
+func withLocationValidation(input map[string]*schema.Schema) {
+	for k, v := range input {
+		if nested, ok := v.Elem.(*schema.Resource); ok {
+			withLocationValidation(nested.Schema)
+		}
+
+		if k != "location" || v.Type != schema.TypeString || (!v.Required && !v.Optional) || v.ValidateDiagFunc != nil {
+			continue
+		}
+
+		v.ValidateFunc = resourceproviders.EnhancedValidateLocation
+	}
+}
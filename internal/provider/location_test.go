@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceproviders"
+)
+
+func TestLocationsUseEnhancedValidation(t *testing.T) {
+	expected := reflect.ValueOf(resourceproviders.EnhancedValidateLocation).Pointer()
+
+	var validate func(name string, input map[string]*schema.Schema)
+	validate = func(name string, input map[string]*schema.Schema) {
+		for k, v := range input {
+			if nested, ok := v.Elem.(*schema.Resource); ok {
+				validate(name, nested.Schema)
+			}
+
+			if k != "location" || (!v.Required && !v.Optional) {
+				continue
+			}
+
+			if v.ValidateFunc == nil || reflect.ValueOf(v.ValidateFunc).Pointer() != expected {
+				t.Fatalf("the `location` field within %q doesn't use `resourceproviders.EnhancedValidateLocation`", name)
+			}
+		}
+	}
+
+	provider := TestAzureProvider()
+	for name, dataSource := range provider.DataSourcesMap {
+		validate(name, dataSource.Schema)
+	}
+	for name, resource := range provider.ResourcesMap {
+		validate(name, resource.Schema)
+	}
+}
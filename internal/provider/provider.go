@@ -11,7 +11,9 @@ import (
 	"github.com/hashicorp/go-azure-helpers/authentication"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/sdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
@@ -97,6 +99,19 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 		}
 	}
 
+	// the Provider-level `default_tags` and `ignore_tags` are applied to all taggable Resources
+	for _, v := range resources {
+		tags.WrapResource(v)
+	}
+
+	// the `location` of all Resources and Data Sources is validated against the Locations supported by the stamp
+	for _, v := range dataSources {
+		withLocationValidation(v.Schema)
+	}
+	for _, v := range resources {
+		withLocationValidation(v.Schema)
+	}
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
@@ -142,6 +157,14 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "The Cloud Environment which should be used.",
 			},
 
+			"identity_provider": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_IDENTITY_PROVIDER", identityProviderAzureActiveDirectory),
+				ValidateFunc: validation.StringInSlice([]string{identityProviderAzureActiveDirectory, identityProviderADFS}, false),
+				Description:  "The Identity Provider used by the Azure Stack Hub stamp, either `aad` (Azure Active Directory) or `adfs` (Active Directory Federation Services).",
+			},
+
 			"auxiliary_tenant_ids": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -189,6 +212,43 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "The path to a custom endpoint for Managed Service Identity - in most circumstances this should be detected automatically. ",
 			},
 
+			// Azure CLI specific fields
+			"use_cli": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_CLI", true),
+				Description: "Allow the Azure CLI to be used for Authentication when no other authentication method is configured.",
+			},
+
+			// TLS and Proxy specific fields
+			"ca_certificate_path": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_certificate_pem"},
+				Description:   "The path to a PEM encoded CA Certificate which should be trusted in addition to the system trust store. The `ARM_CA_CERTIFICATE` Environment Variable can contain either a path or a PEM encoded CA Certificate.",
+			},
+
+			"ca_certificate_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_certificate_path"},
+				Description:   "A PEM encoded CA Certificate which should be trusted in addition to the system trust store.",
+			},
+
+			"tls_min_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"1.2", "1.3"}, false),
+				Description:  "The minimum TLS version which should be used when connecting to Azure Stack.",
+			},
+
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+				Description:  "The URL of the HTTP Proxy which should be used, overriding the `HTTP_PROXY` and `HTTPS_PROXY` Environment Variables.",
+			},
+
 			"disable_correlation_request_id": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -204,6 +264,12 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "Should the AzureStack Provider skip registering all of the Resource Providers that it supports, if they're not already registered?",
 			},
 
+			"retry": schemaRetry(),
+
+			"default_tags": schemaDefaultTags(),
+
+			"ignore_tags": schemaIgnoreTags(),
+
 			"features": schemaFeatures(supportLegacyTestSuite),
 		},
 
@@ -248,11 +314,29 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			return nil, diag.Errorf("provider: `metadata_host` must be set")
 		}
 
+		auth := authConfig{
+			ClientCertPath:     d.Get("client_certificate_path").(string),
+			ClientSecret:       d.Get("client_secret").(string),
+			UseMSI:             d.Get("use_msi").(bool),
+			UseCLI:             d.Get("use_cli").(bool),
+			IdentityProvider:   d.Get("identity_provider").(string),
+			TenantID:           d.Get("tenant_id").(string),
+			AuxiliaryTenantIDs: auxTenants,
+		}
+		method, err := selectAuthMethod(auth)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		tenantId, err := expandTenantID(auth)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
 		builder := &authentication.Builder{
 			SubscriptionID:     d.Get("subscription_id").(string),
 			ClientID:           d.Get("client_id").(string),
 			ClientSecret:       d.Get("client_secret").(string),
-			TenantID:           d.Get("tenant_id").(string),
+			TenantID:           tenantId,
 			Environment:        d.Get("environment").(string),
 			MetadataHost:       metadataHost,
 			AuxiliaryTenantIDs: auxTenants,
@@ -260,12 +344,12 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			ClientCertPassword: d.Get("client_certificate_password").(string),
 			ClientCertPath:     d.Get("client_certificate_path").(string),
 
-			// Feature Toggles
-			SupportsClientCertAuth:   true,
-			SupportsClientSecretAuth: true,
-			// SupportsManagedServiceIdentity: d.Get("use_msi").(bool), todo supported in stack?
-			SupportsAzureCliToken:    true,
-			SupportsAuxiliaryTenants: len(auxTenants) > 0,
+			// Feature Toggles - only the selected authentication method is enabled
+			SupportsClientCertAuth:         *method == authMethodClientCertificate,
+			SupportsClientSecretAuth:       *method == authMethodClientSecret,
+			SupportsManagedServiceIdentity: *method == authMethodManagedServiceIdentity,
+			SupportsAzureCliToken:          *method == authMethodAzureCli,
+			SupportsAuxiliaryTenants:       len(auxTenants) > 0,
 
 			// Doc Links
 			ClientSecretDocsLink: "https://registry.terraform.io/providers/hashicorp/azurestack/latest/docs/guides/service_principal_client_secret",
@@ -283,12 +367,32 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			terraformVersion = "0.11+compatible"
 		}
 
+		transport, err := expandTransportOptions(transportConfig{
+			CACertificatePath: d.Get("ca_certificate_path").(string),
+			CACertificatePEM:  d.Get("ca_certificate_pem").(string),
+			TLSMinVersion:     d.Get("tls_min_version").(string),
+			ProxyURL:          d.Get("proxy_url").(string),
+		})
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		retry, err := expandRetry(d.Get("retry").([]interface{}))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
 		skipProviderRegistration := d.Get("skip_provider_registration").(bool)
 		clientBuilder := clients.ClientBuilder{
 			AuthConfig:                  config,
 			SkipProviderRegistration:    skipProviderRegistration,
 			TerraformVersion:            terraformVersion,
 			DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
+			Features:                    expandFeatures(d.Get("features").([]interface{})),
+			Transport:                   *transport,
+			Retry:                       *retry,
+			UseADFS:                     strings.EqualFold(auth.IdentityProvider, identityProviderADFS),
+			Tags:                        expandProviderTags(d.Get("default_tags").([]interface{}), d.Get("ignore_tags").([]interface{})),
 
 			// this field is intentionally not exposed in the provider block, since it's only used for
 			// platform level tracing
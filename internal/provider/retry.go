@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/common"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+func schemaRetry() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Configures how requests which fail with a transient error, or are throttled, are retried.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"max_attempts": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      3,
+					ValidateFunc: validation.IntBetween(1, 20),
+				},
+
+				"min_backoff": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Default:      "1s",
+					ValidateFunc: validateRetryBackoff,
+				},
+
+				"max_backoff": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Default:      "60s",
+					ValidateFunc: validateRetryBackoff,
+				},
+
+				"retry_on_throttling": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+
+				"retryable_status_codes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeInt,
+						ValidateFunc: validation.IntBetween(400, 599),
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateRetryBackoff(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	duration, err := time.ParseDuration(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a duration such as `500ms` or `30s`: %+v", k, err))
+		return
+	}
+
+	if duration <= 0 {
+		errors = append(errors, fmt.Errorf("%q must be greater than zero", k))
+	}
+
+	return
+}
+
+func expandRetry(input []interface{}) (*common.RetryOptions, error) {
+	options := common.DefaultRetryOptions()
+	if len(input) == 0 || input[0] == nil {
+		return &options, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	options.MaxAttempts = raw["max_attempts"].(int)
+	options.RetryOnThrottling = raw["retry_on_throttling"].(bool)
+
+	minBackoff, err := time.ParseDuration(raw["min_backoff"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("parsing `min_backoff`: %+v", err)
+	}
+	options.MinBackoff = minBackoff
+
+	maxBackoff, err := time.ParseDuration(raw["max_backoff"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("parsing `max_backoff`: %+v", err)
+	}
+	options.MaxBackoff = maxBackoff
+
+	if options.MinBackoff > options.MaxBackoff {
+		return nil, fmt.Errorf("`min_backoff` (%s) must be less than or equal to `max_backoff` (%s)", options.MinBackoff, options.MaxBackoff)
+	}
+
+	if v, ok := raw["retryable_status_codes"].(*pluginsdk.Set); ok && v.Len() > 0 {
+		statusCodes := make([]int, 0)
+		for _, code := range v.List() {
+			statusCodes = append(statusCodes, code.(int))
+		}
+		sort.Ints(statusCodes)
+		options.RetryableStatusCodes = statusCodes
+	}
+
+	return &options, nil
+}
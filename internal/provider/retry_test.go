@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/common"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+func TestExpandRetry(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Input       []interface{}
+		Expected    *common.RetryOptions
+		ShouldError bool
+	}{
+		{
+			Name:  "Not Specified",
+			Input: []interface{}{},
+			Expected: &common.RetryOptions{
+				MaxAttempts:          3,
+				MinBackoff:           1 * time.Second,
+				MaxBackoff:           60 * time.Second,
+				RetryOnThrottling:    true,
+				RetryableStatusCodes: []int{500, 502, 503, 504},
+			},
+		},
+		{
+			Name: "Custom Values",
+			Input: []interface{}{
+				map[string]interface{}{
+					"max_attempts":           5,
+					"min_backoff":            "500ms",
+					"max_backoff":            "2m",
+					"retry_on_throttling":    false,
+					"retryable_status_codes": pluginsdk.NewSet(schema.HashInt, []interface{}{503, 409}),
+				},
+			},
+			Expected: &common.RetryOptions{
+				MaxAttempts:          5,
+				MinBackoff:           500 * time.Millisecond,
+				MaxBackoff:           2 * time.Minute,
+				RetryOnThrottling:    false,
+				RetryableStatusCodes: []int{409, 503},
+			},
+		},
+		{
+			Name: "Default Status Codes",
+			Input: []interface{}{
+				map[string]interface{}{
+					"max_attempts":           10,
+					"min_backoff":            "1s",
+					"max_backoff":            "60s",
+					"retry_on_throttling":    true,
+					"retryable_status_codes": pluginsdk.NewSet(schema.HashInt, []interface{}{}),
+				},
+			},
+			Expected: &common.RetryOptions{
+				MaxAttempts:          10,
+				MinBackoff:           1 * time.Second,
+				MaxBackoff:           60 * time.Second,
+				RetryOnThrottling:    true,
+				RetryableStatusCodes: []int{500, 502, 503, 504},
+			},
+		},
+		{
+			Name: "Min Backoff greater than Max Backoff",
+			Input: []interface{}{
+				map[string]interface{}{
+					"max_attempts":           3,
+					"min_backoff":            "2m",
+					"max_backoff":            "1m",
+					"retry_on_throttling":    true,
+					"retryable_status_codes": pluginsdk.NewSet(schema.HashInt, []interface{}{}),
+				},
+			},
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test Case: %q", v.Name)
+
+		actual, err := expandRetry(v.Input)
+		if err != nil {
+			if v.ShouldError {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.ShouldError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
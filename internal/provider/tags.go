@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func schemaDefaultTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Tags which should be assigned to all taggable resources managed by this Provider.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"tags": {
+					Type:         pluginsdk.TypeMap,
+					Required:     true,
+					ValidateFunc: tags.Validate,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaIgnoreTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Tags which should be ignored when reading taggable resources managed by this Provider.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"keys": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+					AtLeastOneOf: []string{"ignore_tags.0.keys", "ignore_tags.0.key_prefixes"},
+				},
+
+				"key_prefixes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+					AtLeastOneOf: []string{"ignore_tags.0.keys", "ignore_tags.0.key_prefixes"},
+				},
+			},
+		},
+	}
+}
+
+func expandProviderTags(defaultTags []interface{}, ignoreTags []interface{}) tags.ProviderConfig {
+	config := tags.ProviderConfig{
+		DefaultTags:       map[string]*string{},
+		IgnoreKeys:        []string{},
+		IgnoreKeyPrefixes: []string{},
+	}
+
+	if len(defaultTags) > 0 && defaultTags[0] != nil {
+		raw := defaultTags[0].(map[string]interface{})
+		config.DefaultTags = tags.Expand(raw["tags"].(map[string]interface{}))
+	}
+
+	if len(ignoreTags) > 0 && ignoreTags[0] != nil {
+		raw := ignoreTags[0].(map[string]interface{})
+		if v, ok := raw["keys"].(*pluginsdk.Set); ok {
+			config.IgnoreKeys = *utils.ExpandStringSlice(v.List())
+		}
+		if v, ok := raw["key_prefixes"].(*pluginsdk.Set); ok {
+			config.IgnoreKeyPrefixes = *utils.ExpandStringSlice(v.List())
+		}
+	}
+
+	return config
+}
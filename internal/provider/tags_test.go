@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+)
+
+func TestExpandProviderTags(t *testing.T) {
+	testData := []struct {
+		Name        string
+		DefaultTags []interface{}
+		IgnoreTags  []interface{}
+		Expected    tags.ProviderConfig
+	}{
+		{
+			Name:        "Empty Blocks",
+			DefaultTags: []interface{}{},
+			IgnoreTags:  []interface{}{},
+			Expected: tags.ProviderConfig{
+				DefaultTags:       map[string]*string{},
+				IgnoreKeys:        []string{},
+				IgnoreKeyPrefixes: []string{},
+			},
+		},
+		{
+			Name: "Complete",
+			DefaultTags: []interface{}{
+				map[string]interface{}{
+					"tags": map[string]interface{}{
+						"cost-centre": "1234",
+					},
+				},
+			},
+			IgnoreTags: []interface{}{
+				map[string]interface{}{
+					"keys":         schema.NewSet(schema.HashString, []interface{}{"CreatedBy"}),
+					"key_prefixes": schema.NewSet(schema.HashString, []interface{}{"hidden-"}),
+				},
+			},
+			Expected: tags.ProviderConfig{
+				DefaultTags: map[string]*string{
+					"cost-centre": pointer.FromString("1234"),
+				},
+				IgnoreKeys:        []string{"CreatedBy"},
+				IgnoreKeyPrefixes: []string{"hidden-"},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test Case: %q", v.Name)
+
+		actual := expandProviderTags(v.DefaultTags, v.IgnoreTags)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
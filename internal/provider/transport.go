@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/common"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+type transportConfig struct {
+	CACertificatePath string
+	CACertificatePEM  string
+	TLSMinVersion     string
+	ProxyURL          string
+}
+
+func expandTransportOptions(input transportConfig) (*common.TransportOptions, error) {
+	options := common.TransportOptions{}
+
+	// `ARM_CA_CERTIFICATE` can contain either the path to, or the contents of, a PEM encoded CA Certificate
+	if input.CACertificatePath == "" && input.CACertificatePEM == "" {
+		if v := os.Getenv("ARM_CA_CERTIFICATE"); strings.HasPrefix(strings.TrimSpace(v), "-----BEGIN") {
+			input.CACertificatePEM = v
+		} else {
+			input.CACertificatePath = v
+		}
+	}
+
+	if input.CACertificatePath != "" {
+		contents, err := os.ReadFile(input.CACertificatePath)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA Certificate from %q: %+v", input.CACertificatePath, err)
+		}
+		options.CACertificates = contents
+	}
+
+	if input.CACertificatePEM != "" {
+		options.CACertificates = append(options.CACertificates, []byte(input.CACertificatePEM)...)
+	}
+
+	if input.TLSMinVersion != "" {
+		version, ok := tlsVersions[input.TLSMinVersion]
+		if !ok {
+			return nil, fmt.Errorf("`tls_min_version` must be either `1.2` or `1.3` but got %q", input.TLSMinVersion)
+		}
+		options.TLSMinVersion = version
+	}
+
+	if input.ProxyURL != "" {
+		proxyUrl, err := url.Parse(input.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing `proxy_url`: %+v", err)
+		}
+		options.ProxyURL = proxyUrl
+	}
+
+	return &options, nil
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testCACertificate = `-----BEGIN CERTIFICATE-----
+example
+-----END CERTIFICATE-----
+`
+
+func TestExpandTransportOptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, []byte(testCACertificate), 0o600); err != nil {
+		t.Fatalf("writing CA Certificate: %+v", err)
+	}
+
+	testData := []struct {
+		Name                   string
+		Input                  transportConfig
+		EnvVar                 string
+		ExpectedCACertificates string
+		ExpectedTLSMinVersion  uint16
+		ExpectedProxyURL       string
+		ShouldError            bool
+	}{
+		{
+			Name:  "Empty",
+			Input: transportConfig{},
+		},
+		{
+			Name: "CA Certificate Path",
+			Input: transportConfig{
+				CACertificatePath: path,
+			},
+			ExpectedCACertificates: testCACertificate,
+		},
+		{
+			Name: "CA Certificate Path Missing",
+			Input: transportConfig{
+				CACertificatePath: filepath.Join(t.TempDir(), "missing.pem"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "CA Certificate PEM",
+			Input: transportConfig{
+				CACertificatePEM: testCACertificate,
+			},
+			ExpectedCACertificates: testCACertificate,
+		},
+		{
+			Name:                   "CA Certificate Path from Environment Variable",
+			EnvVar:                 path,
+			ExpectedCACertificates: testCACertificate,
+		},
+		{
+			Name:                   "CA Certificate PEM from Environment Variable",
+			EnvVar:                 testCACertificate,
+			ExpectedCACertificates: testCACertificate,
+		},
+		{
+			Name: "Configuration takes precedence over the Environment Variable",
+			Input: transportConfig{
+				CACertificatePEM: testCACertificate,
+			},
+			EnvVar:                 filepath.Join(t.TempDir(), "missing.pem"),
+			ExpectedCACertificates: testCACertificate,
+		},
+		{
+			Name: "TLS Min Version",
+			Input: transportConfig{
+				TLSMinVersion: "1.3",
+			},
+			ExpectedTLSMinVersion: tls.VersionTLS13,
+		},
+		{
+			Name: "Insecure TLS Min Version",
+			Input: transportConfig{
+				TLSMinVersion: "1.1",
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "Invalid TLS Min Version",
+			Input: transportConfig{
+				TLSMinVersion: "2.0",
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "Proxy URL",
+			Input: transportConfig{
+				ProxyURL: "http://proxy.example.com:3128",
+			},
+			ExpectedProxyURL: "http://proxy.example.com:3128",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test Case: %q", v.Name)
+		t.Setenv("ARM_CA_CERTIFICATE", v.EnvVar)
+
+		actual, err := expandTransportOptions(v.Input)
+		if v.ShouldError {
+			if err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+
+		if string(actual.CACertificates) != v.ExpectedCACertificates {
+			t.Fatalf("Expected the CA Certificates to be %q but got %q", v.ExpectedCACertificates, string(actual.CACertificates))
+		}
+		if actual.TLSMinVersion != v.ExpectedTLSMinVersion {
+			t.Fatalf("Expected the TLS Min Version to be %d but got %d", v.ExpectedTLSMinVersion, actual.TLSMinVersion)
+		}
+
+		actualProxyUrl := ""
+		if actual.ProxyURL != nil {
+			actualProxyUrl = actual.ProxyURL.String()
+		}
+		if actualProxyUrl != v.ExpectedProxyURL {
+			t.Fatalf("Expected the Proxy URL to be %q but got %q", v.ExpectedProxyURL, actualProxyUrl)
+		}
+	}
+}
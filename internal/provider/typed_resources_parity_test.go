@@ -0,0 +1,516 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/resource"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/sdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/set"
+)
+
+// these tests confirm that the Resources which have been migrated from the Plugin SDK onto
+// the typed SDK expose the same Schema (and as such, the same State) as the untyped versions
+// did - the `legacy*` functions below are snapshots of the untyped Resources prior to migration
+
+func TestTypedResourcesMatchUntypedSchema(t *testing.T) {
+	testData := []struct {
+		typed  sdk.Resource
+		legacy *schema.Resource
+		config map[string]interface{}
+	}{
+		{
+			typed:  resource.ResourceGroupResource{},
+			legacy: legacyResourceGroupResource(),
+			config: map[string]interface{}{
+				"name":     "example-resources",
+				"location": "local",
+				"tags": map[string]interface{}{
+					"environment": "test",
+				},
+			},
+		},
+		{
+			typed:  dns.DnsARecordResource{},
+			legacy: legacyDnsARecordResource(),
+			config: map[string]interface{}{
+				"name":                "example",
+				"resource_group_name": "example-resources",
+				"zone_name":           "example.com",
+				"records":             []interface{}{"10.0.0.1", "10.0.0.2"},
+				"ttl":                 300,
+				"tags": map[string]interface{}{
+					"environment": "test",
+				},
+			},
+		},
+		{
+			typed:  dns.DnsAAAARecordResource{},
+			legacy: legacyDnsAAAARecordResource(),
+			config: map[string]interface{}{
+				"name":                "example",
+				"resource_group_name": "example-resources",
+				"zone_name":           "example.com",
+				"records":             []interface{}{"2607:f8b0:4009:1803::1005", "2001:db8::1"},
+				"ttl":                 300,
+			},
+		},
+		{
+			typed:  dns.DnsCNameRecordResource{},
+			legacy: legacyDnsCNameRecordResource(),
+			config: map[string]interface{}{
+				"name":                "example",
+				"resource_group_name": "example-resources",
+				"zone_name":           "example.com",
+				"record":              "contoso.com",
+				"ttl":                 300,
+			},
+		},
+		{
+			typed:  dns.DnsMxRecordResource{},
+			legacy: legacyDnsMxRecordResource(),
+			config: map[string]interface{}{
+				"resource_group_name": "example-resources",
+				"zone_name":           "example.com",
+				"record": []interface{}{
+					map[string]interface{}{
+						"preference": "10",
+						"exchange":   "mail1.contoso.com",
+					},
+					map[string]interface{}{
+						"preference": "20",
+						"exchange":   "mail2.contoso.com",
+					},
+				},
+				"ttl": 300,
+			},
+		},
+		{
+			typed:  dns.DnsNsRecordResource{},
+			legacy: legacyDnsNsRecordResource(),
+			config: map[string]interface{}{
+				"name":                "example",
+				"resource_group_name": "example-resources",
+				"zone_name":           "example.com",
+				"records":             []interface{}{"ns1.contoso.com", "ns2.contoso.com"},
+				"ttl":                 300,
+			},
+		},
+		{
+			typed:  dns.DnsPtrRecordResource{},
+			legacy: legacyDnsPtrRecordResource(),
+			config: map[string]interface{}{
+				"name":                "example",
+				"resource_group_name": "example-resources",
+				"zone_name":           "2.0.192.in-addr.arpa",
+				"records":             []interface{}{"hashicorp.com"},
+				"ttl":                 300,
+			},
+		},
+		{
+			typed:  dns.DnsSrvRecordResource{},
+			legacy: legacyDnsSrvRecordResource(),
+			config: map[string]interface{}{
+				"name":                "example",
+				"resource_group_name": "example-resources",
+				"zone_name":           "example.com",
+				"record": []interface{}{
+					map[string]interface{}{
+						"priority": 1,
+						"weight":   5,
+						"port":     8080,
+						"target":   "target1.contoso.com",
+					},
+				},
+				"ttl": 300,
+			},
+		},
+		{
+			typed:  dns.DnsTxtRecordResource{},
+			legacy: legacyDnsTxtRecordResource(),
+			config: map[string]interface{}{
+				"name":                "example",
+				"resource_group_name": "example-resources",
+				"zone_name":           "example.com",
+				"record": []interface{}{
+					map[string]interface{}{
+						"value": "google-site-authenticator",
+					},
+					map[string]interface{}{
+						"value": "more site information here",
+					},
+				},
+				"ttl": 300,
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.typed.ResourceType(), func(t *testing.T) {
+			wrapper := sdk.NewResourceWrapper(v.typed)
+			typed, err := wrapper.Resource()
+			if err != nil {
+				t.Fatalf("building Resource: %+v", err)
+			}
+
+			if !reflect.DeepEqual(v.legacy.CoreConfigSchema(), typed.CoreConfigSchema()) {
+				t.Fatalf("expected the Core Config Schema for the typed and untyped Resources to match but they didn't")
+			}
+
+			if err := schemasMatch("", v.legacy.Schema, typed.Schema); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := timeoutsMatch(v.legacy.Timeouts, typed.Timeouts); err != nil {
+				t.Fatal(err)
+			}
+
+			if v.legacy.Importer == nil || typed.Importer == nil {
+				t.Fatalf("expected both the typed and untyped Resources to be importable")
+			}
+
+			if (v.legacy.Update == nil) != (typed.UpdateContext == nil) {
+				t.Fatalf("expected both the typed and untyped Resources to support Update")
+			}
+
+			legacyState := stateForConfig(t, v.legacy.Schema, v.config)
+			typedState := stateForConfig(t, typed.Schema, v.config)
+			if !reflect.DeepEqual(legacyState, typedState) {
+				t.Fatalf("expected the State for the typed and untyped Resources to match\n\nUntyped: %+v\n\nTyped: %+v", legacyState, typedState)
+			}
+		})
+	}
+}
+
+func schemasMatch(prefix string, expected map[string]*schema.Schema, actual map[string]*schema.Schema) error {
+	if len(expected) != len(actual) {
+		return fmt.Errorf("expected %d fields in %q but got %d: %+v", len(expected), prefix, len(actual), schemaKeys(actual))
+	}
+
+	for k, e := range expected {
+		key := k
+		if prefix != "" {
+			key = fmt.Sprintf("%s.%s", prefix, k)
+		}
+
+		a, ok := actual[k]
+		if !ok {
+			return fmt.Errorf("field %q was missing", key)
+		}
+
+		if e.Type != a.Type {
+			return fmt.Errorf("expected %q to have the Type %s but got %s", key, e.Type, a.Type)
+		}
+		if e.Required != a.Required || e.Optional != a.Optional || e.Computed != a.Computed {
+			return fmt.Errorf("expected %q to be Required %t / Optional %t / Computed %t but got Required %t / Optional %t / Computed %t", key, e.Required, e.Optional, e.Computed, a.Required, a.Optional, a.Computed)
+		}
+		if e.ForceNew != a.ForceNew {
+			return fmt.Errorf("expected %q to have ForceNew %t but got %t", key, e.ForceNew, a.ForceNew)
+		}
+		if e.Sensitive != a.Sensitive {
+			return fmt.Errorf("expected %q to have Sensitive %t but got %t", key, e.Sensitive, a.Sensitive)
+		}
+		if !reflect.DeepEqual(e.Default, a.Default) {
+			return fmt.Errorf("expected %q to have the Default %+v but got %+v", key, e.Default, a.Default)
+		}
+		if e.MinItems != a.MinItems || e.MaxItems != a.MaxItems {
+			return fmt.Errorf("expected %q to have MinItems %d / MaxItems %d but got %d / %d", key, e.MinItems, e.MaxItems, a.MinItems, a.MaxItems)
+		}
+		if !functionsMatch(e.ValidateFunc, a.ValidateFunc) {
+			return fmt.Errorf("expected %q to use the same ValidateFunc", key)
+		}
+		if (e.Set == nil) != (a.Set == nil) {
+			return fmt.Errorf("expected %q to have a Set function %t but got %t", key, e.Set != nil, a.Set != nil)
+		}
+
+		switch elem := e.Elem.(type) {
+		case *schema.Resource:
+			actualElem, ok := a.Elem.(*schema.Resource)
+			if !ok {
+				return fmt.Errorf("expected the Elem for %q to be a Resource but got %+v", key, a.Elem)
+			}
+			if err := schemasMatch(key, elem.Schema, actualElem.Schema); err != nil {
+				return err
+			}
+
+		case *schema.Schema:
+			actualElem, ok := a.Elem.(*schema.Schema)
+			if !ok {
+				return fmt.Errorf("expected the Elem for %q to be a Schema but got %+v", key, a.Elem)
+			}
+			if err := schemasMatch(key, map[string]*schema.Schema{"elem": elem}, map[string]*schema.Schema{"elem": actualElem}); err != nil {
+				return err
+			}
+
+		case nil:
+			if a.Elem != nil {
+				return fmt.Errorf("expected no Elem for %q but got %+v", key, a.Elem)
+			}
+		}
+	}
+
+	return nil
+}
+
+func stateForConfig(t *testing.T, s map[string]*schema.Schema, config map[string]interface{}) map[string]string {
+	d := schema.TestResourceDataRaw(t, s, config)
+	d.SetId("parity")
+	return d.State().Attributes
+}
+
+func timeoutsMatch(expected *schema.ResourceTimeout, actual *schema.ResourceTimeout) error {
+	values := map[string][2]*time.Duration{
+		"Create":  {expected.Create, actual.Create},
+		"Read":    {expected.Read, actual.Read},
+		"Update":  {expected.Update, actual.Update},
+		"Delete":  {expected.Delete, actual.Delete},
+		"Default": {expected.Default, actual.Default},
+	}
+	for name, v := range values {
+		if !reflect.DeepEqual(v[0], v[1]) {
+			return fmt.Errorf("expected the %s timeout to be %+v but got %+v", name, v[0], v[1])
+		}
+	}
+
+	return nil
+}
+
+func functionsMatch(expected interface{}, actual interface{}) bool {
+	e := reflect.ValueOf(expected)
+	a := reflect.ValueOf(actual)
+	if e.IsNil() || a.IsNil() {
+		return e.IsNil() == a.IsNil()
+	}
+
+	return e.Pointer() == a.Pointer()
+}
+
+func schemaKeys(input map[string]*schema.Schema) []string {
+	keys := make([]string, 0)
+	for k := range input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func legacyDnsRecordSchema(records *schema.Schema, recordsKey string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"zone_name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+
+		recordsKey: records,
+
+		"ttl": {
+			Type:     schema.TypeInt,
+			Required: true,
+		},
+
+		"fqdn": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+
+		"tags": tags.Schema(),
+	}
+}
+
+func legacyResource(s map[string]*schema.Schema, timeout time.Duration) *schema.Resource {
+	d := func(duration time.Duration) *time.Duration {
+		return &duration
+	}
+
+	return &schema.Resource{
+		Create:   func(_ *schema.ResourceData, _ interface{}) error { return nil },
+		Read:     func(_ *schema.ResourceData, _ interface{}) error { return nil },
+		Update:   func(_ *schema.ResourceData, _ interface{}) error { return nil },
+		Delete:   func(_ *schema.ResourceData, _ interface{}) error { return nil },
+		Importer: &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: d(timeout),
+			Read:   d(5 * time.Minute),
+			Update: d(timeout),
+			Delete: d(timeout),
+		},
+
+		Schema: s,
+	}
+}
+
+func legacyResourceGroupResource() *schema.Resource {
+	return legacyResource(map[string]*schema.Schema{
+		"name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"tags": commonschema.Tags(),
+	}, 90*time.Minute)
+}
+
+func legacyDnsARecordResource() *schema.Resource {
+	return legacyResource(legacyDnsRecordSchema(&schema.Schema{
+		Type:     schema.TypeSet,
+		Required: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Set:      schema.HashString,
+	}, "records"), 30*time.Minute)
+}
+
+func legacyDnsAAAARecordResource() *schema.Resource {
+	return legacyResource(legacyDnsRecordSchema(&schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.IsIPv6Address,
+		},
+		Set: set.HashIPv6Address,
+	}, "records"), 30*time.Minute)
+}
+
+func legacyDnsCNameRecordResource() *schema.Resource {
+	return legacyResource(legacyDnsRecordSchema(&schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}, "record"), 30*time.Minute)
+}
+
+func legacyDnsMxRecordResource() *schema.Resource {
+	r := legacyResource(legacyDnsRecordSchema(&schema.Schema{
+		Type:     schema.TypeSet,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"preference": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				"exchange": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+		},
+		Set: func(v interface{}) int {
+			var buf bytes.Buffer
+
+			if m, ok := v.(map[string]interface{}); ok {
+				buf.WriteString(fmt.Sprintf("%s-", m["preference"].(string)))
+				buf.WriteString(fmt.Sprintf("%s-", m["exchange"].(string)))
+			}
+
+			return schema.HashString(buf.String())
+		},
+	}, "record"), 30*time.Minute)
+
+	r.Schema["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		Default:  "@",
+	}
+
+	return r
+}
+
+func legacyDnsNsRecordResource() *schema.Resource {
+	r := legacyResource(legacyDnsRecordSchema(&schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}, "records"), 30*time.Minute)
+
+	r.Schema["zone_name"].ForceNew = true
+
+	return r
+}
+
+func legacyDnsPtrRecordResource() *schema.Resource {
+	return legacyResource(legacyDnsRecordSchema(&schema.Schema{
+		Type:     schema.TypeSet,
+		Required: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Set:      schema.HashString,
+	}, "records"), 30*time.Minute)
+}
+
+func legacyDnsSrvRecordResource() *schema.Resource {
+	return legacyResource(legacyDnsRecordSchema(&schema.Schema{
+		Type:     schema.TypeSet,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"priority": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+
+				"weight": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+
+				"port": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+
+				"target": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+		},
+		Set: func(v interface{}) int {
+			var buf bytes.Buffer
+
+			if m, ok := v.(map[string]interface{}); ok {
+				buf.WriteString(fmt.Sprintf("%d-", m["priority"].(int)))
+				buf.WriteString(fmt.Sprintf("%d-", m["weight"].(int)))
+				buf.WriteString(fmt.Sprintf("%d-", m["port"].(int)))
+				buf.WriteString(fmt.Sprintf("%s-", m["target"].(string)))
+			}
+
+			return schema.HashString(buf.String())
+		},
+	}, "record"), 30*time.Minute)
+}
+
+func legacyDnsTxtRecordResource() *schema.Resource {
+	return legacyResource(legacyDnsRecordSchema(&schema.Schema{
+		Type:     schema.TypeSet,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"value": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringLenBetween(1, 1024),
+				},
+			},
+		},
+	}, "record"), 30*time.Minute)
+}
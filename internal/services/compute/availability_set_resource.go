@@ -14,8 +14,10 @@ import (
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/suppress"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
@@ -76,6 +78,15 @@ func availabilitySet() *pluginsdk.Resource {
 				ForceNew: true,
 			},
 
+			"proximity_placement_group_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ProximityPlacementGroupID,
+				// the Compute API returns the Resource Group name in UPPERCASE
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+
 			"tags": tags.Schema(),
 		},
 	}
@@ -119,6 +130,12 @@ func resourceAvailabilitySetCreateUpdate(d *pluginsdk.ResourceData, meta interfa
 		Tags: tags.Expand(t),
 	}
 
+	if v, ok := d.GetOk("proximity_placement_group_id"); ok {
+		availSet.AvailabilitySetProperties.ProximityPlacementGroup = &compute.SubResource{
+			ID: utils.String(v.(string)),
+		}
+	}
+
 	if managed {
 		n := "Aligned"
 		availSet.Sku = &compute.Sku{
@@ -165,6 +182,12 @@ func resourceAvailabilitySetRead(d *pluginsdk.ResourceData, meta interface{}) er
 	if props := resp.AvailabilitySetProperties; props != nil {
 		d.Set("platform_update_domain_count", props.PlatformUpdateDomainCount)
 		d.Set("platform_fault_domain_count", props.PlatformFaultDomainCount)
+
+		proximityPlacementGroupId := ""
+		if props.ProximityPlacementGroup != nil && props.ProximityPlacementGroup.ID != nil {
+			proximityPlacementGroupId = *props.ProximityPlacementGroup.ID
+		}
+		d.Set("proximity_placement_group_id", proximityPlacementGroupId)
 	}
 
 	return tags.FlattenAndSet(d, resp.Tags)
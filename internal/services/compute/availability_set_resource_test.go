@@ -124,6 +124,22 @@ func TestAccAvailabilitySet_unmanaged(t *testing.T) {
 	})
 }
 
+func TestAccAvailabilitySet_proximityPlacementGroup(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_availability_set", "test")
+	r := AvailabilitySetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.proximityPlacementGroup(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("proximity_placement_group_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (AvailabilitySetResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.AvailabilitySetID(state.ID)
 	if err != nil {
@@ -274,3 +290,29 @@ resource "azurestack_availability_set" "test" {
 }
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
+
+func (AvailabilitySetResource) proximityPlacementGroup(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurestack_proximity_placement_group" "test" {
+  name                = "acctestPPG-%[1]d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_availability_set" "test" {
+  name                         = "acctestavset-%[1]d"
+  location                     = azurestack_resource_group.test.location
+  resource_group_name          = azurestack_resource_group.test.name
+  proximity_placement_group_id = azurestack_proximity_placement_group.test.id
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
@@ -7,6 +7,9 @@ import (
 
 type Client struct {
 	AvailabilitySetsClient          *compute.AvailabilitySetsClient
+	DiskEncryptionSetsClient        *compute.DiskEncryptionSetsClient
+	DedicatedHostGroupsClient       *compute.DedicatedHostGroupsClient
+	DedicatedHostsClient            *compute.DedicatedHostsClient
 	DisksClient                     *compute.DisksClient
 	VMExtensionImageClient          *compute.VirtualMachineExtensionImagesClient
 	VMExtensionClient               *compute.VirtualMachineExtensionsClient
@@ -17,12 +20,26 @@ type Client struct {
 	VMClient                        *compute.VirtualMachinesClient
 	VMImageClient                   *compute.VirtualMachineImagesClient
 	ImageClient                     *compute.ImagesClient
+	ProximityPlacementGroupsClient  *compute.ProximityPlacementGroupsClient
+	SnapshotsClient                 *compute.SnapshotsClient
+	SSHPublicKeysClient             *compute.SSHPublicKeysClient
+	UsageClient                     *compute.UsageClient
+	VMSizesClient                   *compute.VirtualMachineSizesClient
 }
 
 func NewClient(o *common.ClientOptions) *Client {
 	availabilitySetsClient := compute.NewAvailabilitySetsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&availabilitySetsClient.Client, o.ResourceManagerAuthorizer)
 
+	diskEncryptionSetsClient := compute.NewDiskEncryptionSetsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&diskEncryptionSetsClient.Client, o.ResourceManagerAuthorizer)
+
+	dedicatedHostGroupsClient := compute.NewDedicatedHostGroupsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&dedicatedHostGroupsClient.Client, o.ResourceManagerAuthorizer)
+
+	dedicatedHostsClient := compute.NewDedicatedHostsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&dedicatedHostsClient.Client, o.ResourceManagerAuthorizer)
+
 	disksClient := compute.NewDisksClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&disksClient.Client, o.ResourceManagerAuthorizer)
 
@@ -56,8 +73,26 @@ func NewClient(o *common.ClientOptions) *Client {
 	imageClient := compute.NewImagesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&imageClient.Client, o.ResourceManagerAuthorizer)
 
+	proximityPlacementGroupsClient := compute.NewProximityPlacementGroupsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&proximityPlacementGroupsClient.Client, o.ResourceManagerAuthorizer)
+
+	snapshotsClient := compute.NewSnapshotsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&snapshotsClient.Client, o.ResourceManagerAuthorizer)
+
+	sshPublicKeysClient := compute.NewSSHPublicKeysClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&sshPublicKeysClient.Client, o.ResourceManagerAuthorizer)
+
+	usageClient := compute.NewUsageClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&usageClient.Client, o.ResourceManagerAuthorizer)
+
+	vmSizesClient := compute.NewVirtualMachineSizesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&vmSizesClient.Client, o.ResourceManagerAuthorizer)
+
 	return &Client{
 		AvailabilitySetsClient:          &availabilitySetsClient,
+		DiskEncryptionSetsClient:        &diskEncryptionSetsClient,
+		DedicatedHostGroupsClient:       &dedicatedHostGroupsClient,
+		DedicatedHostsClient:            &dedicatedHostsClient,
 		DisksClient:                     &disksClient,
 		VMExtensionImageClient:          &vmExtensionImageClient,
 		VMExtensionClient:               &vmExtensionClient,
@@ -68,5 +103,10 @@ func NewClient(o *common.ClientOptions) *Client {
 		VMClient:                        &vmClient,
 		VMImageClient:                   &vmImageClient,
 		ImageClient:                     &imageClient,
+		ProximityPlacementGroupsClient:  &proximityPlacementGroupsClient,
+		SnapshotsClient:                 &snapshotsClient,
+		SSHPublicKeysClient:             &sshPublicKeysClient,
+		UsageClient:                     &usageClient,
+		VMSizesClient:                   &vmSizesClient,
 	}
 }
@@ -0,0 +1,177 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func computeUsageDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: computeUsageDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"location": commonschema.Location(),
+
+			"names": {
+				Type:     pluginsdk.TypeSet,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"usages": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"localized_name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"unit": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"current_value": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"limit": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"remaining": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func computeUsageDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.UsageClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	loc := location.Normalize(d.Get("location").(string))
+
+	// the quota names are matched case-insensitively since the casing differs between Azure Stack versions
+	names := make(map[string]struct{})
+	for _, v := range d.Get("names").(*pluginsdk.Set).List() {
+		names[strings.ToLower(v.(string))] = struct{}{}
+	}
+
+	usages := make([]compute.Usage, 0)
+	iterator, err := client.ListComplete(ctx, loc)
+	if err != nil {
+		return fmt.Errorf("listing Compute Usages (Location %q): %+v", loc, err)
+	}
+	for iterator.NotDone() {
+		usage := iterator.Value()
+		if includeComputeUsage(usage, names) {
+			usages = append(usages, usage)
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("listing Compute Usages (Location %q): %+v", loc, err)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	d.Set("location", loc)
+
+	if err := d.Set("usages", flattenComputeUsages(usages)); err != nil {
+		return fmt.Errorf("setting `usages`: %+v", err)
+	}
+
+	return nil
+}
+
+func includeComputeUsage(usage compute.Usage, names map[string]struct{}) bool {
+	if len(names) == 0 {
+		return true
+	}
+
+	if usage.Name == nil || usage.Name.Value == nil {
+		return false
+	}
+
+	_, ok := names[strings.ToLower(*usage.Name.Value)]
+	return ok
+}
+
+func flattenComputeUsages(input []compute.Usage) []interface{} {
+	results := make([]interface{}, 0)
+
+	for _, usage := range input {
+		name := ""
+		localizedName := ""
+		if usage.Name != nil {
+			if usage.Name.Value != nil {
+				name = *usage.Name.Value
+			}
+			if usage.Name.LocalizedValue != nil {
+				localizedName = *usage.Name.LocalizedValue
+			}
+		}
+
+		unit := ""
+		if usage.Unit != nil {
+			unit = *usage.Unit
+		}
+
+		currentValue := 0
+		if usage.CurrentValue != nil {
+			currentValue = int(*usage.CurrentValue)
+		}
+
+		limit := 0
+		if usage.Limit != nil {
+			limit = int(*usage.Limit)
+		}
+
+		remaining := limit - currentValue
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		results = append(results, map[string]interface{}{
+			"name":           name,
+			"localized_name": localizedName,
+			"unit":           unit,
+			"current_value":  currentValue,
+			"limit":          limit,
+			"remaining":      remaining,
+		})
+	}
+
+	return results
+}
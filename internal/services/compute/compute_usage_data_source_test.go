@@ -0,0 +1,69 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type ComputeUsageDataSource struct{}
+
+func TestAccComputeUsageDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_compute_usage", "test")
+	r := ComputeUsageDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("usages.#").Exists(),
+				check.That(data.ResourceName).Key("usages.0.name").Exists(),
+				check.That(data.ResourceName).Key("usages.0.limit").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccComputeUsageDataSource_names(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_compute_usage", "test")
+	r := ComputeUsageDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.names(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("usages.#").HasValue("1"),
+				check.That(data.ResourceName).Key("usages.0.name").HasValue("cores"),
+				check.That(data.ResourceName).Key("usages.0.current_value").Exists(),
+				check.That(data.ResourceName).Key("usages.0.remaining").Exists(),
+			),
+		},
+	})
+}
+
+func (ComputeUsageDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+data "azurestack_compute_usage" "test" {
+  location = "%s"
+}
+`, data.Locations.Primary)
+}
+
+func (ComputeUsageDataSource) names(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+data "azurestack_compute_usage" "test" {
+  location = "%s"
+  names    = ["cores"]
+}
+`, data.Locations.Primary)
+}
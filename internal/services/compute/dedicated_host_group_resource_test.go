@@ -0,0 +1,142 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+type DedicatedHostGroupResource struct{}
+
+func TestAccDedicatedHostGroup_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_dedicated_host_group", "test")
+	r := DedicatedHostGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("platform_fault_domain_count").HasValue("2"),
+				check.That(data.ResourceName).Key("automatic_placement_enabled").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDedicatedHostGroup_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_dedicated_host_group", "test")
+	r := DedicatedHostGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccDedicatedHostGroup_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_dedicated_host_group", "test")
+	r := DedicatedHostGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("automatic_placement_enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags.ENV").HasValue("prod"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (DedicatedHostGroupResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.DedicatedHostGroupID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.DedicatedHostGroupsClient.Get(ctx, id.ResourceGroup, id.HostGroupName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return pointer.FromBool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.ID != nil), nil
+}
+
+func (DedicatedHostGroupResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-compute-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurestack_dedicated_host_group" "test" {
+  name                        = "acctestDHG-compute-%[1]d"
+  resource_group_name         = azurestack_resource_group.test.name
+  location                    = azurestack_resource_group.test.location
+  platform_fault_domain_count = 2
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r DedicatedHostGroupResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_dedicated_host_group" "import" {
+  name                        = azurestack_dedicated_host_group.test.name
+  resource_group_name         = azurestack_dedicated_host_group.test.resource_group_name
+  location                    = azurestack_dedicated_host_group.test.location
+  platform_fault_domain_count = 2
+}
+`, r.basic(data))
+}
+
+func (DedicatedHostGroupResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-compute-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurestack_dedicated_host_group" "test" {
+  name                        = "acctestDHG-compute-%[1]d"
+  resource_group_name         = azurestack_resource_group.test.name
+  location                    = azurestack_resource_group.test.location
+  platform_fault_domain_count = 2
+  automatic_placement_enabled = true
+
+  tags = {
+    ENV = "prod"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
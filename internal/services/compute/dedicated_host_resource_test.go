@@ -0,0 +1,166 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+type DedicatedHostResource struct{}
+
+func TestAccDedicatedHost_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_dedicated_host", "test")
+	r := DedicatedHostResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("auto_replace_on_failure").HasValue("true"),
+				check.That(data.ResourceName).Key("license_type").HasValue("None"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDedicatedHost_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_dedicated_host", "test")
+	r := DedicatedHostResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccDedicatedHost_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_dedicated_host", "test")
+	r := DedicatedHostResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("auto_replace_on_failure").HasValue("false"),
+				check.That(data.ResourceName).Key("license_type").HasValue("Windows_Server_Hybrid"),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (DedicatedHostResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.DedicatedHostID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.DedicatedHostsClient.Get(ctx, id.ResourceGroup, id.HostGroupName, id.HostName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return pointer.FromBool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.ID != nil), nil
+}
+
+func (DedicatedHostResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-compute-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurestack_dedicated_host_group" "test" {
+  name                        = "acctestDHG-compute-%[1]d"
+  resource_group_name         = azurestack_resource_group.test.name
+  location                    = azurestack_resource_group.test.location
+  platform_fault_domain_count = 2
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r DedicatedHostResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_dedicated_host" "test" {
+  name                    = "acctestDH-compute-%d"
+  location                = azurestack_resource_group.test.location
+  dedicated_host_group_id = azurestack_dedicated_host_group.test.id
+  sku_name                = "DSv3-Type1"
+  platform_fault_domain   = 1
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r DedicatedHostResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_dedicated_host" "import" {
+  name                    = azurestack_dedicated_host.test.name
+  location                = azurestack_dedicated_host.test.location
+  dedicated_host_group_id = azurestack_dedicated_host.test.dedicated_host_group_id
+  sku_name                = azurestack_dedicated_host.test.sku_name
+  platform_fault_domain   = azurestack_dedicated_host.test.platform_fault_domain
+}
+`, r.basic(data))
+}
+
+func (r DedicatedHostResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_dedicated_host" "test" {
+  name                    = "acctestDH-compute-%d"
+  location                = azurestack_resource_group.test.location
+  dedicated_host_group_id = azurestack_dedicated_host_group.test.id
+  sku_name                = "DSv3-Type1"
+  platform_fault_domain   = 1
+  auto_replace_on_failure = false
+  license_type            = "Windows_Server_Hybrid"
+
+  tags = {
+    ENV = "prod"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
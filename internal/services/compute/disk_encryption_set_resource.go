@@ -0,0 +1,300 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	keyVaultParse "github.com/hashicorp/terraform-provider-azurestack/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurestack/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func diskEncryptionSet() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: diskEncryptionSetCreate,
+		Read:   diskEncryptionSetRead,
+		Update: diskEncryptionSetUpdate,
+		Delete: diskEncryptionSetDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.DiskEncryptionSetID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"location": commonschema.Location(),
+
+			"resource_group_name": commonschema.ResourceGroupName(),
+
+			"key_vault_key_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: keyVaultValidate.NestedItemId,
+			},
+
+			"identity": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						// whilst the API Documentation shows optional - attempting to send nothing returns:
+						// `Required parameter 'ResourceIdentity' is missing (null)`
+						"type": {
+							Type:     pluginsdk.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(compute.SystemAssigned),
+							}, false),
+						},
+
+						"principal_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"tenant_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+}
+
+func diskEncryptionSetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.DiskEncryptionSetsClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewDiskEncryptionSetID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return tf.ImportAsExistsError("azurestack_disk_encryption_set", id.ID())
+	}
+
+	activeKey, err := expandDiskEncryptionSetActiveKey(ctx, meta, d.Get("key_vault_key_id").(string))
+	if err != nil {
+		return err
+	}
+
+	params := compute.DiskEncryptionSet{
+		Location: utils.String(location.Normalize(d.Get("location").(string))),
+		EncryptionSetProperties: &compute.EncryptionSetProperties{
+			ActiveKey: activeKey,
+		},
+		Identity: expandDiskEncryptionSetIdentity(d.Get("identity").([]interface{})),
+		Tags:     tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, params)
+	if err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return diskEncryptionSetRead(d, meta)
+}
+
+func diskEncryptionSetRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.DiskEncryptionSetsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DiskEncryptionSetID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s does not exist - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("location", location.NormalizeNilable(resp.Location))
+
+	keyVaultKeyId := ""
+	if props := resp.EncryptionSetProperties; props != nil && props.ActiveKey != nil && props.ActiveKey.KeyURL != nil {
+		keyVaultKeyId = *props.ActiveKey.KeyURL
+	}
+	d.Set("key_vault_key_id", keyVaultKeyId)
+
+	if err := d.Set("identity", flattenDiskEncryptionSetIdentity(resp.Identity)); err != nil {
+		return fmt.Errorf("setting `identity`: %+v", err)
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func diskEncryptionSetUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.DiskEncryptionSetsClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DiskEncryptionSetID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	update := compute.DiskEncryptionSetUpdate{}
+
+	if d.HasChanges("tags", "tags_all") {
+		update.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
+	}
+
+	if d.HasChange("key_vault_key_id") {
+		activeKey, err := expandDiskEncryptionSetActiveKey(ctx, meta, d.Get("key_vault_key_id").(string))
+		if err != nil {
+			return err
+		}
+
+		update.DiskEncryptionSetUpdateProperties = &compute.DiskEncryptionSetUpdateProperties{
+			ActiveKey: activeKey,
+		}
+	}
+
+	future, err := client.Update(ctx, id.ResourceGroup, id.Name, update)
+	if err != nil {
+		return fmt.Errorf("updating %s: %+v", id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for update of %s: %+v", id, err)
+	}
+
+	return diskEncryptionSetRead(d, meta)
+}
+
+func diskEncryptionSetDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.DiskEncryptionSetsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DiskEncryptionSetID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+// expandDiskEncryptionSetActiveKey looks up the Key Vault which contains the `key_vault_key_id` since the API
+// requires the Resource ID of the Key Vault alongside the Key URL
+func expandDiskEncryptionSetActiveKey(ctx context.Context, meta interface{}, keyVaultKeyId string) (*compute.KeyVaultAndKeyReference, error) {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	resourcesClient := meta.(*clients.Client).Resource
+
+	keyId, err := keyVaultParse.ParseNestedItemID(keyVaultKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	keyVaultId, err := keyVaultsClient.KeyVaultIDFromBaseUrl(ctx, resourcesClient, keyId.KeyVaultBaseUrl)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the Resource ID of the Key Vault at URL %q: %+v", keyId.KeyVaultBaseUrl, err)
+	}
+	if keyVaultId == nil {
+		return nil, fmt.Errorf("unable to determine the Resource ID for the Key Vault at URL %q", keyId.KeyVaultBaseUrl)
+	}
+
+	return &compute.KeyVaultAndKeyReference{
+		KeyURL: utils.String(keyVaultKeyId),
+		SourceVault: &compute.SourceVault{
+			ID: keyVaultId,
+		},
+	}, nil
+}
+
+func expandDiskEncryptionSetIdentity(input []interface{}) *compute.EncryptionSetIdentity {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	return &compute.EncryptionSetIdentity{
+		Type: compute.DiskEncryptionSetIdentityType(raw["type"].(string)),
+	}
+}
+
+func flattenDiskEncryptionSetIdentity(input *compute.EncryptionSetIdentity) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	principalId := ""
+	if input.PrincipalID != nil {
+		principalId = *input.PrincipalID
+	}
+
+	tenantId := ""
+	if input.TenantID != nil {
+		tenantId = *input.TenantID
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":         string(input.Type),
+			"principal_id": principalId,
+			"tenant_id":    tenantId,
+		},
+	}
+}
@@ -0,0 +1,240 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+type DiskEncryptionSetResource struct{}
+
+func TestAccDiskEncryptionSet_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_disk_encryption_set", "test")
+	r := DiskEncryptionSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("identity.0.principal_id").Exists(),
+				check.That(data.ResourceName).Key("identity.0.tenant_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDiskEncryptionSet_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_disk_encryption_set", "test")
+	r := DiskEncryptionSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccDiskEncryptionSet_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_disk_encryption_set", "test")
+	r := DiskEncryptionSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.updated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags.Hello").HasValue("woRld"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (DiskEncryptionSetResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.DiskEncryptionSetID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.DiskEncryptionSetsClient.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return pointer.FromBool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.ID != nil), nil
+}
+
+func (r DiskEncryptionSetResource) dependencies(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+%s
+`, data.RandomInteger, data.Locations.Primary, r.keyVault(data))
+}
+
+// keyVault returns a Key Vault and Key which can be used by a Disk Encryption Set, it expects
+// `azurestack_resource_group.test` to be defined
+func (DiskEncryptionSetResource) keyVault(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azurestack_client_config" "current" {}
+
+resource "azurestack_key_vault" "test" {
+  name                = "acctestkv%s"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  tenant_id           = data.azurestack_client_config.current.tenant_id
+  sku_name            = "standard"
+}
+
+resource "azurestack_key_vault_access_policy" "service-principal" {
+  key_vault_id = azurestack_key_vault.test.id
+  tenant_id    = data.azurestack_client_config.current.tenant_id
+  object_id    = data.azurestack_client_config.current.service_principal_object_id
+
+  key_permissions = [
+    "Create",
+    "Delete",
+    "Get",
+    "Purge",
+    "Update",
+  ]
+
+  secret_permissions = [
+    "Get",
+    "Delete",
+    "Set",
+  ]
+}
+
+resource "azurestack_key_vault_key" "test" {
+  name         = "examplekey"
+  key_vault_id = azurestack_key_vault.test.id
+  key_type     = "RSA"
+  key_size     = 2048
+
+  key_opts = [
+    "decrypt",
+    "encrypt",
+    "sign",
+    "unwrapKey",
+    "verify",
+    "wrapKey",
+  ]
+
+  depends_on = ["azurestack_key_vault_access_policy.service-principal"]
+}
+`, data.RandomString)
+}
+
+// grantAccess grants the Disk Encryption Set's identity access to the Key Vault Key, which is required
+// before the Disk Encryption Set can be used to encrypt disks
+func (DiskEncryptionSetResource) grantAccess() string {
+	return `
+resource "azurestack_key_vault_access_policy" "disk-encryption" {
+  key_vault_id = azurestack_key_vault.test.id
+
+  key_permissions = [
+    "Get",
+    "WrapKey",
+    "UnwrapKey",
+  ]
+
+  tenant_id = azurestack_disk_encryption_set.test.identity.0.tenant_id
+  object_id = azurestack_disk_encryption_set.test.identity.0.principal_id
+}
+`
+}
+
+func (r DiskEncryptionSetResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+`, r.dependencies(data), r.diskEncryptionSet(data))
+}
+
+// diskEncryptionSet returns a Disk Encryption Set which depends on the resources from `keyVault`
+func (DiskEncryptionSetResource) diskEncryptionSet(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azurestack_disk_encryption_set" "test" {
+  name                = "acctestDES-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  key_vault_key_id    = azurestack_key_vault_key.test.id
+
+  identity {
+    type = "SystemAssigned"
+  }
+}
+`, data.RandomInteger)
+}
+
+func (r DiskEncryptionSetResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_disk_encryption_set" "import" {
+  name                = azurestack_disk_encryption_set.test.name
+  resource_group_name = azurestack_disk_encryption_set.test.resource_group_name
+  location            = azurestack_disk_encryption_set.test.location
+  key_vault_key_id    = azurestack_disk_encryption_set.test.key_vault_key_id
+
+  identity {
+    type = "SystemAssigned"
+  }
+}
+`, r.basic(data))
+}
+
+func (r DiskEncryptionSetResource) updated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_disk_encryption_set" "test" {
+  name                = "acctestDES-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  key_vault_key_id    = azurestack_key_vault_key.test.id
+
+  identity {
+    type = "SystemAssigned"
+  }
+
+  tags = {
+    Hello = "woRld"
+  }
+}
+`, r.dependencies(data), data.RandomInteger)
+}
@@ -0,0 +1,41 @@
+package compute
+
+import (
+	"sort"
+
+	"github.com/hashicorp/go-version"
+)
+
+// versionIsNewer returns whether version `a` is newer than version `b`. Platform and Extension Image versions
+// are usually semantic (e.g. `16.04.202007080` or `2.1.3`) so these are compared numerically, any versions
+// which can't be parsed are considered older than those which can and are compared lexically.
+func versionIsNewer(a, b string) bool {
+	av, aErr := version.NewVersion(a)
+	bv, bErr := version.NewVersion(b)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		if av.Equal(bv) {
+			return a > b
+		}
+		return av.GreaterThan(bv)
+	case aErr == nil:
+		return true
+	case bErr == nil:
+		return false
+	default:
+		return a > b
+	}
+}
+
+// sortVersionsDescending returns a copy of the input sorted from the newest to the oldest version
+func sortVersionsDescending(input []string) []string {
+	output := make([]string, len(input))
+	copy(output, input)
+
+	sort.SliceStable(output, func(i, j int) bool {
+		return versionIsNewer(output[i], output[j])
+	})
+
+	return output
+}
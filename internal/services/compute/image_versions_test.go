@@ -0,0 +1,85 @@
+package compute
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVersionIsNewer(t *testing.T) {
+	testData := []struct {
+		A        string
+		B        string
+		Expected bool
+	}{
+		{
+			A:        "2.1.3",
+			B:        "2.1.2",
+			Expected: true,
+		},
+		{
+			A:        "2.10.0",
+			B:        "2.9.0",
+			Expected: true,
+		},
+		{
+			A:        "16.04.202007080",
+			B:        "16.04.202102010",
+			Expected: false,
+		},
+		{
+			A:        "1.0",
+			B:        "1.0.0",
+			Expected: false,
+		},
+		{
+			A:        "1.0.0",
+			B:        "latest",
+			Expected: true,
+		},
+		{
+			A:        "latest",
+			B:        "1.0.0",
+			Expected: false,
+		},
+		{
+			A:        "beta",
+			B:        "alpha",
+			Expected: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q > %q", v.A, v.B)
+
+		actual := versionIsNewer(v.A, v.B)
+		if actual != v.Expected {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+func TestSortVersionsDescending(t *testing.T) {
+	input := []string{
+		"1.2.9",
+		"1.10.0",
+		"preview",
+		"1.2.10",
+		"0.9",
+	}
+	expected := []string{
+		"1.10.0",
+		"1.2.10",
+		"1.2.9",
+		"0.9",
+		"preview",
+	}
+
+	actual := sortVersionsDescending(input)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+
+	if input[0] != "1.2.9" {
+		t.Fatalf("Expected the input to be left unmodified but got %+v", input)
+	}
+}
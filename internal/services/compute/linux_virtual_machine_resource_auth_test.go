@@ -68,6 +68,21 @@ func TestAccLinuxVirtualMachine_authSSHMultipleKeys(t *testing.T) {
 	})
 }
 
+func TestAccLinuxVirtualMachine_authSSHPublicKeyResource(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.authSSHPublicKeyResource(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (r LinuxVirtualMachineResource) authPassword(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -206,3 +221,43 @@ resource "azurestack_linux_virtual_machine" "test" {
 }
 `, r.template(data), data.RandomInteger)
 }
+
+func (r LinuxVirtualMachineResource) authSSHPublicKeyResource(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_ssh_public_key" "test" {
+  name                = "acctestsshkey-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+}
+
+resource "azurestack_linux_virtual_machine" "test" {
+  name                = "acctestVM-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  size                = "Standard_F2"
+  admin_username      = "adminuser"
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = azurestack_ssh_public_key.test.public_key
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
@@ -0,0 +1,382 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+func TestAccLinuxVirtualMachine_diskDataEmpty(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.diskDataEmpty(data, "ReadWrite"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_disk.#").HasValue("1"),
+				check.That(data.ResourceName).Key("data_disk.0.create_option").HasValue("Empty"),
+				check.That(data.ResourceName).Key("data_disk.0.managed_disk_id").Exists(),
+			),
+		},
+		data.ImportStep("data_disk"),
+	})
+}
+
+func TestAccLinuxVirtualMachine_diskDataEmptyImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.diskDataEmpty(data, "ReadWrite"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_disk.#").HasValue("1"),
+			),
+		},
+		{
+			// the `data_disk` block isn't populated when importing, since the Data Disks could instead be
+			// managed using the `azurestack_virtual_machine_data_disk_attachment` resource
+			ResourceName: data.ResourceName,
+			ImportState:  true,
+			ImportStateCheck: func(states []*acceptance.InstanceState) error {
+				if len(states) != 1 {
+					return fmt.Errorf("expected 1 state but got %d", len(states))
+				}
+				if v, ok := states[0].Attributes["data_disk.#"]; ok && v != "0" {
+					return fmt.Errorf("expected `data_disk` not to be populated but got %s blocks", v)
+				}
+				return nil
+			},
+		},
+	})
+}
+
+func TestAccLinuxVirtualMachine_diskDataEmptyDeleted(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	var dataDiskId string
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.diskDataEmpty(data, "ReadWrite"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.findDataDiskID(&dataDiskId)),
+			),
+		},
+		{
+			// removing the `data_disk` block should delete the Data Disk
+			Config: r.authSSH(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_disk.#").HasValue("0"),
+				data.CheckWithClientWithoutResource(VirtualMachineResource{}.managedDiskExists(&dataDiskId, false)),
+			),
+		},
+		{
+			Config: r.diskDataEmpty(data, "ReadWrite"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.findDataDiskID(&dataDiskId)),
+			),
+		},
+		{
+			// destroying the Virtual Machine should delete the Data Disk
+			Config: r.template(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				data.CheckWithClientWithoutResource(VirtualMachineResource{}.managedDiskExists(&dataDiskId, false)),
+			),
+		},
+		{
+			// which means the Virtual Machine can be recreated with a Data Disk of the same name
+			Config: r.diskDataEmpty(data, "ReadWrite"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_disk.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("data_disk"),
+	})
+}
+
+func (LinuxVirtualMachineResource) findDataDiskID(managedDiskId *string) acceptance.ClientCheckFunc {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		id := state.Attributes["data_disk.0.managed_disk_id"]
+		if id == "" {
+			return fmt.Errorf("`data_disk.0.managed_disk_id` was empty")
+		}
+
+		*managedDiskId = id
+		return nil
+	}
+}
+
+func TestAccLinuxVirtualMachine_diskDataAttachExisting(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.diskDataAttachExisting(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_disk.#").HasValue("1"),
+				check.That(data.ResourceName).Key("data_disk.0.create_option").HasValue("Attach"),
+				check.That(data.ResourceName).Key("data_disk.0.storage_account_type").HasValue("Standard_LRS"),
+			),
+		},
+		data.ImportStep("data_disk"),
+	})
+}
+
+func TestAccLinuxVirtualMachine_diskDataMultiple(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.diskDataAttachExisting(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_disk.#").HasValue("1"),
+			),
+		},
+		{
+			Config: r.diskDataMultiple(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_disk.#").HasValue("2"),
+			),
+		},
+		{
+			Config: r.diskDataAttachExisting(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_disk.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAccLinuxVirtualMachine_diskDataUpdatingCaching(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.diskDataEmpty(data, "ReadOnly"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_disk.0.caching").HasValue("ReadOnly"),
+			),
+		},
+		{
+			Config: r.diskDataEmpty(data, "ReadWrite"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_disk.0.caching").HasValue("ReadWrite"),
+			),
+		},
+	})
+}
+
+func TestAccLinuxVirtualMachine_diskDataConflictsWithAttachment(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.diskDataConflictsWithAttachment(data, "ReadOnly"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_disk.#").HasValue("1"),
+			),
+		},
+		{
+			Config:      r.diskDataConflictsWithAttachment(data, "ReadWrite"),
+			ExpectError: regexp.MustCompile("outside of the `data_disk` block"),
+		},
+	})
+}
+
+func (r LinuxVirtualMachineResource) diskDataEmpty(data acceptance.TestData, caching string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_linux_virtual_machine" "test" {
+  name                = "acctestVM-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  size                = "Standard_F2"
+  admin_username      = "adminuser"
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = local.first_public_key
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  data_disk {
+    name                 = "acctestdd-%d"
+    lun                  = 0
+    caching              = "%s"
+    create_option        = "Empty"
+    disk_size_gb         = 10
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger, caching)
+}
+
+func (r LinuxVirtualMachineResource) diskDataAttachExisting(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_managed_disk" "test" {
+  name                 = "acctestd-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = 10
+}
+
+resource "azurestack_linux_virtual_machine" "test" {
+  name                = "acctestVM-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  size                = "Standard_F2"
+  admin_username      = "adminuser"
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = local.first_public_key
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  data_disk {
+    lun             = 0
+    caching         = "None"
+    managed_disk_id = azurestack_managed_disk.test.id
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
+
+func (r LinuxVirtualMachineResource) diskDataMultiple(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_managed_disk" "test" {
+  name                 = "acctestd-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = 10
+}
+
+resource "azurestack_linux_virtual_machine" "test" {
+  name                = "acctestVM-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  size                = "Standard_F2"
+  admin_username      = "adminuser"
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = local.first_public_key
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  data_disk {
+    lun             = 0
+    caching         = "None"
+    managed_disk_id = azurestack_managed_disk.test.id
+  }
+
+  data_disk {
+    name                 = "acctestdd-%d"
+    lun                  = 1
+    caching              = "ReadOnly"
+    create_option        = "Empty"
+    disk_size_gb         = 10
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
+func (r LinuxVirtualMachineResource) diskDataConflictsWithAttachment(data acceptance.TestData, caching string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_managed_disk" "attachment" {
+  name                 = "acctestd-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = 10
+}
+
+resource "azurestack_virtual_machine_data_disk_attachment" "test" {
+  managed_disk_id    = azurestack_managed_disk.attachment.id
+  virtual_machine_id = azurestack_linux_virtual_machine.test.id
+  lun                = 1
+  caching            = "None"
+}
+`, r.diskDataEmpty(data, caching), data.RandomInteger)
+}
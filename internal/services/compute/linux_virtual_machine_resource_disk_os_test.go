@@ -104,6 +104,22 @@ func TestAccLinuxVirtualMachine_diskOSCustomSizeExpanded(t *testing.T) {
 	})
 }
 
+func TestAccLinuxVirtualMachine_diskOSDiskEncryptionSet(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.diskOSDiskEncryptionSet(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("os_disk.0.disk_encryption_set_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccLinuxVirtualMachine_diskOSEphemeral(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
 	r := LinuxVirtualMachineResource{}
@@ -451,3 +467,49 @@ resource "azurestack_linux_virtual_machine" "test" {
 }
 `, r.template(data), data.RandomInteger, enabled)
 }
+
+func (r LinuxVirtualMachineResource) diskOSDiskEncryptionSet(data acceptance.TestData) string {
+	des := DiskEncryptionSetResource{}
+	return fmt.Sprintf(`
+%s
+
+%s
+
+%s
+
+%s
+
+resource "azurestack_linux_virtual_machine" "test" {
+  name                = "acctestVM-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  size                = "Standard_F2"
+  admin_username      = "adminuser"
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = local.first_public_key
+  }
+
+  os_disk {
+    caching                = "ReadWrite"
+    storage_account_type   = "Standard_LRS"
+    disk_encryption_set_id = azurestack_disk_encryption_set.test.id
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  depends_on = [
+    azurestack_key_vault_access_policy.disk-encryption,
+  ]
+}
+`, r.template(data), des.keyVault(data), des.diskEncryptionSet(data), des.grantAccess(), data.RandomInteger)
+}
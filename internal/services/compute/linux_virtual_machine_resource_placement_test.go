@@ -0,0 +1,132 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+func TestAccLinuxVirtualMachine_placementProximityPlacementGroup(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.placementProximityPlacementGroup(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("proximity_placement_group_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccLinuxVirtualMachine_placementDedicatedHost(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.placementDedicatedHost(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("dedicated_host_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r LinuxVirtualMachineResource) placementProximityPlacementGroup(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_proximity_placement_group" "test" {
+  name                = "acctestPPG-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_linux_virtual_machine" "test" {
+  name                         = "acctestVM-%d"
+  resource_group_name          = azurestack_resource_group.test.name
+  location                     = azurestack_resource_group.test.location
+  size                         = "Standard_F2"
+  admin_username               = "adminuser"
+  proximity_placement_group_id = azurestack_proximity_placement_group.test.id
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = local.first_public_key
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
+
+func (r LinuxVirtualMachineResource) placementDedicatedHost(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_dedicated_host_group" "test" {
+  name                        = "acctestDHG-%[2]d"
+  resource_group_name         = azurestack_resource_group.test.name
+  location                    = azurestack_resource_group.test.location
+  platform_fault_domain_count = 2
+}
+
+resource "azurestack_dedicated_host" "test" {
+  name                    = "acctestDH-%[2]d"
+  location                = azurestack_resource_group.test.location
+  dedicated_host_group_id = azurestack_dedicated_host_group.test.id
+  sku_name                = "DSv3-Type1"
+  platform_fault_domain   = 1
+}
+
+resource "azurestack_linux_virtual_machine" "test" {
+  name                = "acctestVM-%[2]d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  size                = "Standard_D2s_v3"
+  admin_username      = "adminuser"
+  dedicated_host_id   = azurestack_dedicated_host.test.id
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = local.first_public_key
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
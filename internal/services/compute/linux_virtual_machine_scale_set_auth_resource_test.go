@@ -38,6 +38,21 @@ func TestAccLinuxVirtualMachineScaleSet_authSSHKey(t *testing.T) {
 	})
 }
 
+func TestAccLinuxVirtualMachineScaleSet_authSSHPublicKeyResource(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine_scale_set", "test")
+	r := LinuxVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.authSSHPublicKeyResource(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccLinuxVirtualMachineScaleSet_authSSHKeyAndPassword(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine_scale_set", "test")
 	r := LinuxVirtualMachineScaleSetResource{}
@@ -340,3 +355,53 @@ resource "azurestack_linux_virtual_machine_scale_set" "test" {
 }
 `, r.template(data), data.RandomInteger)
 }
+
+func (r LinuxVirtualMachineScaleSetResource) authSSHPublicKeyResource(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_ssh_public_key" "test" {
+  name                = "acctestsshkey-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  public_key          = local.first_public_key
+}
+
+resource "azurestack_linux_virtual_machine_scale_set" "test" {
+  name                = "acctestvmss-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  sku                 = "Standard_F2"
+  instances           = 1
+  admin_username      = "adminuser"
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = azurestack_ssh_public_key.test.public_key
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  os_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+  }
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = azurestack_subnet.test.id
+    }
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
@@ -68,6 +68,22 @@ func TestAccLinuxVirtualMachineScaleSet_disksOSDiskCustomSize(t *testing.T) {
 	})
 }
 
+func TestAccLinuxVirtualMachineScaleSet_disksOSDiskDiskEncryptionSet(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine_scale_set", "test")
+	r := LinuxVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.disksOSDiskDiskEncryptionSet(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("os_disk.0.disk_encryption_set_id").Exists(),
+			),
+		},
+		data.ImportStep("admin_password"),
+	})
+}
+
 func TestAccLinuxVirtualMachineScaleSet_disksOSDiskEphemeral(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine_scale_set", "test")
 	r := LinuxVirtualMachineScaleSetResource{}
@@ -334,3 +350,56 @@ resource "azurestack_linux_virtual_machine_scale_set" "test" {
 }
 `, r.template(data), data.RandomInteger, enabled)
 }
+
+func (r LinuxVirtualMachineScaleSetResource) disksOSDiskDiskEncryptionSet(data acceptance.TestData) string {
+	des := DiskEncryptionSetResource{}
+	return fmt.Sprintf(`
+%s
+
+%s
+
+%s
+
+%s
+
+resource "azurestack_linux_virtual_machine_scale_set" "test" {
+  name                = "acctestvmss-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  sku                 = "Standard_F2"
+  instances           = 1
+  admin_username      = "adminuser"
+  admin_password      = "P@ssword1234!"
+
+  disable_password_authentication = false
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  os_disk {
+    storage_account_type   = "Standard_LRS"
+    caching                = "ReadWrite"
+    disk_encryption_set_id = azurestack_disk_encryption_set.test.id
+  }
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = azurestack_subnet.test.id
+    }
+  }
+
+  depends_on = [
+    azurestack_key_vault_access_policy.disk-encryption,
+  ]
+}
+`, r.template(data), des.keyVault(data), des.diskEncryptionSet(data), des.grantAccess(), data.RandomInteger)
+}
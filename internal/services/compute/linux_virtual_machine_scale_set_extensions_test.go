@@ -113,6 +113,8 @@ func TestAccLinuxVirtualMachineScaleSet_extensionsMultiple(t *testing.T) {
 			Config: r.extensionMultiple(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("extension_instance_view_summary.#").HasValue("2"),
+				check.That(data.ResourceName).Key("extension_instance_view_summary.0.instance_view_summary.succeeded").HasValue("1"),
 			),
 		},
 		data.ImportStep("admin_password", "extension.0.protected_settings", "extension.1.protected_settings"),
@@ -0,0 +1,75 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+func TestAccLinuxVirtualMachineScaleSet_placementProximityPlacementGroup(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine_scale_set", "test")
+	r := LinuxVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.placementProximityPlacementGroup(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("proximity_placement_group_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r LinuxVirtualMachineScaleSetResource) placementProximityPlacementGroup(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_proximity_placement_group" "test" {
+  name                = "acctestPPG-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_linux_virtual_machine_scale_set" "test" {
+  name                         = "acctestvmss-%d"
+  resource_group_name          = azurestack_resource_group.test.name
+  location                     = azurestack_resource_group.test.location
+  sku                          = "Standard_F2"
+  instances                    = 1
+  admin_username               = "adminuser"
+  proximity_placement_group_id = azurestack_proximity_placement_group.test.id
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = local.first_public_key
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  os_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+  }
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = azurestack_subnet.test.id
+    }
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/base64"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/suppress"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
@@ -132,6 +133,8 @@ func resourceLinuxVirtualMachineScaleSet() *pluginsdk.Resource {
 
 			"extension": VirtualMachineScaleSetExtensionsSchema(),
 
+			"extension_instance_view_summary": virtualMachineScaleSetExtensionsInstanceViewSummarySchema(),
+
 			// TODO: Uncomment extensions_time_budget when it's fixed
 			/*"extensions_time_budget": {
 				Type:         pluginsdk.TypeString,
@@ -168,6 +171,17 @@ func resourceLinuxVirtualMachineScaleSet() *pluginsdk.Resource {
 				ForceNew: true,
 			},
 
+			"proximity_placement_group_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ProximityPlacementGroupID,
+				// the Compute API returns the Resource Group name in UPPERCASE
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+
+			"rolling_upgrade_policy": VirtualMachineScaleSetRollingUpgradePolicySchema(),
+
 			"secret": linuxSecretSchema(),
 
 			"single_placement_group": {
@@ -319,6 +333,11 @@ func resourceLinuxVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta i
 		Mode:                     upgradeMode,
 		AutomaticOSUpgradePolicy: automaticOSUpgradePolicy,
 	}
+	// the Manual upgrade mode doesn't support a Rolling Upgrade Policy, instead this is used to batch the Instances
+	// when they're rolled by the provider during an update
+	if upgradeMode != compute.UpgradeModeManual {
+		upgradePolicy.RollingUpgradePolicy = ExpandVirtualMachineScaleSetRollingUpgradePolicy(d.Get("rolling_upgrade_policy").([]interface{}))
+	}
 
 	virtualMachineProfile := compute.VirtualMachineScaleSetVMProfile{
 		OsProfile: &compute.VirtualMachineScaleSetOSProfile{
@@ -415,6 +434,12 @@ func resourceLinuxVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta i
 		props.VirtualMachineScaleSetProperties.PlatformFaultDomainCount = utils.Int32(int32(v.(int)))
 	}
 
+	if v, ok := d.GetOk("proximity_placement_group_id"); ok {
+		props.VirtualMachineScaleSetProperties.ProximityPlacementGroup = &compute.SubResource{
+			ID: utils.String(v.(string)),
+		}
+	}
+
 	log.Printf("[DEBUG] Creating Linux %s", id)
 	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, props)
 	if err != nil {
@@ -429,6 +454,16 @@ func resourceLinuxVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta i
 
 	d.SetId(id.ID())
 
+	if extensionNames := virtualMachineScaleSetExtensionNames(d.Get("extension").(*pluginsdk.Set).List()); len(extensionNames) > 0 {
+		summary, err := retrieveVirtualMachineScaleSetExtensionsSummary(ctx, client, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVirtualMachineScaleSetExtensionsProvisioned(summary, extensionNames); err != nil {
+			return fmt.Errorf("provisioning Extensions for Linux %s: %+v", id, err)
+		}
+	}
+
 	return resourceLinuxVirtualMachineScaleSetRead(d, meta)
 }
 
@@ -483,7 +518,7 @@ func resourceLinuxVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta i
 		}
 	}
 
-	if d.HasChange("automatic_os_upgrade_policy") {
+	if d.HasChanges("automatic_os_upgrade_policy", "rolling_upgrade_policy") {
 		upgradePolicy := compute.UpgradePolicy{}
 		if existing.VirtualMachineScaleSetProperties.UpgradePolicy == nil {
 			upgradePolicy = compute.UpgradePolicy{
@@ -503,6 +538,10 @@ func resourceLinuxVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta i
 			}
 		}
 
+		if d.HasChange("rolling_upgrade_policy") && upgradePolicy.Mode != compute.UpgradeModeManual {
+			upgradePolicy.RollingUpgradePolicy = ExpandVirtualMachineScaleSetRollingUpgradePolicy(d.Get("rolling_upgrade_policy").([]interface{}))
+		}
+
 		updateProps.UpgradePolicy = &upgradePolicy
 	}
 
@@ -687,25 +726,45 @@ func resourceLinuxVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta i
 		updateProps.VirtualMachineProfile.ExtensionProfile = extensionProfile
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		update.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
 	}
 
 	update.VirtualMachineScaleSetUpdateProperties = &updateProps
 
+	_, hasHealthExtension, err := expandVirtualMachineScaleSetExtensions(d.Get("extension").(*pluginsdk.Set).List())
+	if err != nil {
+		return err
+	}
+
 	metaData := virtualMachineScaleSetUpdateMetaData{
-		AutomaticOSUpgradeIsEnabled: automaticOSUpgradeIsEnabled,
-		UpdateInstances:             updateInstances,
-		Client:                      meta.(*clients.Client).Compute,
-		Existing:                    existing,
-		ID:                          id,
-		OSType:                      compute.Linux,
+		AutomaticOSUpgradeIsEnabled:  automaticOSUpgradeIsEnabled,
+		CanRollInstancesWhenRequired: meta.(*clients.Client).Features.VirtualMachineScaleSet.RollInstancesWhenRequired,
+		UpdateInstances:              updateInstances,
+		HealthMonitoringEnabled:      d.Get("health_probe_id").(string) != "" || hasHealthExtension,
+		RollingUpgradePolicy:         ExpandVirtualMachineScaleSetRollingUpgradePolicy(d.Get("rolling_upgrade_policy").([]interface{})),
+		Client:                       meta.(*clients.Client).Compute,
+		Existing:                     existing,
+		ID:                           id,
+		OSType:                       compute.Linux,
 	}
 
 	if err := metaData.performUpdate(ctx, update); err != nil {
 		return err
 	}
 
+	if d.HasChange("extension") {
+		if extensionNames := virtualMachineScaleSetExtensionNames(d.Get("extension").(*pluginsdk.Set).List()); len(extensionNames) > 0 {
+			summary, err := retrieveVirtualMachineScaleSetExtensionsSummary(ctx, client, *id)
+			if err != nil {
+				return err
+			}
+			if err := checkVirtualMachineScaleSetExtensionsProvisioned(summary, extensionNames); err != nil {
+				return fmt.Errorf("provisioning Extensions for Linux %s: %+v", id, err)
+			}
+		}
+	}
+
 	return resourceLinuxVirtualMachineScaleSetRead(d, meta)
 }
 
@@ -766,6 +825,12 @@ func resourceLinuxVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta int
 	d.Set("do_not_run_extensions_on_overprovisioned_machines", props.DoNotRunExtensionsOnOverprovisionedVMs)
 	d.Set("overprovision", props.Overprovision)
 	d.Set("platform_fault_domain_count", props.PlatformFaultDomainCount)
+
+	proximityPlacementGroupId := ""
+	if props.ProximityPlacementGroup != nil && props.ProximityPlacementGroup.ID != nil {
+		proximityPlacementGroupId = *props.ProximityPlacementGroup.ID
+	}
+	d.Set("proximity_placement_group_id", proximityPlacementGroupId)
 	d.Set("single_placement_group", props.SinglePlacementGroup)
 	d.Set("unique_id", props.UniqueID)
 
@@ -853,6 +918,14 @@ func resourceLinuxVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta int
 		}
 		d.Set("extension", extensionProfile)
 
+		extensionsSummary, err := retrieveVirtualMachineScaleSetExtensionsSummary(ctx, client, *id)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("extension_instance_view_summary", flattenVirtualMachineScaleSetExtensionsSummary(extensionsSummary)); err != nil {
+			return fmt.Errorf("setting `extension_instance_view_summary`: %+v", err)
+		}
+
 		encryptionAtHostEnabled := false
 
 		d.Set("encryption_at_host_enabled", encryptionAtHostEnabled)
@@ -865,6 +938,15 @@ func resourceLinuxVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta int
 		if err := d.Set("automatic_os_upgrade_policy", flattenedAutomatic); err != nil {
 			return fmt.Errorf("setting `automatic_os_upgrade_policy`: %+v", err)
 		}
+
+		// when using the Manual upgrade mode the Rolling Upgrade Policy is only used by the provider, so isn't returned
+		rollingUpgradePolicy := d.Get("rolling_upgrade_policy").([]interface{})
+		if policy.Mode != compute.UpgradeModeManual {
+			rollingUpgradePolicy = FlattenVirtualMachineScaleSetRollingUpgradePolicy(policy.RollingUpgradePolicy)
+		}
+		if err := d.Set("rolling_upgrade_policy", rollingUpgradePolicy); err != nil {
+			return fmt.Errorf("setting `rolling_upgrade_policy`: %+v", err)
+		}
 	}
 
 	return tags.FlattenAndSet(d, resp.Tags)
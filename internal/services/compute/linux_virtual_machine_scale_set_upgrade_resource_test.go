@@ -0,0 +1,84 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+func TestAccLinuxVirtualMachineScaleSet_upgradeManualRollingUpgradePolicy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine_scale_set", "test")
+	r := LinuxVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.upgradeManualRollingUpgradePolicy(data, "Standard_F2", "PT0S"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("upgrade_mode").HasValue("Manual"),
+				check.That(data.ResourceName).Key("rolling_upgrade_policy.0.max_batch_instance_percent").HasValue("50"),
+			),
+		},
+		data.ImportStep("admin_password", "rolling_upgrade_policy"),
+		{
+			// changing the SKU rolls the instances in batches
+			Config: r.upgradeManualRollingUpgradePolicy(data, "Standard_F4", "PT1M"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sku").HasValue("Standard_F4"),
+				check.That(data.ResourceName).Key("rolling_upgrade_policy.0.pause_time_between_batches").HasValue("PT1M"),
+			),
+		},
+		data.ImportStep("admin_password", "rolling_upgrade_policy"),
+	})
+}
+
+func (r LinuxVirtualMachineScaleSetResource) upgradeManualRollingUpgradePolicy(data acceptance.TestData, sku, pauseTime string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_linux_virtual_machine_scale_set" "test" {
+  name                            = "acctestvmss-%d"
+  resource_group_name             = azurestack_resource_group.test.name
+  location                        = azurestack_resource_group.test.location
+  sku                             = "%s"
+  instances                       = 2
+  admin_username                  = "adminuser"
+  admin_password                  = "P@ssword1234!"
+  disable_password_authentication = false
+  upgrade_mode                    = "Manual"
+
+  rolling_upgrade_policy {
+    max_batch_instance_percent              = 50
+    max_unhealthy_instance_percent          = 50
+    max_unhealthy_upgraded_instance_percent = 50
+    pause_time_between_batches              = "%s"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  os_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+  }
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = azurestack_subnet.test.id
+    }
+  }
+}
+`, r.template(data), data.RandomInteger, sku, pauseTime)
+}
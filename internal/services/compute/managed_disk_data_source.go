@@ -34,6 +34,11 @@ func managedDiskDataSource() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"disk_encryption_set_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"disk_size_gb": {
 				Type:     pluginsdk.TypeInt,
 				Computed: true,
@@ -114,6 +119,12 @@ func managedDiskDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) erro
 			d.Set("storage_account_id", creationData.StorageAccountID)
 		}
 
+		diskEncryptionSetId := ""
+		if props.Encryption != nil && props.Encryption.DiskEncryptionSetID != nil {
+			diskEncryptionSetId = *props.Encryption.DiskEncryptionSetID
+		}
+		d.Set("disk_encryption_set_id", diskEncryptionSetId)
+
 		d.Set("disk_size_gb", props.DiskSizeGB)
 		d.Set("os_type", props.OsType)
 	}
@@ -66,6 +66,14 @@ func managedDisk() *pluginsdk.Resource {
 
 			"encryption": encryptionSettingsSchema(),
 
+			"disk_encryption_set_id": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				// the Compute/Disks API is broken and returns the Resource Group name in UPPERCASE
+				DiffSuppressFunc: suppress.CaseDifference,
+				ValidateFunc:     validate.DiskEncryptionSetID,
+			},
+
 			"disk_size_gb": {
 				Type:         pluginsdk.TypeInt,
 				Optional:     true,
@@ -103,9 +111,10 @@ func managedDisk() *pluginsdk.Resource {
 			},
 
 			"source_resource_id": {
-				Type:     pluginsdk.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ManagedDiskOrSnapshotID,
 			},
 
 			"storage_account_id": {
@@ -197,7 +206,7 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 	if createOption == compute.Copy {
 		sourceResourceId := d.Get("source_resource_id").(string)
 		if sourceResourceId == "" {
-			return fmt.Errorf("`source_resource_id` must be specified when `create_option` is set to `Copy` or `Restore`")
+			return fmt.Errorf("`source_resource_id` must be specified when `create_option` is set to `Copy`")
 		}
 
 		props.CreationData.SourceResourceID = pointer.FromString(sourceResourceId)
@@ -218,6 +227,13 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		props.EncryptionSettingsCollection = expandManagedDiskEncryptionSettings(settings)
 	}
 
+	if diskEncryptionSetId := d.Get("disk_encryption_set_id").(string); diskEncryptionSetId != "" {
+		props.Encryption = &compute.Encryption{
+			Type:                compute.EncryptionAtRestWithCustomerKey,
+			DiskEncryptionSetID: utils.String(diskEncryptionSetId),
+		}
+	}
+
 	if v, ok := d.GetOk("hyper_v_generation"); ok {
 		props.HyperVGeneration = compute.HyperVGeneration(v.(string))
 	}
@@ -264,7 +280,6 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 	name := d.Get("name").(string)
 	resourceGroup := d.Get("resource_group_name").(string)
 	storageAccountType := d.Get("storage_account_type").(string)
-	shouldShutDown := false
 
 	disk, err := client.Get(ctx, resourceGroup, name)
 	if err != nil {
@@ -279,13 +294,13 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		DiskUpdateProperties: &compute.DiskUpdateProperties{},
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		t := d.Get("tags").(map[string]interface{})
 		diskUpdate.Tags = tags.Expand(t)
 	}
 
-	if d.HasChange("storage_account_type") {
-		shouldShutDown = true
+	storageAccountTypeChanged := d.HasChange("storage_account_type")
+	if storageAccountTypeChanged {
 		var skuName compute.DiskStorageAccountTypes
 		for _, v := range compute.PossibleDiskStorageAccountTypesValues() {
 			if strings.EqualFold(storageAccountType, string(v)) {
@@ -301,22 +316,34 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		diskUpdate.DiskUpdateProperties.OsType = compute.OperatingSystemTypes(d.Get("os_type").(string))
 	}
 
+	if d.HasChange("disk_encryption_set_id") {
+		diskEncryptionSetId := d.Get("disk_encryption_set_id").(string)
+		if diskEncryptionSetId == "" {
+			return fmt.Errorf("Once a customer-managed key is used, you can’t change the selection back to a platform-managed key")
+		}
+
+		diskUpdate.DiskUpdateProperties.Encryption = &compute.Encryption{
+			Type:                compute.EncryptionAtRestWithCustomerKey,
+			DiskEncryptionSetID: utils.String(diskEncryptionSetId),
+		}
+	}
+
+	diskSizeChanged := false
 	if d.HasChange("disk_size_gb") {
 		if old, new := d.GetChange("disk_size_gb"); new.(int) > old.(int) {
-			shouldShutDown = true
+			diskSizeChanged = true
 			diskUpdate.DiskUpdateProperties.DiskSizeGB = utils.Int32(int32(new.(int)))
 		} else {
 			return fmt.Errorf("- New size must be greater than original size. Shrinking disks is not supported on Azure")
 		}
 	}
 
-	// whilst we need to shut this down, if we're not attached to anything there's no point
-	if shouldShutDown && disk.ManagedBy == nil {
-		shouldShutDown = false
-	}
+	expandWithoutDowntime := meta.(*clients.Client).Features.ManagedDisk.ExpandWithoutDowntime
+	attached := disk.ManagedBy != nil && *disk.ManagedBy != ""
+	requiresShutdown := managedDiskUpdateRequiresShutdown(attached, storageAccountTypeChanged, diskSizeChanged, expandWithoutDowntime)
 
 	// if we are attached to a VM we bring down the VM as necessary for the operations which are not allowed while it's online
-	if shouldShutDown {
+	if requiresShutdown {
 		virtualMachine, err := parse.VirtualMachineID(*disk.ManagedBy)
 		if err != nil {
 			return fmt.Errorf("parsing VMID %q for disk attachment: %+v", *disk.ManagedBy, err)
@@ -324,43 +351,15 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		// check instanceView State
 		vmClient := meta.(*clients.Client).Compute.VMClient
 
-		locks.ByName(name, virtualMachineResourceName)
-		defer locks.UnlockByName(name, virtualMachineResourceName)
+		locks.ByName(virtualMachine.Name, virtualMachineResourceName)
+		defer locks.UnlockByName(virtualMachine.Name, virtualMachineResourceName)
 
 		instanceView, err := vmClient.InstanceView(ctx, virtualMachine.ResourceGroup, virtualMachine.Name)
 		if err != nil {
 			return fmt.Errorf("retrieving InstanceView for Virtual Machine %q (Resource Group %q): %+v", virtualMachine.Name, virtualMachine.ResourceGroup, err)
 		}
 
-		shouldTurnBackOn := true
-		shouldDeallocate := true
-
-		if instanceView.Statuses != nil {
-			for _, status := range *instanceView.Statuses {
-				if status.Code == nil {
-					continue
-				}
-
-				// could also be the provisioning state which we're not bothered with here
-				state := strings.ToLower(*status.Code)
-				if !strings.HasPrefix(state, "powerstate/") {
-					continue
-				}
-
-				state = strings.TrimPrefix(state, "powerstate/")
-				switch strings.ToLower(state) {
-				case "deallocated":
-				case "deallocating":
-					shouldTurnBackOn = false
-					shouldShutDown = false
-					shouldDeallocate = false
-				case "stopping":
-				case "stopped":
-					shouldShutDown = false
-					shouldTurnBackOn = false
-				}
-			}
-		}
+		shouldShutDown, shouldDeallocate, shouldTurnBackOn := managedDiskVirtualMachinePowerActions(instanceView.Statuses)
 
 		// Shutdown
 		if shouldShutDown {
@@ -404,7 +403,7 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		}
 
 		if shouldTurnBackOn {
-			log.Printf("[DEBUG] Starting Linux Virtual Machine %q (Resource Group %q)..", virtualMachine.Name, virtualMachine.ResourceGroup)
+			log.Printf("[DEBUG] Starting Virtual Machine %q (Resource Group %q)..", virtualMachine.Name, virtualMachine.ResourceGroup)
 			future, err := vmClient.Start(ctx, virtualMachine.ResourceGroup, virtualMachine.Name)
 			if err != nil {
 				return fmt.Errorf("starting Virtual Machine %q (Resource Group %q): %+v", virtualMachine.Name, virtualMachine.ResourceGroup, err)
@@ -431,6 +430,59 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 	return resourceManagedDiskRead(d, meta)
 }
 
+// managedDiskUpdateRequiresShutdown returns whether the Virtual Machine a Managed Disk is attached to needs to be
+// shut down to apply the update - expanding a disk can be done online when the `expand_without_downtime` feature is
+// enabled, however changing the storage account type always requires a shut down.
+func managedDiskUpdateRequiresShutdown(attached, storageAccountTypeChanged, diskSizeChanged, expandWithoutDowntime bool) bool {
+	// whilst we may need to shut this down, if we're not attached to anything there's no point
+	if !attached {
+		return false
+	}
+
+	if storageAccountTypeChanged {
+		return true
+	}
+
+	return diskSizeChanged && !expandWithoutDowntime
+}
+
+// managedDiskVirtualMachinePowerActions returns which power actions need to be performed against the Virtual Machine
+// a Managed Disk is attached to (based on its current Power State) so that the Virtual Machine is deallocated whilst
+// the Managed Disk is updated, and is only started again afterwards when it was running beforehand.
+func managedDiskVirtualMachinePowerActions(statuses *[]compute.InstanceViewStatus) (shutDown, deallocate, turnBackOn bool) {
+	shutDown = true
+	deallocate = true
+	turnBackOn = true
+
+	if statuses == nil {
+		return
+	}
+
+	for _, status := range *statuses {
+		if status.Code == nil {
+			continue
+		}
+
+		// could also be the provisioning state which we're not bothered with here
+		state := strings.ToLower(*status.Code)
+		if !strings.HasPrefix(state, "powerstate/") {
+			continue
+		}
+
+		switch strings.TrimPrefix(state, "powerstate/") {
+		case "deallocated", "deallocating":
+			shutDown = false
+			deallocate = false
+			turnBackOn = false
+		case "stopped", "stopping":
+			shutDown = false
+			turnBackOn = false
+		}
+	}
+
+	return
+}
+
 func resourceManagedDiskRead(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Compute.DisksClient
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
@@ -480,6 +532,12 @@ func resourceManagedDiskRead(d *pluginsdk.ResourceData, meta interface{}) error
 		if err := d.Set("encryption", flattenManagedDiskEncryptionSettings(props.EncryptionSettingsCollection)); err != nil {
 			return fmt.Errorf("setting `encryption`: %+v", err)
 		}
+
+		diskEncryptionSetId := ""
+		if props.Encryption != nil && props.Encryption.DiskEncryptionSetID != nil {
+			diskEncryptionSetId = *props.Encryption.DiskEncryptionSetID
+		}
+		d.Set("disk_encryption_set_id", diskEncryptionSetId)
 	}
 
 	return tags.FlattenAndSet(d, resp.Tags)
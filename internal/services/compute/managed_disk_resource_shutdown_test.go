@@ -0,0 +1,153 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func TestManagedDiskUpdateRequiresShutdown(t *testing.T) {
+	testData := []struct {
+		Name                      string
+		Attached                  bool
+		StorageAccountTypeChanged bool
+		DiskSizeChanged           bool
+		ExpandWithoutDowntime     bool
+		Expected                  bool
+	}{
+		{
+			Name:            "Detached Disk",
+			Attached:        false,
+			DiskSizeChanged: true,
+			Expected:        false,
+		},
+		{
+			Name:                      "Detached Disk Storage Account Type Changed",
+			Attached:                  false,
+			StorageAccountTypeChanged: true,
+			Expected:                  false,
+		},
+		{
+			Name:     "Attached Disk No Changes",
+			Attached: true,
+			Expected: false,
+		},
+		{
+			Name:            "Attached Disk Expanded",
+			Attached:        true,
+			DiskSizeChanged: true,
+			Expected:        true,
+		},
+		{
+			Name:                  "Attached Disk Expanded Without Downtime",
+			Attached:              true,
+			DiskSizeChanged:       true,
+			ExpandWithoutDowntime: true,
+			Expected:              false,
+		},
+		{
+			Name:                      "Attached Disk Storage Account Type Changed",
+			Attached:                  true,
+			StorageAccountTypeChanged: true,
+			ExpandWithoutDowntime:     true,
+			Expected:                  true,
+		},
+		{
+			Name:                      "Attached Disk Expanded and Storage Account Type Changed",
+			Attached:                  true,
+			StorageAccountTypeChanged: true,
+			DiskSizeChanged:           true,
+			ExpandWithoutDowntime:     true,
+			Expected:                  true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		actual := managedDiskUpdateRequiresShutdown(v.Attached, v.StorageAccountTypeChanged, v.DiskSizeChanged, v.ExpandWithoutDowntime)
+		if actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+func TestManagedDiskVirtualMachinePowerActions(t *testing.T) {
+	testData := []struct {
+		Name               string
+		PowerState         string
+		ExpectedShutDown   bool
+		ExpectedDeallocate bool
+		ExpectedTurnBackOn bool
+	}{
+		{
+			Name:               "No Power State",
+			PowerState:         "",
+			ExpectedShutDown:   true,
+			ExpectedDeallocate: true,
+			ExpectedTurnBackOn: true,
+		},
+		{
+			Name:               "Running",
+			PowerState:         "PowerState/running",
+			ExpectedShutDown:   true,
+			ExpectedDeallocate: true,
+			ExpectedTurnBackOn: true,
+		},
+		{
+			Name:               "Stopped",
+			PowerState:         "PowerState/stopped",
+			ExpectedShutDown:   false,
+			ExpectedDeallocate: true,
+			ExpectedTurnBackOn: false,
+		},
+		{
+			Name:               "Stopping",
+			PowerState:         "PowerState/stopping",
+			ExpectedShutDown:   false,
+			ExpectedDeallocate: true,
+			ExpectedTurnBackOn: false,
+		},
+		{
+			Name:               "Deallocated",
+			PowerState:         "PowerState/deallocated",
+			ExpectedShutDown:   false,
+			ExpectedDeallocate: false,
+			ExpectedTurnBackOn: false,
+		},
+		{
+			Name:               "Deallocating",
+			PowerState:         "PowerState/Deallocating",
+			ExpectedShutDown:   false,
+			ExpectedDeallocate: false,
+			ExpectedTurnBackOn: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		statuses := []compute.InstanceViewStatus{
+			{
+				Code: utils.String("ProvisioningState/succeeded"),
+			},
+		}
+		if v.PowerState != "" {
+			statuses = append(statuses, compute.InstanceViewStatus{
+				Code: utils.String(v.PowerState),
+			})
+		}
+
+		shutDown, deallocate, turnBackOn := managedDiskVirtualMachinePowerActions(&statuses)
+		if shutDown != v.ExpectedShutDown {
+			t.Fatalf("expected shutDown to be %t but got %t", v.ExpectedShutDown, shutDown)
+		}
+		if deallocate != v.ExpectedDeallocate {
+			t.Fatalf("expected deallocate to be %t but got %t", v.ExpectedDeallocate, deallocate)
+		}
+		if turnBackOn != v.ExpectedTurnBackOn {
+			t.Fatalf("expected turnBackOn to be %t but got %t", v.ExpectedTurnBackOn, turnBackOn)
+		}
+	}
+}
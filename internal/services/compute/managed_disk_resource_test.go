@@ -105,6 +105,23 @@ func TestAccManagedDisk_copy(t *testing.T) {
 	})
 }
 
+func TestAccManagedDisk_copyFromSnapshot(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_managed_disk", "test")
+	r := ManagedDiskResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.copyFromSnapshot(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("create_option").HasValue("Copy"),
+				check.That(data.ResourceName).Key("disk_size_gb").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccManagedDisk_fromPlatformImage(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurestack_managed_disk", "test")
 	r := ManagedDiskResource{}
@@ -230,6 +247,22 @@ func TestAccManagedDisk_encryption(t *testing.T) {
 	})
 }
 
+func TestAccManagedDisk_diskEncryptionSet(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_managed_disk", "test")
+	r := ManagedDiskResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.diskEncryptionSet(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("disk_encryption_set_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (ManagedDiskResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.ManagedDiskID(state.ID)
 	if err != nil {
@@ -397,6 +430,46 @@ resource "azurestack_managed_disk" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
 }
 
+func (ManagedDiskResource) copyFromSnapshot(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_managed_disk" "source" {
+  name                 = "acctestd1-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "1"
+}
+
+resource "azurestack_snapshot" "test" {
+  name                = "acctestss-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  create_option       = "Copy"
+  source_resource_id  = azurestack_managed_disk.source.id
+}
+
+resource "azurestack_managed_disk" "test" {
+  name                 = "acctestd2-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Copy"
+  source_resource_id   = azurestack_snapshot.test.id
+  disk_size_gb         = "2"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
 func (ManagedDiskResource) empty_updated(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurestack" {
@@ -715,3 +788,26 @@ resource "azurestack_managed_disk" "test" {
 }
 `, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomString, data.RandomString, data.RandomInteger)
 }
+
+func (ManagedDiskResource) diskEncryptionSet(data acceptance.TestData) string {
+	des := DiskEncryptionSetResource{}
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "azurestack_managed_disk" "test" {
+  name                   = "acctestd-%d"
+  location               = azurestack_resource_group.test.location
+  resource_group_name    = azurestack_resource_group.test.name
+  storage_account_type   = "Standard_LRS"
+  create_option          = "Empty"
+  disk_size_gb           = 1
+  disk_encryption_set_id = azurestack_disk_encryption_set.test.id
+
+  depends_on = [
+    azurestack_key_vault_access_policy.disk-encryption,
+  ]
+}
+`, des.basic(data), des.grantAccess(), data.RandomInteger)
+}
@@ -0,0 +1,93 @@
+package compute
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func managedDiskSasDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: managedDiskSasDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"managed_disk_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.ManagedDiskID,
+			},
+
+			"duration_in_seconds": {
+				Type:         pluginsdk.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(30),
+			},
+
+			"access_level": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Default:  string(compute.Read),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(compute.Read),
+					string(compute.Write),
+				}, false),
+			},
+
+			"sas_url": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func managedDiskSasDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.DisksClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedDiskID(d.Get("managed_disk_id").(string))
+	if err != nil {
+		return err
+	}
+
+	disk, err := client.Get(ctx, id.ResourceGroup, id.DiskName)
+	if err != nil {
+		if utils.ResponseWasNotFound(disk.Response) {
+			return fmt.Errorf("%s was not found", *id)
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	// access can only be granted to a disk which isn't attached to a running Virtual Machine
+	if disk.ManagedBy != nil && *disk.ManagedBy != "" && disk.DiskProperties != nil && disk.DiskProperties.DiskState == compute.Attached {
+		return fmt.Errorf("granting access to %s: the Managed Disk is attached to %q - the Virtual Machine must be deallocated or the Managed Disk detached first", *id, *disk.ManagedBy)
+	}
+
+	accessLevel := compute.AccessLevel(d.Get("access_level").(string))
+	sasUrl, err := grantManagedDiskAccess(ctx, client, *id, accessLevel, d.Get("duration_in_seconds").(int))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id.ID())
+
+	d.Set("managed_disk_id", id.ID())
+	d.Set("sas_url", sasUrl)
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type ManagedDiskSasDataSource struct{}
+
+func TestAccManagedDiskSasDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_managed_disk_sas", "test")
+	r := ManagedDiskSasDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("managed_disk_id").Exists(),
+				check.That(data.ResourceName).Key("access_level").HasValue("Read"),
+				check.That(data.ResourceName).Key("sas_url").Exists(),
+			),
+		},
+	})
+}
+
+func (ManagedDiskSasDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_managed_disk" "test" {
+  name                 = "acctestd-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "1"
+}
+
+data "azurestack_managed_disk_sas" "test" {
+  managed_disk_id     = azurestack_managed_disk.test.id
+  duration_in_seconds = 300
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
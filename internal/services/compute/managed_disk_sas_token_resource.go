@@ -0,0 +1,188 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func managedDiskSasToken() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: managedDiskSasTokenCreate,
+		Read:   managedDiskSasTokenRead,
+		Delete: managedDiskSasTokenDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"managed_disk_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ManagedDiskID,
+			},
+
+			"duration_in_seconds": {
+				Type:         pluginsdk.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(30),
+			},
+
+			"access_level": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(compute.Read),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(compute.Read),
+					string(compute.Write),
+				}, false),
+			},
+
+			"sas_url": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func managedDiskSasTokenCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.DisksClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedDiskID(d.Get("managed_disk_id").(string))
+	if err != nil {
+		return err
+	}
+
+	disk, err := client.Get(ctx, id.ResourceGroup, id.DiskName)
+	if err != nil {
+		if utils.ResponseWasNotFound(disk.Response) {
+			return fmt.Errorf("%s was not found", *id)
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	// access can only be granted to a disk which isn't attached to a running Virtual Machine
+	if disk.ManagedBy != nil && *disk.ManagedBy != "" && disk.DiskProperties != nil && disk.DiskProperties.DiskState == compute.Attached {
+		return fmt.Errorf("granting access to %s: the Managed Disk is attached to %q - the Virtual Machine must be deallocated or the Managed Disk detached first", *id, *disk.ManagedBy)
+	}
+
+	accessLevel := compute.AccessLevel(d.Get("access_level").(string))
+	sasUrl, err := grantManagedDiskAccess(ctx, client, *id, accessLevel, d.Get("duration_in_seconds").(int))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id.ID())
+
+	// the SAS URL is only returned when access is granted, so must be set here
+	d.Set("sas_url", sasUrl)
+
+	return managedDiskSasTokenRead(d, meta)
+}
+
+func managedDiskSasTokenRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.DisksClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedDiskID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	disk, err := client.Get(ctx, id.ResourceGroup, id.DiskName)
+	if err != nil {
+		if utils.ResponseWasNotFound(disk.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	// once the SAS has expired (or has been revoked outside of Terraform) access needs to be granted again
+	if disk.DiskProperties == nil || disk.DiskProperties.DiskState != compute.ActiveSAS {
+		log.Printf("[DEBUG] %s no longer has an active SAS - removing from state", *id)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("managed_disk_id", id.ID())
+
+	return nil
+}
+
+func managedDiskSasTokenDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.DisksClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedDiskID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Revoking Access to %s..", *id)
+	future, err := client.RevokeAccess(ctx, id.ResourceGroup, id.DiskName)
+	if err != nil {
+		if utils.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("revoking access to %s: %+v", *id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for access to be revoked from %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func grantManagedDiskAccess(ctx context.Context, client *compute.DisksClient, id parse.ManagedDiskId, accessLevel compute.AccessLevel, durationInSeconds int) (string, error) {
+	log.Printf("[DEBUG] Granting %s Access to %s for %d seconds..", accessLevel, id, durationInSeconds)
+	input := compute.GrantAccessData{
+		Access:            accessLevel,
+		DurationInSeconds: utils.Int32(int32(durationInSeconds)),
+	}
+	future, err := client.GrantAccess(ctx, id.ResourceGroup, id.DiskName, input)
+	if err != nil {
+		return "", fmt.Errorf("granting access to %s: %+v", id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return "", fmt.Errorf("waiting for access to be granted to %s: %+v", id, err)
+	}
+
+	result, err := future.Result(*client)
+	if err != nil {
+		return "", fmt.Errorf("retrieving the SAS URL for %s: %+v", id, err)
+	}
+	if result.AccessSAS == nil {
+		return "", fmt.Errorf("retrieving the SAS URL for %s: `accessSAS` was nil", id)
+	}
+
+	return *result.AccessSAS, nil
+}
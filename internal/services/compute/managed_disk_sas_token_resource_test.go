@@ -0,0 +1,118 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+type ManagedDiskSasTokenResource struct{}
+
+func TestAccManagedDiskSasToken_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_managed_disk_sas_token", "test")
+	r := ManagedDiskSasTokenResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, 300),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("access_level").HasValue("Read"),
+				check.That(data.ResourceName).Key("sas_url").IsSet(),
+			),
+		},
+		{
+			// refreshing shouldn't grant access again
+			Config:   r.basic(data, 300),
+			PlanOnly: true,
+		},
+		{
+			Config: r.basic(data, 600),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("duration_in_seconds").HasValue("600"),
+				check.That(data.ResourceName).Key("sas_url").IsSet(),
+			),
+		},
+		{
+			// removing the token should revoke access to the Managed Disk
+			Config: r.template(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				data.CheckWithClientForResource(r.accessRevoked, "azurestack_managed_disk.test"),
+			),
+		},
+	})
+}
+
+func (ManagedDiskSasTokenResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ManagedDiskID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.DisksClient.Get(ctx, id.ResourceGroup, id.DiskName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.DiskProperties != nil && resp.DiskProperties.DiskState == compute.ActiveSAS), nil
+}
+
+func (ManagedDiskSasTokenResource) accessRevoked(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+	id, err := parse.ManagedDiskID(state.ID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := clients.Compute.DisksClient.Get(ctx, id.ResourceGroup, id.DiskName)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	if resp.DiskProperties != nil && resp.DiskProperties.DiskState == compute.ActiveSAS {
+		return fmt.Errorf("%s still has an active SAS", *id)
+	}
+
+	return nil
+}
+
+func (ManagedDiskSasTokenResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_managed_disk" "test" {
+  name                 = "acctestd-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "1"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
+
+func (r ManagedDiskSasTokenResource) basic(data acceptance.TestData, durationInSeconds int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_managed_disk_sas_token" "test" {
+  managed_disk_id     = azurestack_managed_disk.test.id
+  duration_in_seconds = %d
+}
+`, r.template(data), durationInSeconds)
+}
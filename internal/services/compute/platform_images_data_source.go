@@ -0,0 +1,210 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func platformImagesDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: platformImagesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"location": commonschema.Location(),
+
+			"publisher_regex": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"offer_regex": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"sku_regex": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"version_regex": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"latest_count": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"images": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"publisher": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"offer": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"sku": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"version": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func platformImagesDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMImageClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	loc := location.Normalize(d.Get("location").(string))
+
+	// the regular expressions have been validated by the schema, so these can't fail
+	publisherRegex := regexp.MustCompile(d.Get("publisher_regex").(string))
+	offerRegex := regexp.MustCompile(d.Get("offer_regex").(string))
+	skuRegex := regexp.MustCompile(d.Get("sku_regex").(string))
+	versionRegex := regexp.MustCompile(d.Get("version_regex").(string))
+	latestCount := d.Get("latest_count").(int)
+
+	publishers, err := client.ListPublishers(ctx, loc)
+	if err != nil {
+		return fmt.Errorf("listing Platform Image Publishers (Location %q): %+v", loc, err)
+	}
+
+	images := make([]interface{}, 0)
+	for _, publisher := range filterPlatformImageNames(publishers.Value, publisherRegex) {
+		offers, err := client.ListOffers(ctx, loc, publisher)
+		if err != nil {
+			return fmt.Errorf("listing Platform Image Offers (Location %q / Publisher %q): %+v", loc, publisher, err)
+		}
+
+		for _, offer := range filterPlatformImageNames(offers.Value, offerRegex) {
+			skus, err := client.ListSkus(ctx, loc, publisher, offer)
+			if err != nil {
+				return fmt.Errorf("listing Platform Image SKUs (Location %q / Publisher %q / Offer %q): %+v", loc, publisher, offer, err)
+			}
+
+			for _, sku := range filterPlatformImageNames(skus.Value, skuRegex) {
+				versions, err := listPlatformImageVersions(ctx, client, loc, publisher, offer, sku, versionRegex, latestCount)
+				if err != nil {
+					return err
+				}
+
+				images = append(images, versions...)
+			}
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	d.Set("location", loc)
+
+	if err := d.Set("images", images); err != nil {
+		return fmt.Errorf("setting `images`: %+v", err)
+	}
+
+	return nil
+}
+
+// listPlatformImageVersions returns the versions of the specified SKU which match `versionRegex`, sorted from the
+// newest to the oldest version - limited to the `latestCount` newest versions when this is greater than zero
+func listPlatformImageVersions(ctx context.Context, client *compute.VirtualMachineImagesClient, loc, publisher, offer, sku string, versionRegex *regexp.Regexp, latestCount int) ([]interface{}, error) {
+	resp, err := client.List(ctx, loc, publisher, offer, sku, "", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing Platform Image Versions (Location %q / Publisher %q / Offer %q / SKU %q): %+v", loc, publisher, offer, sku, err)
+	}
+
+	ids := make(map[string]string)
+	if resp.Value != nil {
+		for _, item := range *resp.Value {
+			if item.Name == nil || !versionRegex.MatchString(*item.Name) {
+				continue
+			}
+
+			id := ""
+			if item.ID != nil {
+				id = *item.ID
+			}
+			ids[*item.Name] = id
+		}
+	}
+
+	versions := make([]string, 0, len(ids))
+	for v := range ids {
+		versions = append(versions, v)
+	}
+	versions = sortVersionsDescending(versions)
+
+	if latestCount > 0 && len(versions) > latestCount {
+		versions = versions[:latestCount]
+	}
+
+	output := make([]interface{}, 0, len(versions))
+	for _, v := range versions {
+		output = append(output, map[string]interface{}{
+			"id":        ids[v],
+			"publisher": publisher,
+			"offer":     offer,
+			"sku":       sku,
+			"version":   v,
+		})
+	}
+
+	return output, nil
+}
+
+func filterPlatformImageNames(input *[]compute.VirtualMachineImageResource, regex *regexp.Regexp) []string {
+	output := make([]string, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, item := range *input {
+		if item.Name == nil || !regex.MatchString(*item.Name) {
+			continue
+		}
+		output = append(output, *item.Name)
+	}
+
+	return output
+}
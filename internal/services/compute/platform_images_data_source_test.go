@@ -0,0 +1,77 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type PlatformImagesDataSource struct{}
+
+func TestAccPlatformImagesDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_platform_images", "test")
+	r := PlatformImagesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("images.#").Exists(),
+				check.That(data.ResourceName).Key("images.0.publisher").HasValue("Canonical"),
+				check.That(data.ResourceName).Key("images.0.offer").HasValue("UbuntuServer"),
+				check.That(data.ResourceName).Key("images.0.sku").HasValue("16.04-LTS"),
+				check.That(data.ResourceName).Key("images.0.version").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccPlatformImagesDataSource_latest(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_platform_images", "test")
+	r := PlatformImagesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.latest(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("images.#").HasValue("1"),
+				check.That(data.ResourceName).Key("images.0.id").Exists(),
+				check.That(data.ResourceName).Key("images.0.version").Exists(),
+			),
+		},
+	})
+}
+
+func (PlatformImagesDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+data "azurestack_platform_images" "test" {
+  location        = "%s"
+  publisher_regex = "^Canonical$"
+  offer_regex     = "^UbuntuServer$"
+  sku_regex       = "^16\\.04-LTS$"
+}
+`, data.Locations.Primary)
+}
+
+func (PlatformImagesDataSource) latest(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+data "azurestack_platform_images" "test" {
+  location        = "%s"
+  publisher_regex = "^Canonical$"
+  offer_regex     = "^UbuntuServer$"
+  sku_regex       = "^16\\.04-LTS$"
+  version_regex   = "^16\\.04\\."
+  latest_count    = 1
+}
+`, data.Locations.Primary)
+}
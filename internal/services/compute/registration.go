@@ -21,28 +21,44 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurestack_availability_set": availabilitySetDataSource(),
-		"azurestack_managed_disk":     managedDiskDataSource(),
-		"azurestack_platform_image":   platformImageDataSource(),
-		"azurestack_image":            imageDataSource(),
+		"azurestack_availability_set":                    availabilitySetDataSource(),
+		"azurestack_compute_usage":                       computeUsageDataSource(),
+		"azurestack_managed_disk":                        managedDiskDataSource(),
+		"azurestack_managed_disk_sas":                    managedDiskSasDataSource(),
+		"azurestack_platform_image":                      platformImageDataSource(),
+		"azurestack_platform_images":                     platformImagesDataSource(),
+		"azurestack_image":                               imageDataSource(),
+		"azurestack_snapshot":                            snapshotDataSource(),
+		"azurestack_ssh_public_key":                      sshPublicKeyDataSource(),
+		"azurestack_virtual_machine_extension_image":     virtualMachineExtensionImageDataSource(),
+		"azurestack_virtual_machine_scale_set_instances": virtualMachineScaleSetInstancesDataSource(),
+		"azurestack_virtual_machine_sizes":               virtualMachineSizesDataSource(),
 	}
 }
 
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	resources := map[string]*pluginsdk.Resource{
-		"azurestack_availability_set":                     availabilitySet(),
-		"azurestack_linux_virtual_machine":                linuxVirtualMachine(),
-		"azurestack_linux_virtual_machine_scale_set":      resourceLinuxVirtualMachineScaleSet(),
-		"azurestack_managed_disk":                         managedDisk(),
-		"azurestack_virtual_machine":                      virtualMachine(),
-		"azurestack_virtual_machine_data_disk_attachment": virtualMachineDataDiskAttachment(),
-		"azurestack_virtual_machine_extension":            virtualMachineExtension(),
-		"azurestack_virtual_machine_scale_set":            virtualMachineScaleSet(),
-		"azurestack_virtual_machine_scale_set_extension":  virtualMachineScaleSetExtension(),
-		"azurestack_image":                                image(),
-		"azurestack_windows_virtual_machine":              windowsVirtualMachine(),
-		"azurestack_windows_virtual_machine_scale_set":    resourceWindowsVirtualMachineScaleSet(),
+		"azurestack_availability_set":                              availabilitySet(),
+		"azurestack_dedicated_host":                                dedicatedHost(),
+		"azurestack_dedicated_host_group":                          dedicatedHostGroup(),
+		"azurestack_disk_encryption_set":                           diskEncryptionSet(),
+		"azurestack_linux_virtual_machine":                         linuxVirtualMachine(),
+		"azurestack_linux_virtual_machine_scale_set":               resourceLinuxVirtualMachineScaleSet(),
+		"azurestack_managed_disk":                                  managedDisk(),
+		"azurestack_managed_disk_sas_token":                        managedDiskSasToken(),
+		"azurestack_virtual_machine":                               virtualMachine(),
+		"azurestack_virtual_machine_data_disk_attachment":          virtualMachineDataDiskAttachment(),
+		"azurestack_virtual_machine_extension":                     virtualMachineExtension(),
+		"azurestack_virtual_machine_scale_set":                     virtualMachineScaleSet(),
+		"azurestack_virtual_machine_scale_set_extension":           virtualMachineScaleSetExtension(),
+		"azurestack_virtual_machine_scale_set_instance_protection": virtualMachineScaleSetInstanceProtection(),
+		"azurestack_image":                                         image(),
+		"azurestack_proximity_placement_group":                     proximityPlacementGroup(),
+		"azurestack_snapshot":                                      snapshot(),
+		"azurestack_ssh_public_key":                                sshPublicKey(),
+		"azurestack_windows_virtual_machine":                       windowsVirtualMachine(),
+		"azurestack_windows_virtual_machine_scale_set":             resourceWindowsVirtualMachineScaleSet(),
 	}
 
 	return resources
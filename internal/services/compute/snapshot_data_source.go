@@ -0,0 +1,169 @@
+package compute
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func snapshotDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: snapshotDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+
+			"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+			"location": commonschema.LocationComputed(),
+
+			"create_option": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"source_uri": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"source_resource_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"storage_account_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"disk_size_gb": {
+				Type:     pluginsdk.TypeInt,
+				Computed: true,
+			},
+
+			"incremental_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"os_type": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"encryption": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"enabled": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+
+						"disk_encryption_key": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"secret_url": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"source_vault_id": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+
+						"key_encryption_key": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"key_url": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"source_vault_id": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"tags": tags.SchemaDataSource(),
+		},
+	}
+}
+
+func snapshotDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewSnapshotID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("%s was not found", id)
+		}
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	d.Set("name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("location", location.NormalizeNilable(resp.Location))
+
+	if props := resp.SnapshotProperties; props != nil {
+		if data := props.CreationData; data != nil {
+			d.Set("create_option", string(data.CreateOption))
+			d.Set("source_uri", data.SourceURI)
+			d.Set("source_resource_id", data.SourceResourceID)
+			d.Set("storage_account_id", data.StorageAccountID)
+		}
+
+		d.Set("disk_size_gb", props.DiskSizeGB)
+		d.Set("os_type", string(props.OsType))
+
+		incrementalEnabled := false
+		if props.Incremental != nil {
+			incrementalEnabled = *props.Incremental
+		}
+		d.Set("incremental_enabled", incrementalEnabled)
+
+		if err := d.Set("encryption", flattenManagedDiskEncryptionSettings(props.EncryptionSettingsCollection)); err != nil {
+			return fmt.Errorf("setting `encryption`: %+v", err)
+		}
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
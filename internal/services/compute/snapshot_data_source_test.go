@@ -0,0 +1,69 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type SnapshotDataSource struct{}
+
+func TestAccSnapshotDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_snapshot", "snapshot")
+	r := SnapshotDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("name").Exists(),
+				check.That(data.ResourceName).Key("resource_group_name").Exists(),
+				check.That(data.ResourceName).Key("create_option").HasValue("Copy"),
+				check.That(data.ResourceName).Key("source_resource_id").Exists(),
+				check.That(data.ResourceName).Key("incremental_enabled").HasValue("false"),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (SnapshotDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_managed_disk" "test" {
+  name                 = "acctestmd-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "10"
+}
+
+resource "azurestack_snapshot" "test" {
+  name                = "acctestss_%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  create_option       = "Copy"
+  source_resource_id  = azurestack_managed_disk.test.id
+
+  tags = {
+    environment = "acctest"
+  }
+}
+
+data "azurestack_snapshot" "snapshot" {
+  name                = azurestack_snapshot.test.name
+  resource_group_name = azurestack_resource_group.test.name
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
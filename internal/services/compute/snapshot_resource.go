@@ -0,0 +1,386 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceid"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func snapshot() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: snapshotCreate,
+		Read:   snapshotRead,
+		Update: snapshotUpdate,
+		Delete: snapshotDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.SnapshotID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SnapshotName,
+			},
+
+			"location": commonschema.Location(),
+
+			"resource_group_name": commonschema.ResourceGroupName(),
+
+			"create_option": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(compute.Copy),
+					string(compute.Import),
+				}, false),
+			},
+
+			"source_uri": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"source_resource_id": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"storage_account_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: resourceid.ValidateResourceID,
+			},
+
+			"disk_size_gb": {
+				Type:     pluginsdk.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"incremental_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"encryption": encryptionSettingsSchema(),
+
+			"access_duration_in_seconds": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"access_sas": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+}
+
+func snapshotCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewSnapshotID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+	existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return tf.ImportAsExistsError("azurestack_snapshot", id.ID())
+	}
+
+	createOption := compute.DiskCreateOption(d.Get("create_option").(string))
+	props := &compute.SnapshotProperties{
+		CreationData: &compute.CreationData{
+			CreateOption: createOption,
+		},
+		Incremental: utils.Bool(d.Get("incremental_enabled").(bool)),
+	}
+
+	switch createOption {
+	case compute.Copy:
+		sourceResourceId := d.Get("source_resource_id").(string)
+		if sourceResourceId == "" {
+			return fmt.Errorf("`source_resource_id` must be specified when `create_option` is set to `Copy`")
+		}
+		props.CreationData.SourceResourceID = utils.String(sourceResourceId)
+
+	case compute.Import:
+		sourceUri := d.Get("source_uri").(string)
+		if sourceUri == "" {
+			return fmt.Errorf("`source_uri` must be specified when `create_option` is set to `Import`")
+		}
+		storageAccountId := d.Get("storage_account_id").(string)
+		if storageAccountId == "" {
+			return fmt.Errorf("`storage_account_id` must be specified when `create_option` is set to `Import`")
+		}
+		props.CreationData.SourceURI = utils.String(sourceUri)
+		props.CreationData.StorageAccountID = utils.String(storageAccountId)
+	}
+
+	if v := d.Get("disk_size_gb").(int); v != 0 {
+		props.DiskSizeGB = utils.Int32(int32(v))
+	}
+
+	if v, ok := d.GetOk("encryption"); ok {
+		encryptionSettings := v.([]interface{})
+		settings := encryptionSettings[0].(map[string]interface{})
+		props.EncryptionSettingsCollection = expandManagedDiskEncryptionSettings(settings)
+	}
+
+	properties := compute.Snapshot{
+		Location:           utils.String(location.Normalize(d.Get("location").(string))),
+		SnapshotProperties: props,
+		Tags:               tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, properties)
+	if err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	if v := d.Get("access_duration_in_seconds").(int); v > 0 {
+		sas, err := grantSnapshotAccess(ctx, client, id, v)
+		if err != nil {
+			return err
+		}
+		d.Set("access_sas", sas)
+	}
+
+	return snapshotRead(d, meta)
+}
+
+func snapshotUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SnapshotID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChanges("disk_size_gb", "encryption", "tags", "tags_all") {
+		update := compute.SnapshotUpdate{
+			SnapshotUpdateProperties: &compute.SnapshotUpdateProperties{},
+		}
+
+		if d.HasChange("disk_size_gb") {
+			if old, new := d.GetChange("disk_size_gb"); new.(int) > old.(int) {
+				update.SnapshotUpdateProperties.DiskSizeGB = utils.Int32(int32(new.(int)))
+			} else {
+				return fmt.Errorf("- New size must be greater than original size. Shrinking disks is not supported on Azure")
+			}
+		}
+
+		if d.HasChange("encryption") {
+			encryptionSettings := d.Get("encryption").([]interface{})
+			if len(encryptionSettings) > 0 {
+				settings := encryptionSettings[0].(map[string]interface{})
+				update.SnapshotUpdateProperties.EncryptionSettingsCollection = expandManagedDiskEncryptionSettings(settings)
+			} else {
+				update.SnapshotUpdateProperties.EncryptionSettingsCollection = &compute.EncryptionSettingsCollection{
+					Enabled: utils.Bool(false),
+				}
+			}
+		}
+
+		if d.HasChanges("tags", "tags_all") {
+			update.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
+		}
+
+		future, err := client.Update(ctx, id.ResourceGroup, id.Name, update)
+		if err != nil {
+			return fmt.Errorf("updating %s: %+v", *id, err)
+		}
+
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("waiting for update of %s: %+v", *id, err)
+		}
+	}
+
+	if d.HasChange("access_duration_in_seconds") {
+		// an existing SAS has to be revoked before a new one can be granted
+		if old, _ := d.GetChange("access_duration_in_seconds"); old.(int) > 0 {
+			if err := revokeSnapshotAccess(ctx, client, *id); err != nil {
+				return err
+			}
+			d.Set("access_sas", "")
+		}
+
+		if v := d.Get("access_duration_in_seconds").(int); v > 0 {
+			sas, err := grantSnapshotAccess(ctx, client, *id, v)
+			if err != nil {
+				return err
+			}
+			d.Set("access_sas", sas)
+		}
+	}
+
+	return snapshotRead(d, meta)
+}
+
+func snapshotRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SnapshotID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("location", location.NormalizeNilable(resp.Location))
+
+	if props := resp.SnapshotProperties; props != nil {
+		if data := props.CreationData; data != nil {
+			d.Set("create_option", string(data.CreateOption))
+			d.Set("source_uri", data.SourceURI)
+			d.Set("source_resource_id", data.SourceResourceID)
+			d.Set("storage_account_id", data.StorageAccountID)
+		}
+
+		d.Set("disk_size_gb", props.DiskSizeGB)
+
+		incrementalEnabled := false
+		if props.Incremental != nil {
+			incrementalEnabled = *props.Incremental
+		}
+		d.Set("incremental_enabled", incrementalEnabled)
+
+		if err := d.Set("encryption", flattenManagedDiskEncryptionSettings(props.EncryptionSettingsCollection)); err != nil {
+			return fmt.Errorf("setting `encryption`: %+v", err)
+		}
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func snapshotDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SnapshotID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// a Snapshot can't be deleted whilst it's being exported
+	if d.Get("access_sas").(string) != "" {
+		if err := revokeSnapshotAccess(ctx, client, *id); err != nil {
+			return err
+		}
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func grantSnapshotAccess(ctx context.Context, client *compute.SnapshotsClient, id parse.SnapshotId, durationInSeconds int) (string, error) {
+	log.Printf("[DEBUG] Granting Read Access to %s for %d seconds..", id, durationInSeconds)
+	input := compute.GrantAccessData{
+		Access:            compute.Read,
+		DurationInSeconds: utils.Int32(int32(durationInSeconds)),
+	}
+	future, err := client.GrantAccess(ctx, id.ResourceGroup, id.Name, input)
+	if err != nil {
+		return "", fmt.Errorf("granting access to %s: %+v", id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return "", fmt.Errorf("waiting for access to be granted to %s: %+v", id, err)
+	}
+
+	result, err := future.Result(*client)
+	if err != nil {
+		return "", fmt.Errorf("retrieving the SAS for %s: %+v", id, err)
+	}
+	if result.AccessSAS == nil {
+		return "", fmt.Errorf("retrieving the SAS for %s: `accessSAS` was nil", id)
+	}
+
+	return *result.AccessSAS, nil
+}
+
+func revokeSnapshotAccess(ctx context.Context, client *compute.SnapshotsClient, id parse.SnapshotId) error {
+	log.Printf("[DEBUG] Revoking Access to %s..", id)
+	future, err := client.RevokeAccess(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("revoking access to %s: %+v", id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for access to be revoked from %s: %+v", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,389 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+type SnapshotResource struct{}
+
+func TestAccSnapshot_fromManagedDisk(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot", "test")
+	r := SnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fromManagedDisk(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSnapshot_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot", "test")
+	r := SnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fromManagedDisk(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.requiresImport(data),
+			ExpectError: acceptance.RequiresImportError("azurestack_snapshot"),
+		},
+	})
+}
+
+func TestAccSnapshot_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot", "test")
+	r := SnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fromManagedDisk(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.fromManagedDiskUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("disk_size_gb").HasValue("20"),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSnapshot_incremental(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot", "test")
+	r := SnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.incremental(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("incremental_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSnapshot_access(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot", "test")
+	r := SnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.access(data, 3600),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("access_sas").IsSet(),
+			),
+		},
+		data.ImportStep("access_duration_in_seconds", "access_sas"),
+		{
+			Config: r.access(data, 7200),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("access_sas").IsSet(),
+			),
+		},
+		data.ImportStep("access_duration_in_seconds", "access_sas"),
+		{
+			Config: r.fromManagedDisk(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("access_sas").HasValue(""),
+			),
+		},
+	})
+}
+
+func TestAccSnapshot_defaultTags(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot", "test")
+	r := SnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.defaultTags(data, "platform"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags_all.%").HasValue("2"),
+				data.CheckWithClient(r.hasTag("owner", "platform")),
+			),
+		},
+		{
+			// only the Provider's `default_tags` are changed here
+			Config: r.defaultTags(data, "networking"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags_all.owner").HasValue("networking"),
+				data.CheckWithClient(r.hasTag("owner", "networking")),
+			),
+		},
+	})
+}
+
+func TestAccSnapshot_fromUnmanagedDisk(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot", "test")
+	r := SnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fromUnmanagedDisk(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (SnapshotResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.SnapshotID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.SnapshotsClient.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.ID != nil), nil
+}
+
+func (SnapshotResource) hasTag(key string, value string) acceptance.ClientCheckFunc {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		id, err := parse.SnapshotID(state.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := clients.Compute.SnapshotsClient.Get(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			return fmt.Errorf("retrieving %s: %+v", *id, err)
+		}
+
+		if v, ok := resp.Tags[key]; !ok || v == nil || *v != value {
+			return fmt.Errorf("expected the tag %q on %s to be %q but got %+v", key, *id, value, resp.Tags)
+		}
+
+		return nil
+	}
+}
+
+func (SnapshotResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_managed_disk" "test" {
+  name                 = "acctestmd-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "10"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
+
+func (r SnapshotResource) fromManagedDisk(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_snapshot" "test" {
+  name                = "acctestss_%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  create_option       = "Copy"
+  source_resource_id  = azurestack_managed_disk.test.id
+
+  tags = {
+    environment = "acctest"
+    cost-center = "ops"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r SnapshotResource) fromManagedDiskUpdated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_snapshot" "test" {
+  name                = "acctestss_%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  create_option       = "Copy"
+  source_resource_id  = azurestack_managed_disk.test.id
+  disk_size_gb        = 20
+
+  tags = {
+    environment = "acctest"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (SnapshotResource) defaultTags(data acceptance.TestData, owner string) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+
+  default_tags {
+    tags = {
+      owner = "%s"
+    }
+  }
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_managed_disk" "test" {
+  name                 = "acctestmd-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "10"
+}
+
+resource "azurestack_snapshot" "test" {
+  name                = "acctestss_%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  create_option       = "Copy"
+  source_resource_id  = azurestack_managed_disk.test.id
+
+  tags = {
+    environment = "acctest"
+  }
+}
+`, owner, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
+
+func (r SnapshotResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_snapshot" "import" {
+  name                = azurestack_snapshot.test.name
+  location            = azurestack_snapshot.test.location
+  resource_group_name = azurestack_snapshot.test.resource_group_name
+  create_option       = azurestack_snapshot.test.create_option
+  source_resource_id  = azurestack_snapshot.test.source_resource_id
+}
+`, r.fromManagedDisk(data))
+}
+
+func (r SnapshotResource) incremental(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_snapshot" "test" {
+  name                = "acctestss_%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  create_option       = "Copy"
+  source_resource_id  = azurestack_managed_disk.test.id
+  incremental_enabled = true
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r SnapshotResource) access(data acceptance.TestData, durationInSeconds int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_snapshot" "test" {
+  name                       = "acctestss_%d"
+  location                   = azurestack_resource_group.test.location
+  resource_group_name        = azurestack_resource_group.test.name
+  create_option              = "Copy"
+  source_resource_id         = azurestack_managed_disk.test.id
+  access_duration_in_seconds = %d
+
+  tags = {
+    environment = "acctest"
+    cost-center = "ops"
+  }
+}
+`, r.template(data), data.RandomInteger, durationInSeconds)
+}
+
+func (SnapshotResource) fromUnmanagedDisk(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = azurestack_resource_group.test.name
+  location                 = azurestack_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurestack_storage_container" "test" {
+  name                  = "vhds"
+  storage_account_name  = azurestack_storage_account.test.name
+  container_access_type = "private"
+}
+
+resource "azurestack_storage_blob" "test" {
+  name                   = "acctest.vhd"
+  storage_account_name   = azurestack_storage_account.test.name
+  storage_container_name = azurestack_storage_container.test.name
+  type                   = "Page"
+  size                   = 5368709632
+}
+
+resource "azurestack_snapshot" "test" {
+  name                = "acctestss_%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  create_option       = "Import"
+  source_uri          = azurestack_storage_blob.test.url
+  storage_account_id  = azurestack_storage_account.test.id
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomInteger)
+}
@@ -1,9 +1,15 @@
 package compute
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestParseUsernameFromAuthorizedKeysPath(t *testing.T) {
@@ -61,3 +67,61 @@ func TestParseUsernameFromAuthorizedKeysPath(t *testing.T) {
 		}
 	}
 }
+
+func TestSSHKeysSchemaWithGeneratedPublicKey(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating private key: %+v", err)
+	}
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("building public key: %+v", err)
+	}
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+
+	// the `public_key` exported from `azurestack_ssh_public_key` is returned from the API as-is, which
+	// for a Key Pair generated by Azure includes a comment and Windows line endings
+	generatedKey := fmt.Sprintf("%s generated-by-azure\r\n", authorizedKey)
+
+	keySchema := SSHKeysSchema(true).Elem.(*pluginsdk.Resource).Schema["public_key"]
+
+	testData := []struct {
+		Name  string
+		Input string
+	}{
+		{
+			Name:  "Generated by Azure",
+			Input: generatedKey,
+		},
+		{
+			Name:  "Uploaded",
+			Input: authorizedKey + "\n",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		if _, errors := keySchema.ValidateFunc(v.Input, "public_key"); len(errors) > 0 {
+			t.Fatalf("Expected no validation errors but got: %+v", errors)
+		}
+
+		// the key returned from the Virtual Machine may not include the trailing line ending, which shouldn't cause a diff
+		returned := strings.TrimSpace(v.Input)
+		if !keySchema.DiffSuppressFunc("admin_ssh_key.0.public_key", returned, v.Input, nil) {
+			t.Fatalf("Expected the diff between %q and %q to be suppressed", returned, v.Input)
+		}
+
+		expected := SSHKeySchemaHash(map[string]interface{}{
+			"public_key": returned,
+			"username":   "adminuser",
+		})
+		actual := SSHKeySchemaHash(map[string]interface{}{
+			"public_key": v.Input,
+			"username":   "adminuser",
+		})
+		if actual != expected {
+			t.Fatalf("Expected the hash %d but got %d", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type SSHPublicKeyDataSource struct{}
+
+func TestAccSSHPublicKeyDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_ssh_public_key", "test")
+	r := SSHPublicKeyDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("public_key").Exists(),
+				check.That(data.ResourceName).Key("location").Exists(),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (SSHPublicKeyDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurestack_ssh_public_key" "test" {
+  name                = "acctestsshkey-%[1]d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+
+  tags = {
+    "foo" = "bar"
+  }
+}
+
+data "azurestack_ssh_public_key" "test" {
+  name                = azurestack_ssh_public_key.test.name
+  resource_group_name = azurestack_ssh_public_key.test.resource_group_name
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
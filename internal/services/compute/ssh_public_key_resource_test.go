@@ -0,0 +1,179 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+type SSHPublicKeyResource struct{}
+
+func TestAccSSHPublicKey_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_ssh_public_key", "test")
+	r := SSHPublicKeyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("public_key").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSSHPublicKey_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_ssh_public_key", "test")
+	r := SSHPublicKeyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccSSHPublicKey_generated(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_ssh_public_key", "test")
+	r := SSHPublicKeyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.generated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("public_key").Exists(),
+				check.That(data.ResourceName).Key("private_key").Exists(),
+			),
+		},
+		// the private key is only returned when the key pair is generated
+		data.ImportStep("private_key"),
+	})
+}
+
+func TestAccSSHPublicKey_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_ssh_public_key", "test")
+	r := SSHPublicKeyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.updated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("staging"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (SSHPublicKeyResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.SSHPublicKeyID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.SSHPublicKeysClient.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return pointer.FromBool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.ID != nil), nil
+}
+
+func (SSHPublicKeyResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+locals {
+  public_key = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC+wWK73dCr+jgQOAxNsHAnNNNMEMWOHYEccp6wJm2gotpr9katuF/ZAdou5AaW1C61slRkHRkpRRX9FA9CYBiitZgvCCz+3nWNN7l/Up54Zps/pHWGZLHNJZRYyAB6j5yVLMVHIHriY49d/GZTZVNB8GoJv9Gakwc/fuEZYYl4YDFiGMBP///TzlI4jhiJzjKnEvqPFki5p2ZRJqcbCiF4pJrxUQR/RXqVFQdbRLZgYfJ8xGB878RENq3yQ39d8dVOkq4edbkzwcUmwwwkYVPIoDGsYLaRHnG+To7FvMeyO7xDVQkMKzopTQV8AuKpyvpqu0a9pWOMaiCyDytO7GGN you@me.com"
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r SSHPublicKeyResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_ssh_public_key" "test" {
+  name                = "acctestsshkey-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  public_key          = local.public_key
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r SSHPublicKeyResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_ssh_public_key" "import" {
+  name                = azurestack_ssh_public_key.test.name
+  resource_group_name = azurestack_ssh_public_key.test.resource_group_name
+  location            = azurestack_ssh_public_key.test.location
+  public_key          = azurestack_ssh_public_key.test.public_key
+}
+`, r.basic(data))
+}
+
+func (r SSHPublicKeyResource) generated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_ssh_public_key" "test" {
+  name                = "acctestsshkey-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r SSHPublicKeyResource) updated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_ssh_public_key" "test" {
+  name                = "acctestsshkey-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  public_key          = local.public_key
+
+  tags = {
+    environment = "staging"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
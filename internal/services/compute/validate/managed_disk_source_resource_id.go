@@ -0,0 +1,28 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+)
+
+// ManagedDiskOrSnapshotID validates that the specified value is either the ID of a Managed Disk or a Snapshot,
+// both of which can be used as the source of a Managed Disk with the `Copy` create option
+func ManagedDiskOrSnapshotID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if _, err := parse.ManagedDiskID(v); err == nil {
+		return
+	}
+
+	if _, err := parse.SnapshotID(v); err == nil {
+		return
+	}
+
+	errors = append(errors, fmt.Errorf("expected %q to be the ID of a Managed Disk or a Snapshot but got %q", key, v))
+	return
+}
@@ -0,0 +1,21 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func SnapshotName(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", k))
+		return
+	}
+
+	// a-z, A-Z, 0-9, underscores, dots and hyphens, between 1 and 80 characters
+	if !regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,80}$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q may only contain alphanumeric characters, dots, dashes and underscores and must be between 1 and 80 characters, got %q", k, value))
+	}
+
+	return warnings, errors
+}
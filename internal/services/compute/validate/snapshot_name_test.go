@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnapshotName(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			// empty
+			input:    "",
+			expected: false,
+		},
+		{
+			// basic example
+			input:    "snapshot1",
+			expected: true,
+		},
+		{
+			// may contain alphanumerics, dots, dashes and underscores
+			input:    "hello_world7.goodbye-world4",
+			expected: true,
+		},
+		{
+			// 80 chars
+			input:    strings.Repeat("a", 80),
+			expected: true,
+		},
+		{
+			// 81 chars
+			input:    strings.Repeat("a", 81),
+			expected: false,
+		},
+		{
+			// invalid characters
+			input:    "hello/world",
+			expected: false,
+		},
+		{
+			// spaces
+			input:    "hello world",
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		_, errors := SnapshotName(v.input, "name")
+		actual := len(errors) == 0
+		if v.expected != actual {
+			t.Fatalf("Expected %t but got %t", v.expected, actual)
+		}
+	}
+}
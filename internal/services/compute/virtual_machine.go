@@ -2,6 +2,10 @@ package compute
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -300,3 +304,399 @@ func flattenVirtualMachineOSDisk(ctx context.Context, disksClient *compute.Disks
 		},
 	}, nil
 }
+
+func virtualMachineDataDiskSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"caching": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(compute.CachingTypesNone),
+						string(compute.CachingTypesReadOnly),
+						string(compute.CachingTypesReadWrite),
+					}, false),
+				},
+
+				"lun": {
+					Type:         pluginsdk.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+
+				// Optional
+				"create_option": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Default:  string(compute.DiskCreateOptionTypesAttach),
+					ValidateFunc: validation.StringInSlice([]string{
+						string(compute.DiskCreateOptionTypesAttach),
+						string(compute.DiskCreateOptionTypesEmpty),
+					}, false),
+				},
+
+				"disk_size_gb": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntBetween(1, 4095),
+				},
+
+				"managed_disk_id": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Computed: true,
+					// the Compute/VM API is broken and returns the Resource Group name in UPPERCASE
+					DiffSuppressFunc: suppress.CaseDifference,
+					ValidateFunc:     validate.ManagedDiskID,
+				},
+
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+
+				"storage_account_type": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Computed: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(compute.StorageAccountTypesPremiumLRS),
+						string(compute.StorageAccountTypesStandardLRS),
+					}, false),
+				},
+			},
+		},
+	}
+}
+
+// expandVirtualMachineDataDisks expands the `data_disk` blocks, looking up the Managed Disk for any
+// Data Disks which are being attached, since the API requires the Storage Account Type to be specified
+func expandVirtualMachineDataDisks(ctx context.Context, disksClient *compute.DisksClient, input []interface{}) (*[]compute.DataDisk, error) {
+	output := make([]compute.DataDisk, 0)
+	luns := make(map[int]struct{})
+
+	for _, v := range input {
+		raw := v.(map[string]interface{})
+
+		lun := raw["lun"].(int)
+		if _, exists := luns[lun]; exists {
+			return nil, fmt.Errorf("the `lun` %d is used by more than one `data_disk` block", lun)
+		}
+		luns[lun] = struct{}{}
+
+		disk := compute.DataDisk{
+			Caching:      compute.CachingTypes(raw["caching"].(string)),
+			CreateOption: compute.DiskCreateOptionTypes(raw["create_option"].(string)),
+			Lun:          utils.Int32(int32(lun)),
+
+			// Write Accelerator isn't supported on Azure Stack Hub
+			WriteAcceleratorEnabled: utils.Bool(false),
+		}
+
+		name := raw["name"].(string)
+		managedDiskId := raw["managed_disk_id"].(string)
+		diskSizeGb := raw["disk_size_gb"].(int)
+		storageAccountType := raw["storage_account_type"].(string)
+
+		switch disk.CreateOption {
+		case compute.DiskCreateOptionTypesAttach:
+			if managedDiskId == "" {
+				return nil, fmt.Errorf("`managed_disk_id` must be specified for the `data_disk` with `lun` %d when `create_option` is set to `Attach`", lun)
+			}
+
+			id, err := parse.ManagedDiskID(managedDiskId)
+			if err != nil {
+				return nil, err
+			}
+
+			existing, err := disksClient.Get(ctx, id.ResourceGroup, id.DiskName)
+			if err != nil {
+				return nil, fmt.Errorf("retrieving %s for the `data_disk` with `lun` %d: %+v", *id, lun, err)
+			}
+			if existing.Sku == nil {
+				return nil, fmt.Errorf("retrieving %s for the `data_disk` with `lun` %d: `sku` was nil", *id, lun)
+			}
+
+			disk.Name = utils.String(id.DiskName)
+			disk.ManagedDisk = &compute.ManagedDiskParameters{
+				ID:                 utils.String(managedDiskId),
+				StorageAccountType: compute.StorageAccountTypes(existing.Sku.Name),
+			}
+
+		case compute.DiskCreateOptionTypesEmpty:
+			if name == "" {
+				return nil, fmt.Errorf("`name` must be specified for the `data_disk` with `lun` %d when `create_option` is set to `Empty`", lun)
+			}
+			if diskSizeGb == 0 {
+				return nil, fmt.Errorf("`disk_size_gb` must be specified for the `data_disk` with `lun` %d when `create_option` is set to `Empty`", lun)
+			}
+			if storageAccountType == "" {
+				return nil, fmt.Errorf("`storage_account_type` must be specified for the `data_disk` with `lun` %d when `create_option` is set to `Empty`", lun)
+			}
+
+			disk.Name = utils.String(name)
+			disk.DiskSizeGB = utils.Int32(int32(diskSizeGb))
+			disk.ManagedDisk = &compute.ManagedDiskParameters{
+				StorageAccountType: compute.StorageAccountTypes(storageAccountType),
+			}
+
+			// once created the Managed Disk is referenced by its ID (which is returned from the API) - however this is
+			// only sent when the name matches, since the ID is computed and can be stale when a new disk is swapped in
+			if managedDiskId != "" {
+				id, err := parse.ManagedDiskID(managedDiskId)
+				if err != nil {
+					return nil, err
+				}
+				if strings.EqualFold(id.DiskName, name) {
+					disk.ManagedDisk.ID = utils.String(managedDiskId)
+				}
+			}
+		}
+
+		output = append(output, disk)
+	}
+
+	return &output, nil
+}
+
+// flattenVirtualMachineDataDisks flattens the Data Disks attached to the Virtual Machine, ordering them to match the
+// existing `data_disk` blocks (by `lun`) since the API doesn't guarantee the order these are returned in
+func flattenVirtualMachineDataDisks(input *[]compute.DataDisk, existing []interface{}) []interface{} {
+	output := make([]interface{}, 0)
+	if input == nil {
+		return output
+	}
+
+	// only the Data Disks defined in the `data_disk` blocks are tracked here, in the same order - any others (e.g.
+	// those attached using the `azurestack_virtual_machine_data_disk_attachment` resource) are caught during an update
+	order := make(map[int]int)
+	for i, v := range existing {
+		if raw, ok := v.(map[string]interface{}); ok {
+			order[raw["lun"].(int)] = i
+		}
+	}
+
+	dataDisks := make([]compute.DataDisk, 0)
+	for _, v := range *input {
+		if v.Lun == nil {
+			continue
+		}
+		if _, ok := order[int(*v.Lun)]; ok {
+			dataDisks = append(dataDisks, v)
+		}
+	}
+	sort.SliceStable(dataDisks, func(i, j int) bool {
+		return order[int(*dataDisks[i].Lun)] < order[int(*dataDisks[j].Lun)]
+	})
+
+	for _, v := range dataDisks {
+		name := ""
+		if v.Name != nil {
+			name = *v.Name
+		}
+
+		diskSizeGb := 0
+		if v.DiskSizeGB != nil {
+			diskSizeGb = int(*v.DiskSizeGB)
+		}
+
+		managedDiskId := ""
+		storageAccountType := ""
+		if v.ManagedDisk != nil {
+			if v.ManagedDisk.ID != nil {
+				managedDiskId = *v.ManagedDisk.ID
+			}
+			storageAccountType = string(v.ManagedDisk.StorageAccountType)
+		}
+
+		output = append(output, map[string]interface{}{
+			"caching":              string(v.Caching),
+			"create_option":        string(v.CreateOption),
+			"disk_size_gb":         diskSizeGb,
+			"lun":                  int(*v.Lun),
+			"managed_disk_id":      managedDiskId,
+			"name":                 name,
+			"storage_account_type": storageAccountType,
+		})
+	}
+
+	return output
+}
+
+// virtualMachineDataDisksWithExistingIDs returns the `data_disk` blocks, populating the `managed_disk_id` for any blocks
+// (where `create_option` is `Empty`) which don't have one but whose Managed Disk is already attached to the Virtual
+// Machine using the same `lun` and `name` - for example once the Virtual Machine has been imported, since the
+// `data_disk` block isn't populated during an import - so that the existing Managed Disk is used rather than recreated
+func virtualMachineDataDisksWithExistingIDs(existing *[]compute.DataDisk, input []interface{}) []interface{} {
+	if existing == nil {
+		return input
+	}
+
+	output := make([]interface{}, 0)
+	for _, v := range input {
+		raw, ok := v.(map[string]interface{})
+		if !ok || compute.DiskCreateOptionTypes(raw["create_option"].(string)) != compute.DiskCreateOptionTypesEmpty || raw["managed_disk_id"].(string) != "" {
+			output = append(output, v)
+			continue
+		}
+
+		dataDisk := make(map[string]interface{})
+		for key, value := range raw {
+			dataDisk[key] = value
+		}
+
+		for _, disk := range *existing {
+			if disk.Lun == nil || int(*disk.Lun) != raw["lun"].(int) || disk.Name == nil || !strings.EqualFold(*disk.Name, raw["name"].(string)) {
+				continue
+			}
+			if disk.ManagedDisk != nil && disk.ManagedDisk.ID != nil {
+				dataDisk["managed_disk_id"] = *disk.ManagedDisk.ID
+			}
+			break
+		}
+
+		output = append(output, dataDisk)
+	}
+
+	return output
+}
+
+// virtualMachineDataDisksResized returns whether the size of any of the existing Data Disks has been changed,
+// which requires the Virtual Machine to be deallocated
+func virtualMachineDataDisksResized(oldDataDisks []interface{}, newDataDisks []interface{}) bool {
+	oldSizes := make(map[string]int)
+	for _, v := range oldDataDisks {
+		raw := v.(map[string]interface{})
+		oldSizes[strings.ToLower(raw["name"].(string))] = raw["disk_size_gb"].(int)
+	}
+
+	for _, v := range newDataDisks {
+		raw := v.(map[string]interface{})
+		oldSize, ok := oldSizes[strings.ToLower(raw["name"].(string))]
+		if !ok || oldSize == 0 {
+			continue
+		}
+
+		if newSize := raw["disk_size_gb"].(int); newSize != 0 && newSize != oldSize {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateVirtualMachineDataDisksNotManagedExternally returns an error when the Virtual Machine has Data Disks attached
+// which aren't defined in either the previous or the new `data_disk` blocks - for example via the
+// `azurestack_virtual_machine_data_disk_attachment` resource - since both approaches can't be used together
+func validateVirtualMachineDataDisksNotManagedExternally(existing *[]compute.DataDisk, oldDataDisks []interface{}, newDataDisks []interface{}) error {
+	if existing == nil {
+		return nil
+	}
+
+	knownLuns := make(map[int]struct{})
+	for _, v := range append(oldDataDisks, newDataDisks...) {
+		raw, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		knownLuns[raw["lun"].(int)] = struct{}{}
+	}
+
+	conflicts := make([]string, 0)
+	for _, disk := range *existing {
+		if disk.Lun == nil {
+			continue
+		}
+		if _, ok := knownLuns[int(*disk.Lun)]; ok {
+			continue
+		}
+
+		name := ""
+		if disk.Name != nil {
+			name = *disk.Name
+		}
+		conflicts = append(conflicts, fmt.Sprintf("%q (lun %d)", name, *disk.Lun))
+	}
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return fmt.Errorf("the Data Disks %s are attached to this Virtual Machine outside of the `data_disk` block (for example using the `azurestack_virtual_machine_data_disk_attachment` resource) - Data Disks must either be managed using the `data_disk` block or using the `azurestack_virtual_machine_data_disk_attachment` resource, but not both", strings.Join(conflicts, ", "))
+	}
+
+	return nil
+}
+
+// virtualMachineRemovedEmptyDataDiskIDs returns the IDs of the Managed Disks which were created using the previous
+// `data_disk` blocks (where `create_option` is `Empty`) and which aren't used by the new `data_disk` blocks. Since the
+// `managed_disk_id` is computed for these the new blocks are matched on the `name` of the Managed Disk
+func virtualMachineRemovedEmptyDataDiskIDs(oldDataDisks []interface{}, newDataDisks []interface{}) []string {
+	inUse := make(map[string]struct{})
+	for _, v := range newDataDisks {
+		raw, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch compute.DiskCreateOptionTypes(raw["create_option"].(string)) {
+		case compute.DiskCreateOptionTypesAttach:
+			inUse[strings.ToLower(raw["managed_disk_id"].(string))] = struct{}{}
+		case compute.DiskCreateOptionTypesEmpty:
+			inUse[strings.ToLower(raw["name"].(string))] = struct{}{}
+		}
+	}
+
+	output := make([]string, 0)
+	for _, v := range oldDataDisks {
+		raw, ok := v.(map[string]interface{})
+		if !ok || compute.DiskCreateOptionTypes(raw["create_option"].(string)) != compute.DiskCreateOptionTypesEmpty {
+			continue
+		}
+
+		managedDiskId := raw["managed_disk_id"].(string)
+		if managedDiskId == "" {
+			continue
+		}
+		if _, ok := inUse[strings.ToLower(raw["name"].(string))]; ok {
+			continue
+		}
+		if _, ok := inUse[strings.ToLower(managedDiskId)]; ok {
+			continue
+		}
+
+		output = append(output, managedDiskId)
+	}
+
+	return output
+}
+
+// deleteVirtualMachineDataDisks deletes the specified Managed Disks, which must already have been detached from the Virtual Machine
+func deleteVirtualMachineDataDisks(ctx context.Context, disksClient *compute.DisksClient, managedDiskIds []string) error {
+	for _, managedDiskId := range managedDiskIds {
+		id, err := parse.ManagedDiskID(managedDiskId)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG] Deleting Data Disk %s..", *id)
+		future, err := disksClient.Delete(ctx, id.ResourceGroup, id.DiskName)
+		if err != nil {
+			if utils.WasNotFound(future.Response()) {
+				continue
+			}
+
+			return fmt.Errorf("deleting Data Disk %s: %+v", *id, err)
+		}
+
+		if err := future.WaitForCompletionRef(ctx, disksClient.Client); err != nil {
+			return fmt.Errorf("waiting for deletion of Data Disk %s: %+v", *id, err)
+		}
+		log.Printf("[DEBUG] Deleted Data Disk %s.", *id)
+	}
+
+	return nil
+}
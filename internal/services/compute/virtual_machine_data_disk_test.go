@@ -0,0 +1,396 @@
+package compute
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func TestFlattenVirtualMachineDataDisks(t *testing.T) {
+	input := &[]compute.DataDisk{
+		{
+			Name:       utils.String("disk1"),
+			Lun:        utils.Int32(1),
+			DiskSizeGB: utils.Int32(10),
+		},
+		{
+			Name:       utils.String("attached"),
+			Lun:        utils.Int32(5),
+			DiskSizeGB: utils.Int32(10),
+		},
+		{
+			Name:       utils.String("disk0"),
+			Lun:        utils.Int32(0),
+			DiskSizeGB: utils.Int32(20),
+		},
+	}
+
+	testData := []struct {
+		Name     string
+		Existing []interface{}
+		Expected []string
+	}{
+		{
+			Name:     "None Existing",
+			Existing: []interface{}{},
+			Expected: []string{},
+		},
+		{
+			Name: "Ordered By Existing",
+			Existing: []interface{}{
+				map[string]interface{}{"lun": 1},
+				map[string]interface{}{"lun": 0},
+			},
+			Expected: []string{"disk1", "disk0"},
+		},
+		{
+			Name: "Existing Removed From Virtual Machine",
+			Existing: []interface{}{
+				map[string]interface{}{"lun": 0},
+				map[string]interface{}{"lun": 2},
+			},
+			Expected: []string{"disk0"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := flattenVirtualMachineDataDisks(input, v.Existing)
+		if len(actual) != len(v.Expected) {
+			t.Fatalf("Expected %d Data Disks but got %d", len(v.Expected), len(actual))
+		}
+
+		for i, expected := range v.Expected {
+			if name := actual[i].(map[string]interface{})["name"].(string); name != expected {
+				t.Fatalf("Expected Data Disk %d to be %q but got %q", i, expected, name)
+			}
+		}
+	}
+}
+
+func TestVirtualMachineDataDisksResized(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Old      []interface{}
+		New      []interface{}
+		Expected bool
+	}{
+		{
+			Name: "Unchanged",
+			Old: []interface{}{
+				map[string]interface{}{"name": "disk0", "disk_size_gb": 10},
+			},
+			New: []interface{}{
+				map[string]interface{}{"name": "disk0", "disk_size_gb": 10},
+			},
+			Expected: false,
+		},
+		{
+			Name: "New Data Disk",
+			Old:  []interface{}{},
+			New: []interface{}{
+				map[string]interface{}{"name": "disk0", "disk_size_gb": 10},
+			},
+			Expected: false,
+		},
+		{
+			Name: "Resized",
+			Old: []interface{}{
+				map[string]interface{}{"name": "disk0", "disk_size_gb": 10},
+			},
+			New: []interface{}{
+				map[string]interface{}{"name": "DISK0", "disk_size_gb": 20},
+			},
+			Expected: true,
+		},
+		{
+			Name: "Size Not Specified",
+			Old: []interface{}{
+				map[string]interface{}{"name": "disk0", "disk_size_gb": 10},
+			},
+			New: []interface{}{
+				map[string]interface{}{"name": "disk0", "disk_size_gb": 0},
+			},
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		if actual := virtualMachineDataDisksResized(v.Old, v.New); actual != v.Expected {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+func TestValidateVirtualMachineDataDisksNotManagedExternally(t *testing.T) {
+	existing := &[]compute.DataDisk{
+		{
+			Name: utils.String("disk0"),
+			Lun:  utils.Int32(0),
+		},
+		{
+			Name: utils.String("disk1"),
+			Lun:  utils.Int32(1),
+		},
+	}
+
+	testData := []struct {
+		Name        string
+		Old         []interface{}
+		New         []interface{}
+		ShouldError bool
+	}{
+		{
+			Name: "All Managed",
+			Old: []interface{}{
+				map[string]interface{}{"lun": 0},
+			},
+			New: []interface{}{
+				map[string]interface{}{"lun": 0},
+				map[string]interface{}{"lun": 1},
+			},
+			ShouldError: false,
+		},
+		{
+			Name: "Removed From Configuration",
+			Old: []interface{}{
+				map[string]interface{}{"lun": 0},
+				map[string]interface{}{"lun": 1},
+			},
+			New:         []interface{}{},
+			ShouldError: false,
+		},
+		{
+			Name: "Attached Outside Of Configuration",
+			Old: []interface{}{
+				map[string]interface{}{"lun": 0},
+			},
+			New: []interface{}{
+				map[string]interface{}{"lun": 0},
+			},
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateVirtualMachineDataDisksNotManagedExternally(existing, v.Old, v.New)
+		if v.ShouldError && err == nil {
+			t.Fatalf("Expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestVirtualMachineDataDisksWithExistingIDs(t *testing.T) {
+	diskId := func(name string) string {
+		return "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/disks/" + name
+	}
+	dataDisk := func(createOption string, lun int, name string, managedDiskId string) map[string]interface{} {
+		return map[string]interface{}{
+			"create_option":   createOption,
+			"lun":             lun,
+			"managed_disk_id": managedDiskId,
+			"name":            name,
+		}
+	}
+	existing := &[]compute.DataDisk{
+		{
+			Name: utils.String("disk0"),
+			Lun:  utils.Int32(0),
+			ManagedDisk: &compute.ManagedDiskParameters{
+				ID: utils.String(diskId("disk0")),
+			},
+		},
+		{
+			Name: utils.String("disk1"),
+			Lun:  utils.Int32(1),
+			ManagedDisk: &compute.ManagedDiskParameters{
+				ID: utils.String(diskId("disk1")),
+			},
+		},
+	}
+
+	testData := []struct {
+		Name     string
+		Existing *[]compute.DataDisk
+		Input    []interface{}
+		Expected []interface{}
+	}{
+		{
+			Name:     "No Existing Data Disks",
+			Existing: nil,
+			Input: []interface{}{
+				dataDisk("Empty", 0, "disk0", ""),
+			},
+			Expected: []interface{}{
+				dataDisk("Empty", 0, "disk0", ""),
+			},
+		},
+		{
+			Name:     "Imported",
+			Existing: existing,
+			Input: []interface{}{
+				dataDisk("Empty", 0, "DISK0", ""),
+				dataDisk("Empty", 1, "disk1", ""),
+			},
+			Expected: []interface{}{
+				dataDisk("Empty", 0, "DISK0", diskId("disk0")),
+				dataDisk("Empty", 1, "disk1", diskId("disk1")),
+			},
+		},
+		{
+			Name:     "Different Lun",
+			Existing: existing,
+			Input: []interface{}{
+				dataDisk("Empty", 2, "disk0", ""),
+			},
+			Expected: []interface{}{
+				dataDisk("Empty", 2, "disk0", ""),
+			},
+		},
+		{
+			Name:     "Different Name",
+			Existing: existing,
+			Input: []interface{}{
+				dataDisk("Empty", 0, "disk2", ""),
+			},
+			Expected: []interface{}{
+				dataDisk("Empty", 0, "disk2", ""),
+			},
+		},
+		{
+			Name:     "Managed Disk ID Already Known",
+			Existing: existing,
+			Input: []interface{}{
+				dataDisk("Empty", 0, "disk0", diskId("other")),
+				dataDisk("Attach", 1, "", diskId("disk2")),
+			},
+			Expected: []interface{}{
+				dataDisk("Empty", 0, "disk0", diskId("other")),
+				dataDisk("Attach", 1, "", diskId("disk2")),
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := virtualMachineDataDisksWithExistingIDs(v.Existing, v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestVirtualMachineRemovedEmptyDataDiskIDs(t *testing.T) {
+	diskId := func(name string) string {
+		return "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/disks/" + name
+	}
+	emptyDisk := func(name string, managedDiskId string) map[string]interface{} {
+		return map[string]interface{}{
+			"create_option":   "Empty",
+			"managed_disk_id": managedDiskId,
+			"name":            name,
+		}
+	}
+	attachedDisk := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"create_option":   "Attach",
+			"managed_disk_id": diskId(name),
+			"name":            name,
+		}
+	}
+
+	testData := []struct {
+		Name     string
+		Old      []interface{}
+		New      []interface{}
+		Expected []string
+	}{
+		{
+			Name: "Unchanged",
+			Old: []interface{}{
+				emptyDisk("disk0", diskId("disk0")),
+			},
+			New: []interface{}{
+				emptyDisk("disk0", diskId("disk0")),
+			},
+			Expected: []string{},
+		},
+		{
+			Name: "Empty Disk Removed",
+			Old: []interface{}{
+				emptyDisk("disk0", diskId("disk0")),
+				emptyDisk("disk1", diskId("disk1")),
+			},
+			New: []interface{}{
+				emptyDisk("disk1", diskId("disk0")),
+			},
+			Expected: []string{diskId("disk0")},
+		},
+		{
+			Name: "Empty Disk Renamed",
+			Old: []interface{}{
+				emptyDisk("disk0", diskId("disk0")),
+			},
+			New: []interface{}{
+				emptyDisk("DISK1", diskId("disk0")),
+			},
+			Expected: []string{diskId("disk0")},
+		},
+		{
+			Name: "Empty Disk Attached",
+			Old: []interface{}{
+				emptyDisk("disk0", diskId("disk0")),
+			},
+			New: []interface{}{
+				attachedDisk("disk0"),
+			},
+			Expected: []string{},
+		},
+		{
+			Name: "Attached Disk Removed",
+			Old: []interface{}{
+				attachedDisk("disk0"),
+			},
+			New:      []interface{}{},
+			Expected: []string{},
+		},
+		{
+			Name: "Empty Disk Not Created",
+			Old: []interface{}{
+				emptyDisk("disk0", ""),
+			},
+			New:      nil,
+			Expected: []string{},
+		},
+		{
+			Name: "Virtual Machine Deleted",
+			Old: []interface{}{
+				emptyDisk("disk0", diskId("disk0")),
+				attachedDisk("disk1"),
+				emptyDisk("disk2", diskId("disk2")),
+			},
+			New:      nil,
+			Expected: []string{diskId("disk0"), diskId("disk2")},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := virtualMachineRemovedEmptyDataDiskIDs(v.Old, v.New)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package compute
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func virtualMachineExtensionImageDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: virtualMachineExtensionImageDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"location": commonschema.Location(),
+
+			"publisher": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"type": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"version_regex": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"types": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"latest_version": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"versions": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func virtualMachineExtensionImageDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMExtensionImageClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	loc := location.Normalize(d.Get("location").(string))
+	publisher := d.Get("publisher").(string)
+	typeName := d.Get("type").(string)
+
+	// the regular expression has been validated by the schema, so this can't fail
+	versionRegex := regexp.MustCompile(d.Get("version_regex").(string))
+
+	typesResp, err := client.ListTypes(ctx, loc, publisher)
+	if err != nil {
+		return fmt.Errorf("listing Virtual Machine Extension Image Types (Location %q / Publisher %q): %+v", loc, publisher, err)
+	}
+
+	typeNames := make([]string, 0)
+	if typesResp.Value != nil {
+		for _, item := range *typesResp.Value {
+			if item.Name == nil {
+				continue
+			}
+
+			if typeName != "" && !strings.EqualFold(*item.Name, typeName) {
+				continue
+			}
+
+			typeNames = append(typeNames, *item.Name)
+		}
+	}
+
+	if typeName != "" && len(typeNames) == 0 {
+		return fmt.Errorf("Virtual Machine Extension Image Type %q was not found (Location %q / Publisher %q)", typeName, loc, publisher)
+	}
+
+	types := make([]interface{}, 0)
+	for _, name := range typeNames {
+		versionsResp, err := client.ListVersions(ctx, loc, publisher, name, "", nil, "")
+		if err != nil {
+			return fmt.Errorf("listing Virtual Machine Extension Image Versions (Location %q / Publisher %q / Type %q): %+v", loc, publisher, name, err)
+		}
+
+		versions := make([]string, 0)
+		if versionsResp.Value != nil {
+			for _, item := range *versionsResp.Value {
+				if item.Name == nil || !versionRegex.MatchString(*item.Name) {
+					continue
+				}
+				versions = append(versions, *item.Name)
+			}
+		}
+		versions = sortVersionsDescending(versions)
+
+		latestVersion := ""
+		if len(versions) > 0 {
+			latestVersion = versions[0]
+		}
+
+		types = append(types, map[string]interface{}{
+			"name":           name,
+			"latest_version": latestVersion,
+			"versions":       versions,
+		})
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	d.Set("location", loc)
+
+	if err := d.Set("types", types); err != nil {
+		return fmt.Errorf("setting `types`: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type VirtualMachineExtensionImageDataSource struct{}
+
+func TestAccVirtualMachineExtensionImageDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_extension_image", "test")
+	r := VirtualMachineExtensionImageDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("types.#").Exists(),
+				check.That(data.ResourceName).Key("types.0.name").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccVirtualMachineExtensionImageDataSource_type(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_extension_image", "test")
+	r := VirtualMachineExtensionImageDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.withType(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("types.#").HasValue("1"),
+				check.That(data.ResourceName).Key("types.0.name").HasValue("CustomScript"),
+				check.That(data.ResourceName).Key("types.0.latest_version").Exists(),
+				check.That(data.ResourceName).Key("types.0.versions.#").Exists(),
+			),
+		},
+	})
+}
+
+func (VirtualMachineExtensionImageDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+data "azurestack_virtual_machine_extension_image" "test" {
+  location  = "%s"
+  publisher = "Microsoft.Azure.Extensions"
+}
+`, data.Locations.Primary)
+}
+
+func (VirtualMachineExtensionImageDataSource) withType(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+data "azurestack_virtual_machine_extension_image" "test" {
+  location      = "%s"
+  publisher     = "Microsoft.Azure.Extensions"
+  type          = "CustomScript"
+  version_regex = "^2\\."
+}
+`, data.Locations.Primary)
+}
@@ -743,6 +743,95 @@ func FlattenVirtualMachineScaleSetAutomaticOSUpgradePolicy(input *compute.Automa
 	}
 }
 
+func VirtualMachineScaleSetRollingUpgradePolicySchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"max_batch_instance_percent": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      20,
+					ValidateFunc: validation.IntBetween(5, 100),
+				},
+
+				"max_unhealthy_instance_percent": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      20,
+					ValidateFunc: validation.IntBetween(5, 100),
+				},
+
+				"max_unhealthy_upgraded_instance_percent": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      20,
+					ValidateFunc: validation.IntBetween(0, 100),
+				},
+
+				"pause_time_between_batches": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Default:      "PT0S",
+					ValidateFunc: utils.ISO8601Duration,
+				},
+			},
+		},
+	}
+}
+
+func ExpandVirtualMachineScaleSetRollingUpgradePolicy(input []interface{}) *compute.RollingUpgradePolicy {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	return &compute.RollingUpgradePolicy{
+		MaxBatchInstancePercent:             utils.Int32(int32(raw["max_batch_instance_percent"].(int))),
+		MaxUnhealthyInstancePercent:         utils.Int32(int32(raw["max_unhealthy_instance_percent"].(int))),
+		MaxUnhealthyUpgradedInstancePercent: utils.Int32(int32(raw["max_unhealthy_upgraded_instance_percent"].(int))),
+		PauseTimeBetweenBatches:             utils.String(raw["pause_time_between_batches"].(string)),
+	}
+}
+
+func FlattenVirtualMachineScaleSetRollingUpgradePolicy(input *compute.RollingUpgradePolicy) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	maxBatchInstancePercent := 0
+	if input.MaxBatchInstancePercent != nil {
+		maxBatchInstancePercent = int(*input.MaxBatchInstancePercent)
+	}
+
+	maxUnhealthyInstancePercent := 0
+	if input.MaxUnhealthyInstancePercent != nil {
+		maxUnhealthyInstancePercent = int(*input.MaxUnhealthyInstancePercent)
+	}
+
+	maxUnhealthyUpgradedInstancePercent := 0
+	if input.MaxUnhealthyUpgradedInstancePercent != nil {
+		maxUnhealthyUpgradedInstancePercent = int(*input.MaxUnhealthyUpgradedInstancePercent)
+	}
+
+	pauseTimeBetweenBatches := ""
+	if input.PauseTimeBetweenBatches != nil {
+		pauseTimeBetweenBatches = *input.PauseTimeBetweenBatches
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"max_batch_instance_percent":              maxBatchInstancePercent,
+			"max_unhealthy_instance_percent":          maxUnhealthyInstancePercent,
+			"max_unhealthy_upgraded_instance_percent": maxUnhealthyUpgradedInstancePercent,
+			"pause_time_between_batches":              pauseTimeBetweenBatches,
+		},
+	}
+}
+
 func VirtualMachineScaleSetTerminateNotificationSchema() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
 		Type:     pluginsdk.TypeList,
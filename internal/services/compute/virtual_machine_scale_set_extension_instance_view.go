@@ -0,0 +1,146 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+func virtualMachineScaleSetExtensionInstanceViewSummarySchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeMap,
+		Computed: true,
+		Elem: &pluginsdk.Schema{
+			Type: pluginsdk.TypeInt,
+		},
+	}
+}
+
+func virtualMachineScaleSetExtensionsInstanceViewSummarySchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"instance_view_summary": virtualMachineScaleSetExtensionInstanceViewSummarySchema(),
+			},
+		},
+	}
+}
+
+// retrieveVirtualMachineScaleSetExtensionsSummary returns the number of Instances in each Provisioning State
+// (e.g. `succeeded` or `failed`) for each of the Extensions within the Virtual Machine Scale Set
+func retrieveVirtualMachineScaleSetExtensionsSummary(ctx context.Context, client *compute.VirtualMachineScaleSetsClient, id parse.VirtualMachineScaleSetId) (map[string]map[string]int, error) {
+	resp, err := client.GetInstanceView(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Instance View for %s: %+v", id, err)
+	}
+
+	output := make(map[string]map[string]int)
+	if resp.Extensions == nil {
+		return output, nil
+	}
+
+	for _, extension := range *resp.Extensions {
+		if extension.Name == nil {
+			continue
+		}
+
+		output[*extension.Name] = flattenVirtualMachineScaleSetExtensionStatusesSummary(extension.StatusesSummary)
+	}
+
+	return output, nil
+}
+
+// flattenVirtualMachineScaleSetExtensionStatusesSummary returns the number of Instances in each Provisioning State,
+// the status codes are returned in the format `ProvisioningState/{state}` (optionally with an error code suffix)
+func flattenVirtualMachineScaleSetExtensionStatusesSummary(input *[]compute.VirtualMachineStatusCodeCount) map[string]int {
+	output := make(map[string]int)
+	if input == nil {
+		return output
+	}
+
+	prefix := "provisioningstate/"
+	for _, v := range *input {
+		if v.Code == nil || v.Count == nil {
+			continue
+		}
+
+		code := strings.ToLower(*v.Code)
+		if !strings.HasPrefix(code, prefix) {
+			continue
+		}
+
+		state := strings.Split(strings.TrimPrefix(code, prefix), "/")[0]
+		output[state] += int(*v.Count)
+	}
+
+	return output
+}
+
+func flattenVirtualMachineScaleSetExtensionsSummary(input map[string]map[string]int) []interface{} {
+	names := make([]string, 0, len(input))
+	for name := range input {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	output := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		output = append(output, map[string]interface{}{
+			"name":                  name,
+			"instance_view_summary": input[name],
+		})
+	}
+
+	return output
+}
+
+// checkVirtualMachineScaleSetExtensionsProvisioned returns an error when any of the specified Extensions failed to
+// provision on one or more Instances, so that a partially failed rollout surfaces as an error rather than succeeding
+func checkVirtualMachineScaleSetExtensionsProvisioned(summary map[string]map[string]int, extensionNames []string) error {
+	failures := make([]string, 0)
+	for name, states := range summary {
+		failed := states["failed"]
+		if failed == 0 {
+			continue
+		}
+
+		for _, extensionName := range extensionNames {
+			if strings.EqualFold(name, extensionName) {
+				failures = append(failures, fmt.Sprintf("%q (%d instance(s))", name, failed))
+				break
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return fmt.Errorf("the following Extensions failed to provision: %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+func virtualMachineScaleSetExtensionNames(input []interface{}) []string {
+	output := make([]string, 0)
+	for _, v := range input {
+		raw, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		output = append(output, raw["name"].(string))
+	}
+
+	return output
+}
@@ -0,0 +1,141 @@
+package compute
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func TestFlattenVirtualMachineScaleSetExtensionStatusesSummary(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    *[]compute.VirtualMachineStatusCodeCount
+		Expected map[string]int
+	}{
+		{
+			Name:     "nil",
+			Input:    nil,
+			Expected: map[string]int{},
+		},
+		{
+			Name: "succeeded and failed",
+			Input: &[]compute.VirtualMachineStatusCodeCount{
+				{
+					Code:  utils.String("ProvisioningState/succeeded"),
+					Count: utils.Int32(2),
+				},
+				{
+					Code:  utils.String("ProvisioningState/failed"),
+					Count: utils.Int32(1),
+				},
+			},
+			Expected: map[string]int{
+				"succeeded": 2,
+				"failed":    1,
+			},
+		},
+		{
+			Name: "error codes are grouped by state",
+			Input: &[]compute.VirtualMachineStatusCodeCount{
+				{
+					Code:  utils.String("ProvisioningState/failed/VMExtensionProvisioningError"),
+					Count: utils.Int32(1),
+				},
+				{
+					Code:  utils.String("ProvisioningState/failed/VMExtensionHandlerNonTransientError"),
+					Count: utils.Int32(2),
+				},
+			},
+			Expected: map[string]int{
+				"failed": 3,
+			},
+		},
+		{
+			Name: "other status codes are ignored",
+			Input: &[]compute.VirtualMachineStatusCodeCount{
+				{
+					Code:  utils.String("PowerState/running"),
+					Count: utils.Int32(2),
+				},
+				{
+					Code:  nil,
+					Count: utils.Int32(1),
+				},
+				{
+					Code:  utils.String("ProvisioningState/creating"),
+					Count: utils.Int32(1),
+				},
+			},
+			Expected: map[string]int{
+				"creating": 1,
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := flattenVirtualMachineScaleSetExtensionStatusesSummary(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestCheckVirtualMachineScaleSetExtensionsProvisioned(t *testing.T) {
+	summary := map[string]map[string]int{
+		"healthy": {
+			"succeeded": 3,
+		},
+		"CustomScript": {
+			"succeeded": 2,
+			"failed":    1,
+		},
+	}
+
+	testData := []struct {
+		Name           string
+		ExtensionNames []string
+		ExpectError    bool
+	}{
+		{
+			Name:           "no extensions",
+			ExtensionNames: []string{},
+			ExpectError:    false,
+		},
+		{
+			Name:           "succeeded",
+			ExtensionNames: []string{"healthy"},
+			ExpectError:    false,
+		},
+		{
+			Name:           "partially failed",
+			ExtensionNames: []string{"healthy", "CustomScript"},
+			ExpectError:    true,
+		},
+		{
+			Name:           "partially failed with a different casing",
+			ExtensionNames: []string{"customscript"},
+			ExpectError:    true,
+		},
+		{
+			Name:           "not present in the instance view",
+			ExtensionNames: []string{"other"},
+			ExpectError:    false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := checkVirtualMachineScaleSetExtensionsProvisioned(summary, v.ExtensionNames)
+		if v.ExpectError && err == nil {
+			t.Fatalf("Expected an error but didn't get one")
+		}
+		if !v.ExpectError && err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+	}
+}
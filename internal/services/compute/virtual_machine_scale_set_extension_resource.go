@@ -3,6 +3,7 @@ package compute
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
@@ -92,17 +93,29 @@ func virtualMachineScaleSetExtension() *pluginsdk.Resource {
 				DiffSuppressFunc: pluginsdk.SuppressJsonDiff,
 			},
 
+			"provision_after_extensions": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
 			"settings": {
 				Type:             pluginsdk.TypeString,
 				Optional:         true,
 				ValidateFunc:     validation.StringIsJSON,
 				DiffSuppressFunc: pluginsdk.SuppressJsonDiff,
 			},
+
+			"instance_view_summary": virtualMachineScaleSetExtensionInstanceViewSummarySchema(),
 		},
 	}
 }
 
 func virtualMachineScaleSetExtensionCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	vmssClient := meta.(*clients.Client).Compute.VMScaleSetClient
 	client := meta.(*clients.Client).Compute.VMScaleSetExtensionsClient
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -145,12 +158,13 @@ func virtualMachineScaleSetExtensionCreate(d *pluginsdk.ResourceData, meta inter
 	props := compute.VirtualMachineScaleSetExtension{
 		Name: pointer.FromString(id.ExtensionName),
 		VirtualMachineScaleSetExtensionProperties: &compute.VirtualMachineScaleSetExtensionProperties{
-			Publisher:               pointer.FromString(d.Get("publisher").(string)),
-			Type:                    pointer.FromString(d.Get("type").(string)),
-			TypeHandlerVersion:      pointer.FromString(d.Get("type_handler_version").(string)),
-			AutoUpgradeMinorVersion: pointer.FromBool(d.Get("auto_upgrade_minor_version").(bool)),
-			ProtectedSettings:       protectedSettings,
-			Settings:                settings,
+			Publisher:                pointer.FromString(d.Get("publisher").(string)),
+			Type:                     pointer.FromString(d.Get("type").(string)),
+			TypeHandlerVersion:       pointer.FromString(d.Get("type_handler_version").(string)),
+			AutoUpgradeMinorVersion:  pointer.FromBool(d.Get("auto_upgrade_minor_version").(bool)),
+			ProtectedSettings:        protectedSettings,
+			ProvisionAfterExtensions: utils.ExpandStringSlice(d.Get("provision_after_extensions").([]interface{})),
+			Settings:                 settings,
 		},
 	}
 	if v, ok := d.GetOk("force_update_tag"); ok {
@@ -168,10 +182,20 @@ func virtualMachineScaleSetExtensionCreate(d *pluginsdk.ResourceData, meta inter
 
 	d.SetId(id.ID()) // TODO before release confirm no state migration is required for this
 
+	// the Extension has been created at this point, so any failure is surfaced against the (tainted) resource
+	summary, err := retrieveVirtualMachineScaleSetExtensionsSummary(ctx, vmssClient, *virtualMachineScaleSetId)
+	if err != nil {
+		return err
+	}
+	if err := checkVirtualMachineScaleSetExtensionsProvisioned(summary, []string{id.ExtensionName}); err != nil {
+		return fmt.Errorf("provisioning %s: %+v", id, err)
+	}
+
 	return virtualMachineScaleSetExtensionRead(d, meta)
 }
 
 func virtualMachineScaleSetExtensionUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	vmssClient := meta.(*clients.Client).Compute.VMScaleSetClient
 	client := meta.(*clients.Client).Compute.VMScaleSetExtensionsClient
 	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -203,6 +227,10 @@ func virtualMachineScaleSetExtensionUpdate(d *pluginsdk.ResourceData, meta inter
 		props.ProtectedSettings = protectedSettings
 	}
 
+	if d.HasChange("provision_after_extensions") {
+		props.ProvisionAfterExtensions = utils.ExpandStringSlice(d.Get("provision_after_extensions").([]interface{}))
+	}
+
 	if d.HasChange("publisher") {
 		props.Publisher = pointer.FromString(d.Get("publisher").(string))
 	}
@@ -242,6 +270,15 @@ func virtualMachineScaleSetExtensionUpdate(d *pluginsdk.ResourceData, meta inter
 		return fmt.Errorf("waiting for update of Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", id.ExtensionName, id.VirtualMachineScaleSetName, id.ResourceGroup, err)
 	}
 
+	virtualMachineScaleSetId := parse.NewVirtualMachineScaleSetID(id.SubscriptionId, id.ResourceGroup, id.VirtualMachineScaleSetName)
+	summary, err := retrieveVirtualMachineScaleSetExtensionsSummary(ctx, vmssClient, virtualMachineScaleSetId)
+	if err != nil {
+		return err
+	}
+	if err := checkVirtualMachineScaleSetExtensionsProvisioned(summary, []string{id.ExtensionName}); err != nil {
+		return fmt.Errorf("provisioning %s: %+v", id, err)
+	}
+
 	return virtualMachineScaleSetExtensionRead(d, meta)
 }
 
@@ -284,6 +321,7 @@ func virtualMachineScaleSetExtensionRead(d *pluginsdk.ResourceData, meta interfa
 	if props := resp.VirtualMachineScaleSetExtensionProperties; props != nil {
 		d.Set("auto_upgrade_minor_version", props.AutoUpgradeMinorVersion)
 		d.Set("force_update_tag", props.ForceUpdateTag)
+		d.Set("provision_after_extensions", utils.FlattenStringSlice(props.ProvisionAfterExtensions))
 		d.Set("publisher", props.Publisher)
 		d.Set("type", props.Type)
 		d.Set("type_handler_version", props.TypeHandlerVersion)
@@ -302,6 +340,21 @@ func virtualMachineScaleSetExtensionRead(d *pluginsdk.ResourceData, meta interfa
 		d.Set("settings", settings)
 	}
 
+	summary, err := retrieveVirtualMachineScaleSetExtensionsSummary(ctx, vmssClient, parse.NewVirtualMachineScaleSetID(id.SubscriptionId, id.ResourceGroup, id.VirtualMachineScaleSetName))
+	if err != nil {
+		return err
+	}
+	instanceViewSummary := make(map[string]int)
+	for name, states := range summary {
+		if strings.EqualFold(name, id.ExtensionName) {
+			instanceViewSummary = states
+			break
+		}
+	}
+	if err := d.Set("instance_view_summary", instanceViewSummary); err != nil {
+		return fmt.Errorf("setting `instance_view_summary`: %+v", err)
+	}
+
 	return nil
 }
 
@@ -143,6 +143,22 @@ func TestAccVirtualMachineScaleSetExtension_updateVersion(t *testing.T) {
 	})
 }
 
+func TestAccVirtualMachineScaleSetExtension_provisionAfterExtensions(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_scale_set_extension", "test")
+	r := VirtualMachineScaleSetExtensionResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.provisionAfterExtensions(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("provision_after_extensions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("instance_view_summary.succeeded").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (t VirtualMachineScaleSetExtensionResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.VirtualMachineScaleSetExtensionID(state.ID)
 	if err != nil {
@@ -227,6 +243,35 @@ resource "azurestack_virtual_machine_scale_set_extension" "test" {
 `, r.templateLinux(data), data.RandomInteger, tag)
 }
 
+func (r VirtualMachineScaleSetExtensionResource) provisionAfterExtensions(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_scale_set_extension" "first" {
+  name                         = "acctestExt1-%d"
+  virtual_machine_scale_set_id = azurestack_linux_virtual_machine_scale_set.test.id
+  publisher                    = "Microsoft.Azure.Extensions"
+  type                         = "CustomScript"
+  type_handler_version         = "2.0"
+  settings = jsonencode({
+    "commandToExecute" = "echo $HOSTNAME"
+  })
+}
+
+resource "azurestack_virtual_machine_scale_set_extension" "test" {
+  name                         = "acctestExt2-%d"
+  virtual_machine_scale_set_id = azurestack_linux_virtual_machine_scale_set.test.id
+  publisher                    = "Microsoft.OSTCExtensions"
+  type                         = "CustomScriptForLinux"
+  type_handler_version         = "1.5"
+  provision_after_extensions   = [azurestack_virtual_machine_scale_set_extension.first.name]
+  settings = jsonencode({
+    "commandToExecute" = "echo $HOSTNAME"
+  })
+}
+`, r.templateLinux(data), data.RandomInteger, data.RandomInteger)
+}
+
 func (r VirtualMachineScaleSetExtensionResource) updateVersion(data acceptance.TestData, version string) string {
 	return fmt.Sprintf(`
 %s
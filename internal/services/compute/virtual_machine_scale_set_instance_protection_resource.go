@@ -0,0 +1,212 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func virtualMachineScaleSetInstanceProtection() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: virtualMachineScaleSetInstanceProtectionCreate,
+		Read:   virtualMachineScaleSetInstanceProtectionRead,
+		Update: virtualMachineScaleSetInstanceProtectionUpdate,
+		Delete: virtualMachineScaleSetInstanceProtectionDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.VirtualMachineScaleSetInstanceID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"virtual_machine_scale_set_instance_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.VirtualMachineScaleSetInstanceID,
+			},
+
+			"protect_from_scale_in": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"protect_from_scale_set_actions": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func virtualMachineScaleSetInstanceProtectionCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMScaleSetVMsClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineScaleSetInstanceID(d.Get("virtual_machine_scale_set_instance_id").(string))
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.Get(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("%s was not found", *id)
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	if props := existing.VirtualMachineScaleSetVMProperties; props != nil && props.ProtectionPolicy != nil {
+		policy := props.ProtectionPolicy
+		// an instance which is already protected needs to be imported
+		if (policy.ProtectFromScaleIn != nil && *policy.ProtectFromScaleIn) || (policy.ProtectFromScaleSetActions != nil && *policy.ProtectFromScaleSetActions) {
+			return tf.ImportAsExistsError("azurestack_virtual_machine_scale_set_instance_protection", id.ID())
+		}
+	}
+
+	policy := compute.VirtualMachineScaleSetVMProtectionPolicy{
+		ProtectFromScaleIn:         utils.Bool(d.Get("protect_from_scale_in").(bool)),
+		ProtectFromScaleSetActions: utils.Bool(d.Get("protect_from_scale_set_actions").(bool)),
+	}
+	if err := updateVirtualMachineScaleSetInstanceProtectionPolicy(ctx, client, *id, existing, policy); err != nil {
+		return err
+	}
+
+	d.SetId(id.ID())
+
+	return virtualMachineScaleSetInstanceProtectionRead(d, meta)
+}
+
+func virtualMachineScaleSetInstanceProtectionRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMScaleSetVMsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineScaleSetInstanceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s does not exist - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("virtual_machine_scale_set_instance_id", id.ID())
+
+	protectFromScaleIn := false
+	protectFromScaleSetActions := false
+	if props := resp.VirtualMachineScaleSetVMProperties; props != nil && props.ProtectionPolicy != nil {
+		if props.ProtectionPolicy.ProtectFromScaleIn != nil {
+			protectFromScaleIn = *props.ProtectionPolicy.ProtectFromScaleIn
+		}
+		if props.ProtectionPolicy.ProtectFromScaleSetActions != nil {
+			protectFromScaleSetActions = *props.ProtectionPolicy.ProtectFromScaleSetActions
+		}
+	}
+	d.Set("protect_from_scale_in", protectFromScaleIn)
+	d.Set("protect_from_scale_set_actions", protectFromScaleSetActions)
+
+	return nil
+}
+
+func virtualMachineScaleSetInstanceProtectionUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMScaleSetVMsClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineScaleSetInstanceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.Get(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, "")
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	policy := compute.VirtualMachineScaleSetVMProtectionPolicy{
+		ProtectFromScaleIn:         utils.Bool(d.Get("protect_from_scale_in").(bool)),
+		ProtectFromScaleSetActions: utils.Bool(d.Get("protect_from_scale_set_actions").(bool)),
+	}
+	if err := updateVirtualMachineScaleSetInstanceProtectionPolicy(ctx, client, *id, existing, policy); err != nil {
+		return err
+	}
+
+	return virtualMachineScaleSetInstanceProtectionRead(d, meta)
+}
+
+func virtualMachineScaleSetInstanceProtectionDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMScaleSetVMsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineScaleSetInstanceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.Get(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			log.Printf("[DEBUG] %s could not be found - nothing to remove", *id)
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	policy := compute.VirtualMachineScaleSetVMProtectionPolicy{
+		ProtectFromScaleIn:         utils.Bool(false),
+		ProtectFromScaleSetActions: utils.Bool(false),
+	}
+	return updateVirtualMachineScaleSetInstanceProtectionPolicy(ctx, client, *id, existing, policy)
+}
+
+func updateVirtualMachineScaleSetInstanceProtectionPolicy(ctx context.Context, client *compute.VirtualMachineScaleSetVMsClient, id parse.VirtualMachineScaleSetInstanceId, existing compute.VirtualMachineScaleSetVM, policy compute.VirtualMachineScaleSetVMProtectionPolicy) error {
+	if existing.VirtualMachineScaleSetVMProperties == nil {
+		return fmt.Errorf("retrieving %s: `properties` was nil", id)
+	}
+
+	// the Instance View and the Extensions are read-only and are rejected by the API when sent back
+	existing.Resources = nil
+	existing.VirtualMachineScaleSetVMProperties.InstanceView = nil
+	existing.VirtualMachineScaleSetVMProperties.ProtectionPolicy = &policy
+
+	future, err := client.Update(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, existing)
+	if err != nil {
+		return fmt.Errorf("updating Protection Policy for %s: %+v", id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for the update of the Protection Policy for %s: %+v", id, err)
+	}
+
+	return nil
+}
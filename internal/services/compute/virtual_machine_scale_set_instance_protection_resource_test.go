@@ -0,0 +1,132 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+type VirtualMachineScaleSetInstanceProtectionResource struct{}
+
+func TestAccVirtualMachineScaleSetInstanceProtection_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_scale_set_instance_protection", "test")
+	r := VirtualMachineScaleSetInstanceProtectionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, true, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("protect_from_scale_in").HasValue("true"),
+				check.That(data.ResourceName).Key("protect_from_scale_set_actions").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccVirtualMachineScaleSetInstanceProtection_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_scale_set_instance_protection", "test")
+	r := VirtualMachineScaleSetInstanceProtectionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, true, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccVirtualMachineScaleSetInstanceProtection_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_scale_set_instance_protection", "test")
+	r := VirtualMachineScaleSetInstanceProtectionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, true, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, true, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("protect_from_scale_set_actions").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, true, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("protect_from_scale_set_actions").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (VirtualMachineScaleSetInstanceProtectionResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.VirtualMachineScaleSetInstanceID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.VMScaleSetVMsClient.Get(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return pointer.FromBool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	if props := resp.VirtualMachineScaleSetVMProperties; props != nil && props.ProtectionPolicy != nil {
+		policy := props.ProtectionPolicy
+		protected := (policy.ProtectFromScaleIn != nil && *policy.ProtectFromScaleIn) || (policy.ProtectFromScaleSetActions != nil && *policy.ProtectFromScaleSetActions)
+		return pointer.FromBool(protected), nil
+	}
+
+	return pointer.FromBool(false), nil
+}
+
+func (VirtualMachineScaleSetInstanceProtectionResource) basic(data acceptance.TestData, protectFromScaleIn, protectFromScaleSetActions bool) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_machine_scale_set_instances" "test" {
+  name                = azurestack_linux_virtual_machine_scale_set.test.name
+  resource_group_name = azurestack_linux_virtual_machine_scale_set.test.resource_group_name
+}
+
+resource "azurestack_virtual_machine_scale_set_instance_protection" "test" {
+  virtual_machine_scale_set_instance_id = data.azurestack_virtual_machine_scale_set_instances.test.instances.0.id
+  protect_from_scale_in                 = %t
+  protect_from_scale_set_actions        = %t
+}
+`, LinuxVirtualMachineScaleSetResource{}.authPassword(data), protectFromScaleIn, protectFromScaleSetActions)
+}
+
+func (r VirtualMachineScaleSetInstanceProtectionResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_scale_set_instance_protection" "import" {
+  virtual_machine_scale_set_instance_id = azurestack_virtual_machine_scale_set_instance_protection.test.virtual_machine_scale_set_instance_id
+  protect_from_scale_in                 = azurestack_virtual_machine_scale_set_instance_protection.test.protect_from_scale_in
+  protect_from_scale_set_actions        = azurestack_virtual_machine_scale_set_instance_protection.test.protect_from_scale_set_actions
+}
+`, r.basic(data, true, false))
+}
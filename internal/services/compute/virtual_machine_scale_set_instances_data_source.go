@@ -0,0 +1,278 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func virtualMachineScaleSetInstancesDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: virtualMachineScaleSetInstancesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"location": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"instances": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"instance_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"computer_name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"private_ip_address": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"private_ip_addresses": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"power_state": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"latest_model_applied": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+
+						"zone": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func virtualMachineScaleSetInstancesDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	scaleSetsClient := meta.(*clients.Client).Compute.VMScaleSetClient
+	instancesClient := meta.(*clients.Client).Compute.VMScaleSetVMsClient
+	networkInterfacesClient := meta.(*clients.Client).Network.InterfacesClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewVirtualMachineScaleSetID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	resp, err := scaleSetsClient.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("%s was not found", id)
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	privateIPAddresses, err := retrieveVirtualMachineScaleSetPrivateIPAddresses(ctx, networkInterfacesClient, id)
+	if err != nil {
+		return err
+	}
+
+	instances := make([]interface{}, 0)
+	iterator, err := instancesClient.ListComplete(ctx, id.ResourceGroup, id.Name, "", "", "instanceView")
+	if err != nil {
+		return fmt.Errorf("listing Instances for %s: %+v", id, err)
+	}
+	for iterator.NotDone() {
+		instances = append(instances, flattenVirtualMachineScaleSetInstance(iterator.Value(), privateIPAddresses))
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("listing Instances for %s: %+v", id, err)
+		}
+	}
+
+	d.SetId(id.ID())
+
+	d.Set("name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("location", location.NormalizeNilable(resp.Location))
+
+	if err := d.Set("instances", instances); err != nil {
+		return fmt.Errorf("setting `instances`: %+v", err)
+	}
+
+	return nil
+}
+
+// retrieveVirtualMachineScaleSetPrivateIPAddresses returns the Private IP Addresses assigned to each of the
+// Instances within the Scale Set, keyed by the (lower-cased) Resource ID of the Instance
+func retrieveVirtualMachineScaleSetPrivateIPAddresses(ctx context.Context, client *network.InterfacesClient, id parse.VirtualMachineScaleSetId) (map[string][]string, error) {
+	output := make(map[string][]string)
+
+	iterator, err := client.ListVirtualMachineScaleSetNetworkInterfacesComplete(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return nil, fmt.Errorf("listing Network Interfaces for %s: %+v", id, err)
+	}
+	for iterator.NotDone() {
+		nic := iterator.Value()
+		if props := nic.InterfacePropertiesFormat; props != nil && props.VirtualMachine != nil && props.VirtualMachine.ID != nil {
+			instanceId := strings.ToLower(*props.VirtualMachine.ID)
+
+			// the Primary Network Interface is listed first so that `private_ip_address` is the primary address
+			primary := props.Primary != nil && *props.Primary
+			addresses := flattenNetworkInterfacePrivateIPAddresses(props.IPConfigurations)
+			if primary {
+				output[instanceId] = append(addresses, output[instanceId]...)
+			} else {
+				output[instanceId] = append(output[instanceId], addresses...)
+			}
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing Network Interfaces for %s: %+v", id, err)
+		}
+	}
+
+	return output, nil
+}
+
+func flattenNetworkInterfacePrivateIPAddresses(input *[]network.InterfaceIPConfiguration) []string {
+	output := make([]string, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, config := range *input {
+		if props := config.InterfaceIPConfigurationPropertiesFormat; props != nil && props.PrivateIPAddress != nil {
+			output = append(output, *props.PrivateIPAddress)
+		}
+	}
+
+	return output
+}
+
+func flattenVirtualMachineScaleSetInstance(input compute.VirtualMachineScaleSetVM, privateIPAddresses map[string][]string) map[string]interface{} {
+	id := ""
+	if input.ID != nil {
+		id = *input.ID
+	}
+
+	instanceId := ""
+	if input.InstanceID != nil {
+		instanceId = *input.InstanceID
+	}
+
+	name := ""
+	if input.Name != nil {
+		name = *input.Name
+	}
+
+	zone := ""
+	if input.Zones != nil && len(*input.Zones) > 0 {
+		zone = (*input.Zones)[0]
+	}
+
+	computerName := ""
+	latestModelApplied := false
+	powerState := ""
+	if props := input.VirtualMachineScaleSetVMProperties; props != nil {
+		if props.OsProfile != nil && props.OsProfile.ComputerName != nil {
+			computerName = *props.OsProfile.ComputerName
+		}
+
+		if props.LatestModelApplied != nil {
+			latestModelApplied = *props.LatestModelApplied
+		}
+
+		if props.InstanceView != nil {
+			powerState = flattenVirtualMachineScaleSetInstancePowerState(props.InstanceView.Statuses)
+		}
+	}
+
+	addresses := privateIPAddresses[strings.ToLower(id)]
+	if addresses == nil {
+		addresses = make([]string, 0)
+	}
+	privateIPAddress := ""
+	if len(addresses) > 0 {
+		privateIPAddress = addresses[0]
+	}
+
+	return map[string]interface{}{
+		"id":                   id,
+		"instance_id":          instanceId,
+		"name":                 name,
+		"computer_name":        computerName,
+		"private_ip_address":   privateIPAddress,
+		"private_ip_addresses": addresses,
+		"power_state":          powerState,
+		"latest_model_applied": latestModelApplied,
+		"zone":                 zone,
+	}
+}
+
+// flattenVirtualMachineScaleSetInstancePowerState returns the Power State (e.g. `running`) from the
+// Instance View Statuses, which is exposed in the format `PowerState/running`
+func flattenVirtualMachineScaleSetInstancePowerState(input *[]compute.InstanceViewStatus) string {
+	if input == nil {
+		return ""
+	}
+
+	for _, status := range *input {
+		if status.Code == nil {
+			continue
+		}
+
+		prefix := "PowerState/"
+		if strings.HasPrefix(*status.Code, prefix) {
+			return strings.TrimPrefix(*status.Code, prefix)
+		}
+	}
+
+	return ""
+}
@@ -1598,6 +1598,17 @@ func virtualMachineScaleSetExtensionHash(v interface{}) int {
 			buf.WriteString(fmt.Sprintf("%t-", v.(bool)))
 		}
 
+		// these are only hashed when set, so that the hash of existing Extensions doesn't change
+		if v, ok := m["force_update_tag"]; ok && v.(string) != "" {
+			buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+		}
+
+		if v, ok := m["provision_after_extensions"]; ok {
+			for _, extension := range v.([]interface{}) {
+				buf.WriteString(fmt.Sprintf("%s-", extension.(string)))
+			}
+		}
+
 		// we need to ensure the whitespace is consistent
 		settings := m["settings"].(string)
 		if settings != "" {
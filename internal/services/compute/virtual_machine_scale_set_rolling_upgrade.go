@@ -0,0 +1,388 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/client"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/rickb777/date/period"
+)
+
+// vmScaleSetVMsClient is the subset of the Virtual Machine Scale Set (VM) API's used to roll the Instances
+// within a Scale Set using the Manual upgrade mode - which allows the batching logic to be tested in isolation
+type vmScaleSetVMsClient interface {
+	// ListInstances returns all of the Instances within the Virtual Machine Scale Set
+	ListInstances(ctx context.Context) ([]compute.VirtualMachineScaleSetVM, error)
+
+	// UpgradeInstances updates the specified Instances to the latest model and then reimages them
+	UpgradeInstances(ctx context.Context, instanceIds []string) error
+
+	// GetInstanceView returns the Instance View for the specified Instance
+	GetInstanceView(ctx context.Context, instanceId string) (compute.VirtualMachineScaleSetVMInstanceView, error)
+}
+
+var _ vmScaleSetVMsClient = sdkVMScaleSetVMsClient{}
+
+type sdkVMScaleSetVMsClient struct {
+	client *client.Client
+	id     parse.VirtualMachineScaleSetId
+}
+
+func (c sdkVMScaleSetVMsClient) ListInstances(ctx context.Context) ([]compute.VirtualMachineScaleSetVM, error) {
+	instances := make([]compute.VirtualMachineScaleSetVM, 0)
+
+	iterator, err := c.client.VMScaleSetVMsClient.ListComplete(ctx, c.id.ResourceGroup, c.id.Name, "", "", "")
+	if err != nil {
+		return nil, fmt.Errorf("listing Instances for %s: %+v", c.id, err)
+	}
+	for iterator.NotDone() {
+		instances = append(instances, iterator.Value())
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("enumerating Instances for %s: %+v", c.id, err)
+		}
+	}
+
+	return instances, nil
+}
+
+func (c sdkVMScaleSetVMsClient) UpgradeInstances(ctx context.Context, instanceIds []string) error {
+	scaleSetsClient := c.client.VMScaleSetClient
+
+	log.Printf("[DEBUG] Updating Instances %s of %s to the Latest Configuration..", strings.Join(instanceIds, ", "), c.id)
+	ids := compute.VirtualMachineScaleSetVMInstanceRequiredIDs{
+		InstanceIds: &instanceIds,
+	}
+	future, err := scaleSetsClient.UpdateInstances(ctx, c.id.ResourceGroup, c.id.Name, ids)
+	if err != nil {
+		return fmt.Errorf("updating Instances %s of %s to the Latest Configuration: %+v", strings.Join(instanceIds, ", "), c.id, err)
+	}
+	if err = future.WaitForCompletionRef(ctx, scaleSetsClient.Client); err != nil {
+		return fmt.Errorf("waiting for update of Instances %s of %s to the Latest Configuration: %+v", strings.Join(instanceIds, ", "), c.id, err)
+	}
+
+	// TODO: does this want to be a separate, user-configurable toggle?
+	log.Printf("[DEBUG] Reimaging Instances %s of %s..", strings.Join(instanceIds, ", "), c.id)
+	reimageInput := &compute.VirtualMachineScaleSetReimageParameters{
+		InstanceIds: &instanceIds,
+	}
+	reimageFuture, err := scaleSetsClient.Reimage(ctx, c.id.ResourceGroup, c.id.Name, reimageInput)
+	if err != nil {
+		return fmt.Errorf("reimaging Instances %s of %s: %+v", strings.Join(instanceIds, ", "), c.id, err)
+	}
+	if err = reimageFuture.WaitForCompletionRef(ctx, scaleSetsClient.Client); err != nil {
+		return fmt.Errorf("waiting for reimage of Instances %s of %s: %+v", strings.Join(instanceIds, ", "), c.id, err)
+	}
+
+	return nil
+}
+
+func (c sdkVMScaleSetVMsClient) GetInstanceView(ctx context.Context, instanceId string) (compute.VirtualMachineScaleSetVMInstanceView, error) {
+	resp, err := c.client.VMScaleSetVMsClient.GetInstanceView(ctx, c.id.ResourceGroup, c.id.Name, instanceId)
+	if err != nil {
+		return resp, fmt.Errorf("retrieving Instance View for Instance %q of %s: %+v", instanceId, c.id, err)
+	}
+
+	return resp, nil
+}
+
+type virtualMachineScaleSetInstanceHealth string
+
+const (
+	virtualMachineScaleSetInstanceHealthy   virtualMachineScaleSetInstanceHealth = "healthy"
+	virtualMachineScaleSetInstancePending   virtualMachineScaleSetInstanceHealth = "pending"
+	virtualMachineScaleSetInstanceUnhealthy virtualMachineScaleSetInstanceHealth = "unhealthy"
+)
+
+// virtualMachineScaleSetRollingUpgradeOptions is the parsed representation of the `rolling_upgrade_policy` block
+type virtualMachineScaleSetRollingUpgradeOptions struct {
+	// MaxBatchInstancePercent is the percentage of the total Instances to upgrade in each batch,
+	// when this is zero Instances are upgraded one at a time
+	MaxBatchInstancePercent int
+
+	MaxUnhealthyInstancePercent         int
+	MaxUnhealthyUpgradedInstancePercent int
+	PauseTimeBetweenBatches             time.Duration
+}
+
+// defaultVirtualMachineScaleSetRollingUpgradeOptions is used when no `rolling_upgrade_policy` block is specified,
+// which upgrades the Instances one at a time without checking their health
+func defaultVirtualMachineScaleSetRollingUpgradeOptions() virtualMachineScaleSetRollingUpgradeOptions {
+	return virtualMachineScaleSetRollingUpgradeOptions{
+		MaxBatchInstancePercent:             0,
+		MaxUnhealthyInstancePercent:         100,
+		MaxUnhealthyUpgradedInstancePercent: 100,
+		PauseTimeBetweenBatches:             0,
+	}
+}
+
+func parseVirtualMachineScaleSetRollingUpgradeOptions(input *compute.RollingUpgradePolicy) (*virtualMachineScaleSetRollingUpgradeOptions, error) {
+	options := defaultVirtualMachineScaleSetRollingUpgradeOptions()
+	if input == nil {
+		return &options, nil
+	}
+
+	if input.MaxBatchInstancePercent != nil {
+		options.MaxBatchInstancePercent = int(*input.MaxBatchInstancePercent)
+	}
+	if input.MaxUnhealthyInstancePercent != nil {
+		options.MaxUnhealthyInstancePercent = int(*input.MaxUnhealthyInstancePercent)
+	}
+	if input.MaxUnhealthyUpgradedInstancePercent != nil {
+		options.MaxUnhealthyUpgradedInstancePercent = int(*input.MaxUnhealthyUpgradedInstancePercent)
+	}
+	if input.PauseTimeBetweenBatches != nil && *input.PauseTimeBetweenBatches != "" {
+		pause, err := period.Parse(*input.PauseTimeBetweenBatches)
+		if err != nil {
+			return nil, fmt.Errorf("parsing `pause_time_between_batches` %q: %+v", *input.PauseTimeBetweenBatches, err)
+		}
+		options.PauseTimeBetweenBatches = pause.DurationApprox()
+	}
+
+	return &options, nil
+}
+
+// virtualMachineScaleSetRollingUpgrade rolls the Instances within a Virtual Machine Scale Set which are not
+// using the latest model in batches, checking the health of the Instances after each batch and aborting
+// the upgrade when too many Instances are unhealthy
+type virtualMachineScaleSetRollingUpgrade struct {
+	client  vmScaleSetVMsClient
+	options virtualMachineScaleSetRollingUpgradeOptions
+
+	// healthMonitoringEnabled specifies whether either a Health Probe or the Application Health Extension
+	// is configured, in which case the health state reported by the Instance View must be `healthy`
+	healthMonitoringEnabled bool
+
+	// healthCheckAttempts and healthCheckInterval control how long to wait for Instances which are
+	// still being provisioned or are initializing to report their health
+	healthCheckAttempts int
+	healthCheckInterval time.Duration
+
+	// sleep is overridden in the tests so that no time is spent waiting between batches
+	sleep func(ctx context.Context, duration time.Duration) error
+}
+
+func newVirtualMachineScaleSetRollingUpgrade(client vmScaleSetVMsClient, options virtualMachineScaleSetRollingUpgradeOptions, healthMonitoringEnabled bool) virtualMachineScaleSetRollingUpgrade {
+	return virtualMachineScaleSetRollingUpgrade{
+		client:                  client,
+		options:                 options,
+		healthMonitoringEnabled: healthMonitoringEnabled,
+		healthCheckAttempts:     20,
+		healthCheckInterval:     30 * time.Second,
+		sleep:                   sleepWithContext,
+	}
+}
+
+func (r virtualMachineScaleSetRollingUpgrade) run(ctx context.Context) error {
+	instances, err := r.client.ListInstances(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Determining instances to roll..")
+	allInstanceIds := make([]string, 0)
+	instanceIdsToRoll := make([]string, 0)
+	for _, instance := range instances {
+		if instance.InstanceID == nil {
+			continue
+		}
+		allInstanceIds = append(allInstanceIds, *instance.InstanceID)
+
+		props := instance.VirtualMachineScaleSetVMProperties
+		if props == nil {
+			continue
+		}
+		if latestModel := props.LatestModelApplied; latestModel == nil || !*latestModel {
+			instanceIdsToRoll = append(instanceIdsToRoll, *instance.InstanceID)
+		}
+	}
+
+	if len(instanceIdsToRoll) == 0 {
+		log.Printf("[DEBUG] All Instances are using the Latest Configuration - nothing to roll")
+		return nil
+	}
+
+	// Instances which are already unhealthy count towards the unhealthy limit, so check before rolling anything
+	if err := r.checkHealth(ctx, allInstanceIds, []string{}); err != nil {
+		return fmt.Errorf("aborting the rolling upgrade before the first batch: %+v", err)
+	}
+
+	batches := virtualMachineScaleSetRollingUpgradeBatches(instanceIdsToRoll, len(allInstanceIds), r.options.MaxBatchInstancePercent)
+	upgradedInstanceIds := make([]string, 0)
+	for i, batch := range batches {
+		log.Printf("[DEBUG] Rolling batch %d of %d (Instances %s)..", i+1, len(batches), strings.Join(batch, ", "))
+		if err := r.client.UpgradeInstances(ctx, batch); err != nil {
+			return err
+		}
+		upgradedInstanceIds = append(upgradedInstanceIds, batch...)
+
+		if err := r.checkHealth(ctx, allInstanceIds, upgradedInstanceIds); err != nil {
+			return fmt.Errorf("aborting the rolling upgrade after batch %d of %d (%d of %d Instances upgraded): %+v", i+1, len(batches), len(upgradedInstanceIds), len(instanceIdsToRoll), err)
+		}
+		log.Printf("[DEBUG] Rolled batch %d of %d.", i+1, len(batches))
+
+		if i < len(batches)-1 && r.options.PauseTimeBetweenBatches > 0 {
+			log.Printf("[DEBUG] Pausing for %s before rolling the next batch..", r.options.PauseTimeBetweenBatches)
+			if err := r.sleep(ctx, r.options.PauseTimeBetweenBatches); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkHealth returns an error when the percentage of unhealthy Instances (either across the Scale Set or
+// of the Instances upgraded so far) exceeds the limits defined in the `rolling_upgrade_policy` block
+func (r virtualMachineScaleSetRollingUpgrade) checkHealth(ctx context.Context, allInstanceIds []string, upgradedInstanceIds []string) error {
+	if r.options.MaxUnhealthyInstancePercent >= 100 && r.options.MaxUnhealthyUpgradedInstancePercent >= 100 {
+		return nil
+	}
+
+	var unhealthy map[string]string
+	for attempt := 1; ; attempt++ {
+		unhealthy = make(map[string]string)
+		pending := false
+		for _, instanceId := range allInstanceIds {
+			view, err := r.client.GetInstanceView(ctx, instanceId)
+			if err != nil {
+				return err
+			}
+
+			health, reason := virtualMachineScaleSetInstanceHealthFromView(view, r.healthMonitoringEnabled)
+			switch health {
+			case virtualMachineScaleSetInstancePending:
+				pending = true
+				unhealthy[instanceId] = reason
+			case virtualMachineScaleSetInstanceUnhealthy:
+				unhealthy[instanceId] = reason
+			}
+		}
+
+		if !pending || attempt >= r.healthCheckAttempts {
+			break
+		}
+
+		log.Printf("[DEBUG] Waiting for Instances to finish provisioning before checking their health (attempt %d of %d)..", attempt, r.healthCheckAttempts)
+		if err := r.sleep(ctx, r.healthCheckInterval); err != nil {
+			return err
+		}
+	}
+
+	if exceedsPercentage(len(unhealthy), len(allInstanceIds), r.options.MaxUnhealthyInstancePercent) {
+		return fmt.Errorf("%d of %d Instances are unhealthy which exceeds `max_unhealthy_instance_percent` (%d%%): %s", len(unhealthy), len(allInstanceIds), r.options.MaxUnhealthyInstancePercent, describeUnhealthyInstances(unhealthy))
+	}
+
+	unhealthyUpgraded := make(map[string]string)
+	for _, instanceId := range upgradedInstanceIds {
+		if reason, ok := unhealthy[instanceId]; ok {
+			unhealthyUpgraded[instanceId] = reason
+		}
+	}
+	if exceedsPercentage(len(unhealthyUpgraded), len(upgradedInstanceIds), r.options.MaxUnhealthyUpgradedInstancePercent) {
+		return fmt.Errorf("%d of %d upgraded Instances are unhealthy which exceeds `max_unhealthy_upgraded_instance_percent` (%d%%): %s", len(unhealthyUpgraded), len(upgradedInstanceIds), r.options.MaxUnhealthyUpgradedInstancePercent, describeUnhealthyInstances(unhealthyUpgraded))
+	}
+
+	return nil
+}
+
+// virtualMachineScaleSetRollingUpgradeBatches splits the Instances to roll into batches, where each batch contains
+// at most `maxBatchInstancePercent` percent of the total number of Instances (rounded up, with at least one Instance)
+func virtualMachineScaleSetRollingUpgradeBatches(instanceIds []string, totalInstances int, maxBatchInstancePercent int) [][]string {
+	batchSize := 1
+	if maxBatchInstancePercent > 0 {
+		batchSize = (totalInstances*maxBatchInstancePercent + 99) / 100
+		if batchSize < 1 {
+			batchSize = 1
+		}
+	}
+
+	batches := make([][]string, 0)
+	for start := 0; start < len(instanceIds); start += batchSize {
+		end := start + batchSize
+		if end > len(instanceIds) {
+			end = len(instanceIds)
+		}
+		batches = append(batches, instanceIds[start:end])
+	}
+
+	return batches
+}
+
+// virtualMachineScaleSetInstanceHealthFromView determines the health of an Instance from its Instance View,
+// returning the status code which caused the Instance to be considered unhealthy (or pending)
+func virtualMachineScaleSetInstanceHealthFromView(input compute.VirtualMachineScaleSetVMInstanceView, healthMonitoringEnabled bool) (virtualMachineScaleSetInstanceHealth, string) {
+	if input.Statuses != nil {
+		for _, status := range *input.Statuses {
+			if status.Code == nil {
+				continue
+			}
+
+			code := strings.ToLower(*status.Code)
+			if !strings.HasPrefix(code, "provisioningstate/") {
+				continue
+			}
+
+			state := strings.Split(strings.TrimPrefix(code, "provisioningstate/"), "/")[0]
+			switch state {
+			case "succeeded":
+				continue
+			case "creating", "updating":
+				return virtualMachineScaleSetInstancePending, *status.Code
+			default:
+				return virtualMachineScaleSetInstanceUnhealthy, *status.Code
+			}
+		}
+	}
+
+	if !healthMonitoringEnabled {
+		return virtualMachineScaleSetInstanceHealthy, ""
+	}
+
+	if input.VMHealth == nil || input.VMHealth.Status == nil || input.VMHealth.Status.Code == nil {
+		return virtualMachineScaleSetInstancePending, "HealthState/unknown"
+	}
+
+	code := *input.VMHealth.Status.Code
+	switch strings.ToLower(code) {
+	case "healthstate/healthy":
+		return virtualMachineScaleSetInstanceHealthy, ""
+	case "healthstate/initializing":
+		return virtualMachineScaleSetInstancePending, code
+	}
+
+	return virtualMachineScaleSetInstanceUnhealthy, code
+}
+
+func exceedsPercentage(count int, total int, maxPercent int) bool {
+	if total == 0 {
+		return false
+	}
+
+	return count*100 > total*maxPercent
+}
+
+func describeUnhealthyInstances(input map[string]string) string {
+	output := make([]string, 0, len(input))
+	for instanceId, reason := range input {
+		output = append(output, fmt.Sprintf("Instance %q (%s)", instanceId, reason))
+	}
+	sort.Strings(output)
+
+	return strings.Join(output, ", ")
+}
+
+func sleepWithContext(ctx context.Context, duration time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context was cancelled while waiting: %+v", ctx.Err())
+	case <-time.After(duration):
+		return nil
+	}
+}
@@ -0,0 +1,443 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+type fakeVMScaleSetVMsClient struct {
+	instances []compute.VirtualMachineScaleSetVM
+
+	// healthStates contains the health state codes returned for each Instance, once an Instance has
+	// been upgraded `upgradedHealthStates` is used instead (where specified)
+	healthStates         map[string]string
+	upgradedHealthStates map[string][]string
+
+	upgraded        map[string]int
+	upgradedBatches [][]string
+}
+
+func newFakeVMScaleSetVMsClient(count int, latestModel map[string]bool) *fakeVMScaleSetVMsClient {
+	client := &fakeVMScaleSetVMsClient{
+		healthStates:         map[string]string{},
+		upgradedHealthStates: map[string][]string{},
+		upgraded:             map[string]int{},
+		upgradedBatches:      [][]string{},
+	}
+	for i := 0; i < count; i++ {
+		instanceId := fmt.Sprintf("%d", i)
+		client.instances = append(client.instances, compute.VirtualMachineScaleSetVM{
+			InstanceID: utils.String(instanceId),
+			VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+				LatestModelApplied: utils.Bool(latestModel[instanceId]),
+			},
+		})
+		client.healthStates[instanceId] = "HealthState/healthy"
+	}
+	return client
+}
+
+func (c *fakeVMScaleSetVMsClient) ListInstances(_ context.Context) ([]compute.VirtualMachineScaleSetVM, error) {
+	return c.instances, nil
+}
+
+func (c *fakeVMScaleSetVMsClient) UpgradeInstances(_ context.Context, instanceIds []string) error {
+	c.upgradedBatches = append(c.upgradedBatches, append([]string{}, instanceIds...))
+	for _, instanceId := range instanceIds {
+		c.upgraded[instanceId] = 0
+	}
+	return nil
+}
+
+func (c *fakeVMScaleSetVMsClient) GetInstanceView(_ context.Context, instanceId string) (compute.VirtualMachineScaleSetVMInstanceView, error) {
+	code := c.healthStates[instanceId]
+	if calls, upgraded := c.upgraded[instanceId]; upgraded {
+		if states, ok := c.upgradedHealthStates[instanceId]; ok && len(states) > 0 {
+			// each subsequent call returns the next state, with the last state being returned thereafter
+			index := calls
+			if index >= len(states) {
+				index = len(states) - 1
+			}
+			code = states[index]
+			c.upgraded[instanceId] = calls + 1
+		}
+	}
+
+	return compute.VirtualMachineScaleSetVMInstanceView{
+		Statuses: &[]compute.InstanceViewStatus{
+			{
+				Code: utils.String("ProvisioningState/succeeded"),
+			},
+		},
+		VMHealth: &compute.VirtualMachineHealthStatus{
+			Status: &compute.InstanceViewStatus{
+				Code: utils.String(code),
+			},
+		},
+	}, nil
+}
+
+type fakeSleeper struct {
+	durations []time.Duration
+}
+
+func (s *fakeSleeper) sleep(_ context.Context, duration time.Duration) error {
+	s.durations = append(s.durations, duration)
+	return nil
+}
+
+func newTestVirtualMachineScaleSetRollingUpgrade(client vmScaleSetVMsClient, options virtualMachineScaleSetRollingUpgradeOptions, sleeper *fakeSleeper) virtualMachineScaleSetRollingUpgrade {
+	upgrade := newVirtualMachineScaleSetRollingUpgrade(client, options, true)
+	upgrade.healthCheckAttempts = 3
+	upgrade.sleep = sleeper.sleep
+	return upgrade
+}
+
+func TestVirtualMachineScaleSetRollingUpgradeBatches(t *testing.T) {
+	testData := []struct {
+		Name                    string
+		InstanceIds             []string
+		TotalInstances          int
+		MaxBatchInstancePercent int
+		Expected                [][]string
+	}{
+		{
+			Name:                    "no instances",
+			InstanceIds:             []string{},
+			TotalInstances:          3,
+			MaxBatchInstancePercent: 20,
+			Expected:                [][]string{},
+		},
+		{
+			Name:                    "one at a time",
+			InstanceIds:             []string{"0", "1", "2"},
+			TotalInstances:          3,
+			MaxBatchInstancePercent: 0,
+			Expected:                [][]string{{"0"}, {"1"}, {"2"}},
+		},
+		{
+			Name:                    "rounded up to a single instance",
+			InstanceIds:             []string{"0", "1", "2"},
+			TotalInstances:          3,
+			MaxBatchInstancePercent: 5,
+			Expected:                [][]string{{"0"}, {"1"}, {"2"}},
+		},
+		{
+			Name:                    "rounded up",
+			InstanceIds:             []string{"0", "1", "2", "3", "4", "5", "6"},
+			TotalInstances:          7,
+			MaxBatchInstancePercent: 20,
+			Expected:                [][]string{{"0", "1"}, {"2", "3"}, {"4", "5"}, {"6"}},
+		},
+		{
+			Name:                    "batch size is based on the total number of instances",
+			InstanceIds:             []string{"3", "4", "5"},
+			TotalInstances:          10,
+			MaxBatchInstancePercent: 20,
+			Expected:                [][]string{{"3", "4"}, {"5"}},
+		},
+		{
+			Name:                    "all at once",
+			InstanceIds:             []string{"0", "1", "2"},
+			TotalInstances:          3,
+			MaxBatchInstancePercent: 100,
+			Expected:                [][]string{{"0", "1", "2"}},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := virtualMachineScaleSetRollingUpgradeBatches(v.InstanceIds, v.TotalInstances, v.MaxBatchInstancePercent)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestVirtualMachineScaleSetRollingUpgrade_batchesAndPauses(t *testing.T) {
+	client := newFakeVMScaleSetVMsClient(10, map[string]bool{})
+	sleeper := &fakeSleeper{}
+	options := virtualMachineScaleSetRollingUpgradeOptions{
+		MaxBatchInstancePercent:             20,
+		MaxUnhealthyInstancePercent:         20,
+		MaxUnhealthyUpgradedInstancePercent: 20,
+		PauseTimeBetweenBatches:             5 * time.Minute,
+	}
+
+	if err := newTestVirtualMachineScaleSetRollingUpgrade(client, options, sleeper).run(context.TODO()); err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	expectedBatches := [][]string{{"0", "1"}, {"2", "3"}, {"4", "5"}, {"6", "7"}, {"8", "9"}}
+	if !reflect.DeepEqual(client.upgradedBatches, expectedBatches) {
+		t.Fatalf("Expected the batches %+v but got %+v", expectedBatches, client.upgradedBatches)
+	}
+
+	// there's no pause after the final batch
+	expectedPauses := []time.Duration{5 * time.Minute, 5 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	if !reflect.DeepEqual(sleeper.durations, expectedPauses) {
+		t.Fatalf("Expected the pauses %+v but got %+v", expectedPauses, sleeper.durations)
+	}
+}
+
+func TestVirtualMachineScaleSetRollingUpgrade_onlyOutdatedInstances(t *testing.T) {
+	client := newFakeVMScaleSetVMsClient(4, map[string]bool{
+		"0": true,
+		"2": true,
+	})
+	sleeper := &fakeSleeper{}
+
+	if err := newTestVirtualMachineScaleSetRollingUpgrade(client, defaultVirtualMachineScaleSetRollingUpgradeOptions(), sleeper).run(context.TODO()); err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	expectedBatches := [][]string{{"1"}, {"3"}}
+	if !reflect.DeepEqual(client.upgradedBatches, expectedBatches) {
+		t.Fatalf("Expected the batches %+v but got %+v", expectedBatches, client.upgradedBatches)
+	}
+
+	if len(sleeper.durations) != 0 {
+		t.Fatalf("Expected no pauses but got %+v", sleeper.durations)
+	}
+}
+
+func TestVirtualMachineScaleSetRollingUpgrade_abortsWhenTooManyInstancesAreUnhealthy(t *testing.T) {
+	client := newFakeVMScaleSetVMsClient(10, map[string]bool{})
+	client.upgradedHealthStates["2"] = []string{"HealthState/unhealthy"}
+	client.upgradedHealthStates["3"] = []string{"HealthState/unhealthy"}
+	client.upgradedHealthStates["4"] = []string{"HealthState/unhealthy"}
+	sleeper := &fakeSleeper{}
+	options := virtualMachineScaleSetRollingUpgradeOptions{
+		MaxBatchInstancePercent:             30,
+		MaxUnhealthyInstancePercent:         20,
+		MaxUnhealthyUpgradedInstancePercent: 100,
+	}
+
+	err := newTestVirtualMachineScaleSetRollingUpgrade(client, options, sleeper).run(context.TODO())
+	if err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+
+	for _, expected := range []string{"batch 2 of 4", "max_unhealthy_instance_percent", `Instance "2" (HealthState/unhealthy)`, `Instance "4" (HealthState/unhealthy)`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected the error to contain %q but got: %+v", expected, err)
+		}
+	}
+
+	// the remaining batches must not be rolled
+	expectedBatches := [][]string{{"0", "1", "2"}, {"3", "4", "5"}}
+	if !reflect.DeepEqual(client.upgradedBatches, expectedBatches) {
+		t.Fatalf("Expected the batches %+v but got %+v", expectedBatches, client.upgradedBatches)
+	}
+}
+
+func TestVirtualMachineScaleSetRollingUpgrade_abortsWhenTooManyUpgradedInstancesAreUnhealthy(t *testing.T) {
+	client := newFakeVMScaleSetVMsClient(10, map[string]bool{})
+	client.upgradedHealthStates["0"] = []string{"HealthState/unhealthy"}
+	sleeper := &fakeSleeper{}
+	options := virtualMachineScaleSetRollingUpgradeOptions{
+		MaxBatchInstancePercent:             20,
+		MaxUnhealthyInstancePercent:         20,
+		MaxUnhealthyUpgradedInstancePercent: 20,
+	}
+
+	err := newTestVirtualMachineScaleSetRollingUpgrade(client, options, sleeper).run(context.TODO())
+	if err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+
+	if !strings.Contains(err.Error(), "max_unhealthy_upgraded_instance_percent") {
+		t.Fatalf("Expected the error to reference `max_unhealthy_upgraded_instance_percent` but got: %+v", err)
+	}
+
+	if len(client.upgradedBatches) != 1 {
+		t.Fatalf("Expected 1 batch to be rolled but got %d", len(client.upgradedBatches))
+	}
+}
+
+func TestVirtualMachineScaleSetRollingUpgrade_abortsWhenAlreadyUnhealthy(t *testing.T) {
+	client := newFakeVMScaleSetVMsClient(4, map[string]bool{})
+	client.healthStates["1"] = "HealthState/unhealthy"
+	client.healthStates["2"] = "HealthState/unhealthy"
+	sleeper := &fakeSleeper{}
+	options := virtualMachineScaleSetRollingUpgradeOptions{
+		MaxBatchInstancePercent:             25,
+		MaxUnhealthyInstancePercent:         25,
+		MaxUnhealthyUpgradedInstancePercent: 25,
+	}
+
+	err := newTestVirtualMachineScaleSetRollingUpgrade(client, options, sleeper).run(context.TODO())
+	if err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+
+	if !strings.Contains(err.Error(), "before the first batch") {
+		t.Fatalf("Expected the error to reference the first batch but got: %+v", err)
+	}
+
+	if len(client.upgradedBatches) != 0 {
+		t.Fatalf("Expected no batches to be rolled but got %+v", client.upgradedBatches)
+	}
+}
+
+func TestVirtualMachineScaleSetRollingUpgrade_waitsForInitializingInstances(t *testing.T) {
+	client := newFakeVMScaleSetVMsClient(2, map[string]bool{})
+	client.upgradedHealthStates["0"] = []string{"HealthState/initializing", "HealthState/healthy"}
+	sleeper := &fakeSleeper{}
+	options := virtualMachineScaleSetRollingUpgradeOptions{
+		MaxBatchInstancePercent:             50,
+		MaxUnhealthyInstancePercent:         20,
+		MaxUnhealthyUpgradedInstancePercent: 20,
+	}
+
+	upgrade := newTestVirtualMachineScaleSetRollingUpgrade(client, options, sleeper)
+	if err := upgrade.run(context.TODO()); err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	expectedPauses := []time.Duration{upgrade.healthCheckInterval}
+	if !reflect.DeepEqual(sleeper.durations, expectedPauses) {
+		t.Fatalf("Expected the pauses %+v but got %+v", expectedPauses, sleeper.durations)
+	}
+}
+
+func TestVirtualMachineScaleSetRollingUpgrade_initializingInstancesEventuallyUnhealthy(t *testing.T) {
+	client := newFakeVMScaleSetVMsClient(2, map[string]bool{})
+	client.upgradedHealthStates["0"] = []string{"HealthState/initializing"}
+	sleeper := &fakeSleeper{}
+	options := virtualMachineScaleSetRollingUpgradeOptions{
+		MaxBatchInstancePercent:             50,
+		MaxUnhealthyInstancePercent:         20,
+		MaxUnhealthyUpgradedInstancePercent: 20,
+	}
+
+	upgrade := newTestVirtualMachineScaleSetRollingUpgrade(client, options, sleeper)
+	err := upgrade.run(context.TODO())
+	if err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+
+	if !strings.Contains(err.Error(), `Instance "0" (HealthState/initializing)`) {
+		t.Fatalf("Expected the error to reference the initializing Instance but got: %+v", err)
+	}
+
+	if len(sleeper.durations) != upgrade.healthCheckAttempts-1 {
+		t.Fatalf("Expected %d health check pauses but got %d", upgrade.healthCheckAttempts-1, len(sleeper.durations))
+	}
+}
+
+func TestVirtualMachineScaleSetInstanceHealthFromView(t *testing.T) {
+	testData := []struct {
+		Name                    string
+		ProvisioningState       string
+		HealthState             string
+		HealthMonitoringEnabled bool
+		Expected                virtualMachineScaleSetInstanceHealth
+	}{
+		{
+			Name:              "provisioned without health monitoring",
+			ProvisioningState: "ProvisioningState/succeeded",
+			HealthState:       "HealthState/unhealthy",
+			Expected:          virtualMachineScaleSetInstanceHealthy,
+		},
+		{
+			Name:              "failed without health monitoring",
+			ProvisioningState: "ProvisioningState/failed/InternalOperationError",
+			Expected:          virtualMachineScaleSetInstanceUnhealthy,
+		},
+		{
+			Name:              "updating without health monitoring",
+			ProvisioningState: "ProvisioningState/updating",
+			Expected:          virtualMachineScaleSetInstancePending,
+		},
+		{
+			Name:                    "healthy",
+			ProvisioningState:       "ProvisioningState/succeeded",
+			HealthState:             "HealthState/healthy",
+			HealthMonitoringEnabled: true,
+			Expected:                virtualMachineScaleSetInstanceHealthy,
+		},
+		{
+			Name:                    "unhealthy",
+			ProvisioningState:       "ProvisioningState/succeeded",
+			HealthState:             "HealthState/unhealthy",
+			HealthMonitoringEnabled: true,
+			Expected:                virtualMachineScaleSetInstanceUnhealthy,
+		},
+		{
+			Name:                    "initializing",
+			ProvisioningState:       "ProvisioningState/succeeded",
+			HealthState:             "HealthState/initializing",
+			HealthMonitoringEnabled: true,
+			Expected:                virtualMachineScaleSetInstancePending,
+		},
+		{
+			Name:                    "no health state reported",
+			ProvisioningState:       "ProvisioningState/succeeded",
+			HealthMonitoringEnabled: true,
+			Expected:                virtualMachineScaleSetInstancePending,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		input := compute.VirtualMachineScaleSetVMInstanceView{
+			Statuses: &[]compute.InstanceViewStatus{
+				{
+					Code: utils.String(v.ProvisioningState),
+				},
+			},
+		}
+		if v.HealthState != "" {
+			input.VMHealth = &compute.VirtualMachineHealthStatus{
+				Status: &compute.InstanceViewStatus{
+					Code: utils.String(v.HealthState),
+				},
+			}
+		}
+
+		actual, _ := virtualMachineScaleSetInstanceHealthFromView(input, v.HealthMonitoringEnabled)
+		if actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestParseVirtualMachineScaleSetRollingUpgradeOptions(t *testing.T) {
+	actual, err := parseVirtualMachineScaleSetRollingUpgradeOptions(&compute.RollingUpgradePolicy{
+		MaxBatchInstancePercent:             utils.Int32(25),
+		MaxUnhealthyInstancePercent:         utils.Int32(30),
+		MaxUnhealthyUpgradedInstancePercent: utils.Int32(35),
+		PauseTimeBetweenBatches:             utils.String("PT1M30S"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	expected := virtualMachineScaleSetRollingUpgradeOptions{
+		MaxBatchInstancePercent:             25,
+		MaxUnhealthyInstancePercent:         30,
+		MaxUnhealthyUpgradedInstancePercent: 35,
+		PauseTimeBetweenBatches:             90 * time.Second,
+	}
+	if *actual != expected {
+		t.Fatalf("Expected %+v but got %+v", expected, *actual)
+	}
+
+	actual, err = parseVirtualMachineScaleSetRollingUpgradeOptions(nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if *actual != defaultVirtualMachineScaleSetRollingUpgradeOptions() {
+		t.Fatalf("Expected the default options but got %+v", *actual)
+	}
+}
@@ -22,6 +22,12 @@ type virtualMachineScaleSetUpdateMetaData struct {
 	// do we need to roll the instances in this scale set?
 	UpdateInstances bool
 
+	// is either a health probe or the application health extension configured?
+	HealthMonitoringEnabled bool
+
+	// the `rolling_upgrade_policy` used to batch the instances when rolling them using the Manual upgrade mode
+	RollingUpgradePolicy *compute.RollingUpgradePolicy
+
 	Client   *client.Client
 	Existing compute.VirtualMachineScaleSet
 	ID       *parse.VirtualMachineScaleSetId
@@ -122,65 +128,21 @@ func (metadata virtualMachineScaleSetUpdateMetaData) upgradeInstancesForAutomati
 }
 
 func (metadata virtualMachineScaleSetUpdateMetaData) upgradeInstancesForManualUpgradePolicy(ctx context.Context) error {
-	client := metadata.Client.VMScaleSetClient
 	id := metadata.ID
 
-	log.Printf("[DEBUG] Rolling the VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q)..", metadata.OSType, id.Name, id.ResourceGroup)
-	instancesClient := metadata.Client.VMScaleSetVMsClient
-	instances, err := instancesClient.ListComplete(ctx, id.ResourceGroup, id.Name, "", "", "")
+	options, err := parseVirtualMachineScaleSetRollingUpgradeOptions(metadata.RollingUpgradePolicy)
 	if err != nil {
-		return fmt.Errorf("listing VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+		return err
 	}
 
-	log.Printf("[DEBUG] Determining instances to roll..")
-	instanceIdsToRoll := make([]string, 0)
-	for instances.NotDone() {
-		instance := instances.Value()
-		props := instance.VirtualMachineScaleSetVMProperties
-		if props != nil && instance.InstanceID != nil {
-			latestModel := props.LatestModelApplied
-			if latestModel != nil || !*latestModel {
-				instanceIdsToRoll = append(instanceIdsToRoll, *instance.InstanceID)
-			}
-		}
-
-		if err := instances.NextWithContext(ctx); err != nil {
-			return fmt.Errorf("enumerating instances: %s", err)
-		}
+	log.Printf("[DEBUG] Rolling the VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q)..", metadata.OSType, id.Name, id.ResourceGroup)
+	instancesClient := sdkVMScaleSetVMsClient{
+		client: metadata.Client,
+		id:     *id,
 	}
-
-	// TODO: there's a performance enhancement to do batches here, but this is fine for a first pass
-	for _, instanceId := range instanceIdsToRoll {
-		instanceIds := []string{instanceId}
-
-		log.Printf("[DEBUG] Updating Instance %q to the Latest Configuration..", instanceId)
-		ids := compute.VirtualMachineScaleSetVMInstanceRequiredIDs{
-			InstanceIds: &instanceIds,
-		}
-		future, err := client.UpdateInstances(ctx, id.ResourceGroup, id.Name, ids)
-		if err != nil {
-			return fmt.Errorf("updating Instance %q (%s VM Scale Set %q / Resource Group %q) to the Latest Configuration: %+v", instanceId, metadata.OSType, id.Name, id.ResourceGroup, err)
-		}
-
-		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
-			return fmt.Errorf("waiting for update of Instance %q (%s VM Scale Set %q / Resource Group %q) to the Latest Configuration: %+v", instanceId, metadata.OSType, id.Name, id.ResourceGroup, err)
-		}
-		log.Printf("[DEBUG] Updated Instance %q to the Latest Configuration.", instanceId)
-
-		// TODO: does this want to be a separate, user-configurable toggle?
-		log.Printf("[DEBUG] Reimaging Instance %q..", instanceId)
-		reimageInput := &compute.VirtualMachineScaleSetReimageParameters{
-			InstanceIds: &instanceIds,
-		}
-		reimageFuture, err := client.Reimage(ctx, id.ResourceGroup, id.Name, reimageInput)
-		if err != nil {
-			return fmt.Errorf("reimaging Instance %q (%s VM Scale Set %q / Resource Group %q): %+v", instanceId, metadata.OSType, id.Name, id.ResourceGroup, err)
-		}
-
-		if err = reimageFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
-			return fmt.Errorf("waiting for reimage of Instance %q (%s VM Scale Set %q / Resource Group %q): %+v", instanceId, metadata.OSType, id.Name, id.ResourceGroup, err)
-		}
-		log.Printf("[DEBUG] Reimaged Instance %q..", instanceId)
+	upgrade := newVirtualMachineScaleSetRollingUpgrade(instancesClient, *options, metadata.HealthMonitoringEnabled)
+	if err := upgrade.run(ctx); err != nil {
+		return fmt.Errorf("rolling the VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
 	}
 
 	log.Printf("[DEBUG] Rolled the VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q).", metadata.OSType, id.Name, id.ResourceGroup)
@@ -0,0 +1,181 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func virtualMachineSizesDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: virtualMachineSizesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"location": commonschema.Location(),
+
+			"name_prefix": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"minimum_cores": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"minimum_memory_in_mb": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"sizes": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"number_of_cores": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"memory_in_mb": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"max_data_disk_count": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"os_disk_size_in_mb": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"resource_disk_size_in_mb": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func virtualMachineSizesDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMSizesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	loc := location.Normalize(d.Get("location").(string))
+
+	resp, err := client.List(ctx, loc)
+	if err != nil {
+		return fmt.Errorf("listing Virtual Machine Sizes (Location %q): %+v", loc, err)
+	}
+
+	namePrefix := d.Get("name_prefix").(string)
+	minimumCores := d.Get("minimum_cores").(int)
+	minimumMemory := d.Get("minimum_memory_in_mb").(int)
+
+	filtered := make([]compute.VirtualMachineSize, 0)
+	if resp.Value != nil {
+		for _, size := range *resp.Value {
+			if size.Name == nil {
+				continue
+			}
+
+			if namePrefix != "" && !strings.HasPrefix(strings.ToLower(*size.Name), strings.ToLower(namePrefix)) {
+				continue
+			}
+
+			if minimumCores > 0 && (size.NumberOfCores == nil || int(*size.NumberOfCores) < minimumCores) {
+				continue
+			}
+
+			if minimumMemory > 0 && (size.MemoryInMB == nil || int(*size.MemoryInMB) < minimumMemory) {
+				continue
+			}
+
+			filtered = append(filtered, size)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	d.Set("location", loc)
+
+	if err := d.Set("sizes", flattenVirtualMachineSizes(filtered)); err != nil {
+		return fmt.Errorf("setting `sizes`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenVirtualMachineSizes(input []compute.VirtualMachineSize) []interface{} {
+	results := make([]interface{}, 0)
+
+	for _, size := range input {
+		name := ""
+		if size.Name != nil {
+			name = *size.Name
+		}
+
+		numberOfCores := 0
+		if size.NumberOfCores != nil {
+			numberOfCores = int(*size.NumberOfCores)
+		}
+
+		memoryInMB := 0
+		if size.MemoryInMB != nil {
+			memoryInMB = int(*size.MemoryInMB)
+		}
+
+		maxDataDiskCount := 0
+		if size.MaxDataDiskCount != nil {
+			maxDataDiskCount = int(*size.MaxDataDiskCount)
+		}
+
+		osDiskSizeInMB := 0
+		if size.OsDiskSizeInMB != nil {
+			osDiskSizeInMB = int(*size.OsDiskSizeInMB)
+		}
+
+		resourceDiskSizeInMB := 0
+		if size.ResourceDiskSizeInMB != nil {
+			resourceDiskSizeInMB = int(*size.ResourceDiskSizeInMB)
+		}
+
+		results = append(results, map[string]interface{}{
+			"name":                     name,
+			"number_of_cores":          numberOfCores,
+			"memory_in_mb":             memoryInMB,
+			"max_data_disk_count":      maxDataDiskCount,
+			"os_disk_size_in_mb":       osDiskSizeInMB,
+			"resource_disk_size_in_mb": resourceDiskSizeInMB,
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,70 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type VirtualMachineSizesDataSource struct{}
+
+func TestAccVirtualMachineSizesDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_sizes", "test")
+	r := VirtualMachineSizesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("sizes.#").Exists(),
+				check.That(data.ResourceName).Key("sizes.0.name").Exists(),
+				check.That(data.ResourceName).Key("sizes.0.number_of_cores").Exists(),
+				check.That(data.ResourceName).Key("sizes.0.memory_in_mb").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccVirtualMachineSizesDataSource_filtered(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_sizes", "test")
+	r := VirtualMachineSizesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.filtered(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("sizes.#").Exists(),
+				check.That(data.ResourceName).Key("sizes.0.name").Exists(),
+			),
+		},
+	})
+}
+
+func (VirtualMachineSizesDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+data "azurestack_virtual_machine_sizes" "test" {
+  location = "%s"
+}
+`, data.Locations.Primary)
+}
+
+func (VirtualMachineSizesDataSource) filtered(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+data "azurestack_virtual_machine_sizes" "test" {
+  location             = "%s"
+  name_prefix          = "Standard_F"
+  minimum_cores        = 2
+  minimum_memory_in_mb = 2048
+}
+`, data.Locations.Primary)
+}
@@ -133,6 +133,17 @@ func windowsVirtualMachine() *pluginsdk.Resource {
 
 			"custom_data": base64.OptionalSchema(true),
 
+			"data_disk": virtualMachineDataDiskSchema(),
+
+			"dedicated_host_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: computeValidate.DedicatedHostID,
+				// the Compute API returns the Resource Group name in UPPERCASE
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+
 			"enable_automatic_updates": {
 				Type:     pluginsdk.TypeBool,
 				Optional: true,
@@ -220,6 +231,15 @@ func windowsVirtualMachine() *pluginsdk.Resource {
 				ForceNew: true,
 			},
 
+			"proximity_placement_group_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: computeValidate.ProximityPlacementGroupID,
+				// the Compute API returns the Resource Group name in UPPERCASE
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+
 			"secret": windowsSecretSchema(),
 
 			"source_image_id": {
@@ -400,8 +420,9 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 				ImageReference: sourceImageReference,
 				OsDisk:         osDisk,
 
-				// Data Disks are instead handled via the Association resource - as such we can send an empty value here
-				// but for Updates this'll need to be nil, else any associations will be overwritten
+				// Data Disks are either defined using the `data_disk` block (which replaces this below) or are handled via
+				// the Association resource - as such we can send an empty value here, but for Updates this'll need to be nil
+				// unless the `data_disk` block is used, else any associations will be overwritten
 				DataDisks: &[]compute.DataDisk{},
 			},
 
@@ -434,6 +455,18 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 		}
 	}
 
+	if v, ok := d.GetOk("dedicated_host_id"); ok {
+		params.Host = &compute.SubResource{
+			ID: utils.String(v.(string)),
+		}
+	}
+
+	if v, ok := d.GetOk("proximity_placement_group_id"); ok {
+		params.ProximityPlacementGroup = &compute.SubResource{
+			ID: utils.String(v.(string)),
+		}
+	}
+
 	if v, ok := d.GetOk("custom_data"); ok {
 		params.OsProfile.CustomData = utils.String(v.(string))
 	}
@@ -479,6 +512,14 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 		params.VirtualMachineProperties.OsProfile.WindowsConfiguration.TimeZone = utils.String(v.(string))
 	}
 
+	if v, ok := d.GetOk("data_disk"); ok {
+		dataDisks, err := expandVirtualMachineDataDisks(ctx, meta.(*clients.Client).Compute.DisksClient, v.([]interface{}))
+		if err != nil {
+			return fmt.Errorf("expanding `data_disk`: %+v", err)
+		}
+		params.VirtualMachineProperties.StorageProfile.DataDisks = dataDisks
+	}
+
 	if v, ok := d.GetOk("zone"); ok {
 		params.Zones = &[]string{
 			v.(string),
@@ -547,6 +588,18 @@ func resourceWindowsVirtualMachineRead(d *pluginsdk.ResourceData, meta interface
 	}
 	d.Set("availability_set_id", availabilitySetId)
 
+	dedicatedHostId := ""
+	if props.Host != nil && props.Host.ID != nil {
+		dedicatedHostId = *props.Host.ID
+	}
+	d.Set("dedicated_host_id", dedicatedHostId)
+
+	proximityPlacementGroupId := ""
+	if props.ProximityPlacementGroup != nil && props.ProximityPlacementGroup.ID != nil {
+		proximityPlacementGroupId = *props.ProximityPlacementGroup.ID
+	}
+	d.Set("proximity_placement_group_id", proximityPlacementGroupId)
+
 	if err := d.Set("boot_diagnostics", flattenBootDiagnostics(props.DiagnosticsProfile)); err != nil {
 		return fmt.Errorf("setting `boot_diagnostics`: %+v", err)
 	}
@@ -635,6 +688,14 @@ func resourceWindowsVirtualMachineRead(d *pluginsdk.ResourceData, meta interface
 		}
 		d.Set("source_image_id", storageImageId)
 
+		// Data Disks are only managed here when the `data_disk` block is used, since otherwise these are managed
+		// using the `azurestack_virtual_machine_data_disk_attachment` resource
+		if existingDataDisks := d.Get("data_disk").([]interface{}); len(existingDataDisks) > 0 {
+			if err := d.Set("data_disk", flattenVirtualMachineDataDisks(profile.DataDisks, existingDataDisks)); err != nil {
+				return fmt.Errorf("setting `data_disk`: %+v", err)
+			}
+		}
+
 		if err := d.Set("source_image_reference", flattenSourceImageReference(profile.ImageReference)); err != nil {
 			return fmt.Errorf("setting `source_image_reference`: %+v", err)
 		}
@@ -816,6 +877,41 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		}
 	}
 
+	// the Data Disks created using a `data_disk` block which has been removed are deleted once they've been detached
+	removedDataDiskIds := make([]string, 0)
+	if d.HasChange("data_disk") {
+		shouldUpdate = true
+
+		oldDataDisks, newDataDisks := d.GetChange("data_disk")
+		var existingDataDisks *[]compute.DataDisk
+		if props := existing.VirtualMachineProperties; props != nil && props.StorageProfile != nil {
+			existingDataDisks = props.StorageProfile.DataDisks
+		}
+		if err := validateVirtualMachineDataDisksNotManagedExternally(existingDataDisks, oldDataDisks.([]interface{}), newDataDisks.([]interface{})); err != nil {
+			return err
+		}
+
+		// Code="Conflict" Message="Disk resizing is allowed only when creating a VM or when the VM is deallocated." Target="disk.diskSizeGB"
+		if virtualMachineDataDisksResized(oldDataDisks.([]interface{}), newDataDisks.([]interface{})) {
+			shouldShutDown = true
+			shouldDeallocate = true
+		}
+
+		dataDisks, err := expandVirtualMachineDataDisks(ctx, meta.(*clients.Client).Compute.DisksClient, virtualMachineDataDisksWithExistingIDs(existingDataDisks, newDataDisks.([]interface{})))
+		if err != nil {
+			return fmt.Errorf("expanding `data_disk`: %+v", err)
+		}
+
+		if meta.(*clients.Client).Features.VirtualMachine.DeleteDataDisksOnDeletion {
+			removedDataDiskIds = virtualMachineRemovedEmptyDataDiskIDs(oldDataDisks.([]interface{}), newDataDisks.([]interface{}))
+		}
+
+		if update.VirtualMachineProperties.StorageProfile == nil {
+			update.VirtualMachineProperties.StorageProfile = &compute.StorageProfile{}
+		}
+		update.VirtualMachineProperties.StorageProfile.DataDisks = dataDisks
+	}
+
 	if d.HasChange("size") {
 		shouldUpdate = true
 
@@ -859,7 +955,7 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		}
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		shouldUpdate = true
 
 		tagsRaw := d.Get("tags").(map[string]interface{})
@@ -1038,6 +1134,13 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		log.Printf("[DEBUG] Updated Windows Virtual Machine %q (Resource Group %q).", id.Name, id.ResourceGroup)
 	}
 
+	if len(removedDataDiskIds) > 0 {
+		log.Printf("[DEBUG] Deleting Data Disks removed from Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
+		if err := deleteVirtualMachineDataDisks(ctx, meta.(*clients.Client).Compute.DisksClient, removedDataDiskIds); err != nil {
+			return err
+		}
+	}
+
 	// if we've shut it down and it was turned off, let's boot it back up
 	if shouldTurnBackOn && shouldShutDown {
 		log.Printf("[DEBUG] Starting Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
@@ -1156,6 +1259,17 @@ func resourceWindowsVirtualMachineDelete(d *pluginsdk.ResourceData, meta interfa
 		log.Printf("[DEBUG] Skipping Deleting OS Disk from Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
 	}
 
+	if meta.(*clients.Client).Features.VirtualMachine.DeleteDataDisksOnDeletion {
+		// only the Data Disks created using the `data_disk` block are deleted, those which were attached are managed separately
+		log.Printf("[DEBUG] Deleting Data Disks from Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
+		dataDiskIds := virtualMachineRemovedEmptyDataDiskIDs(d.Get("data_disk").([]interface{}), nil)
+		if err := deleteVirtualMachineDataDisks(ctx, meta.(*clients.Client).Compute.DisksClient, dataDiskIds); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("[DEBUG] Skipping Deleting Data Disks from Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
+	}
+
 	// Need to add a get and a state wait to avoid bug in network API where the attached disk(s) are not actually deleted
 	// Service team indicated that we need to do a get after VM delete call returns to verify that the VM and all attached
 	// disks have actually been deleted.
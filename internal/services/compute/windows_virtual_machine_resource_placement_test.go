@@ -0,0 +1,124 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+func TestAccWindowsVirtualMachine_placementProximityPlacementGroup(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_windows_virtual_machine", "test")
+	r := WindowsVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.placementProximityPlacementGroup(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("proximity_placement_group_id").Exists(),
+			),
+		},
+		data.ImportStep("admin_password"),
+	})
+}
+
+func TestAccWindowsVirtualMachine_placementDedicatedHost(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_windows_virtual_machine", "test")
+	r := WindowsVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.placementDedicatedHost(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("dedicated_host_id").Exists(),
+			),
+		},
+		data.ImportStep("admin_password"),
+	})
+}
+
+func (r WindowsVirtualMachineResource) placementProximityPlacementGroup(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_proximity_placement_group" "test" {
+  name                = "acctestPPG-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_windows_virtual_machine" "test" {
+  name                         = local.vm_name
+  resource_group_name          = azurestack_resource_group.test.name
+  location                     = azurestack_resource_group.test.location
+  size                         = "Standard_F2"
+  admin_username               = "adminuser"
+  admin_password               = "P@$$w0rd1234!"
+  proximity_placement_group_id = azurestack_proximity_placement_group.test.id
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "MicrosoftWindowsServer"
+    offer     = "WindowsServer"
+    sku       = "2016-Datacenter"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r WindowsVirtualMachineResource) placementDedicatedHost(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_dedicated_host_group" "test" {
+  name                        = "acctestDHG-%[2]d"
+  resource_group_name         = azurestack_resource_group.test.name
+  location                    = azurestack_resource_group.test.location
+  platform_fault_domain_count = 2
+}
+
+resource "azurestack_dedicated_host" "test" {
+  name                    = "acctestDH-%[2]d"
+  location                = azurestack_resource_group.test.location
+  dedicated_host_group_id = azurestack_dedicated_host_group.test.id
+  sku_name                = "DSv3-Type1"
+  platform_fault_domain   = 1
+}
+
+resource "azurestack_windows_virtual_machine" "test" {
+  name                = local.vm_name
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  size                = "Standard_D2s_v3"
+  admin_username      = "adminuser"
+  admin_password      = "P@$$w0rd1234!"
+  dedicated_host_id   = azurestack_dedicated_host.test.id
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "MicrosoftWindowsServer"
+    offer     = "WindowsServer"
+    sku       = "2016-Datacenter"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
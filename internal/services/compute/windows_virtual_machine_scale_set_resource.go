@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/base64"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/suppress"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
@@ -134,6 +135,8 @@ func resourceWindowsVirtualMachineScaleSet() *pluginsdk.Resource {
 
 			"extension": VirtualMachineScaleSetExtensionsSchema(),
 
+			"extension_instance_view_summary": virtualMachineScaleSetExtensionsInstanceViewSummarySchema(),
+
 			// TODO: Uncomment extensions_time_budget when it's fixed
 			/*"extensions_time_budget": {
 				Type:         pluginsdk.TypeString,
@@ -187,6 +190,17 @@ func resourceWindowsVirtualMachineScaleSet() *pluginsdk.Resource {
 				ForceNew: true,
 			},
 
+			"proximity_placement_group_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: computeValidate.ProximityPlacementGroupID,
+				// the Compute API returns the Resource Group name in UPPERCASE
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+
+			"rolling_upgrade_policy": VirtualMachineScaleSetRollingUpgradePolicySchema(),
+
 			"secret": windowsSecretSchema(),
 
 			"single_placement_group": {
@@ -342,6 +356,11 @@ func resourceWindowsVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta
 		Mode:                     upgradeMode,
 		AutomaticOSUpgradePolicy: automaticOSUpgradePolicy,
 	}
+	// the Manual upgrade mode doesn't support a Rolling Upgrade Policy, instead this is used to batch the Instances
+	// when they're rolled by the provider during an update
+	if upgradeMode != compute.UpgradeModeManual {
+		upgradePolicy.RollingUpgradePolicy = ExpandVirtualMachineScaleSetRollingUpgradePolicy(d.Get("rolling_upgrade_policy").([]interface{}))
+	}
 
 	virtualMachineProfile := compute.VirtualMachineScaleSetVMProfile{
 		OsProfile: &compute.VirtualMachineScaleSetOSProfile{
@@ -440,6 +459,12 @@ func resourceWindowsVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta
 		props.VirtualMachineScaleSetProperties.PlatformFaultDomainCount = utils.Int32(int32(v.(int)))
 	}
 
+	if v, ok := d.GetOk("proximity_placement_group_id"); ok {
+		props.VirtualMachineScaleSetProperties.ProximityPlacementGroup = &compute.SubResource{
+			ID: utils.String(v.(string)),
+		}
+	}
+
 	log.Printf("[DEBUG] Creating Windows %s.", id)
 	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, props)
 	if err != nil {
@@ -454,6 +479,16 @@ func resourceWindowsVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta
 
 	d.SetId(id.ID())
 
+	if extensionNames := virtualMachineScaleSetExtensionNames(d.Get("extension").(*pluginsdk.Set).List()); len(extensionNames) > 0 {
+		summary, err := retrieveVirtualMachineScaleSetExtensionsSummary(ctx, client, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVirtualMachineScaleSetExtensionsProvisioned(summary, extensionNames); err != nil {
+			return fmt.Errorf("provisioning Extensions for Windows %s: %+v", id, err)
+		}
+	}
+
 	return resourceWindowsVirtualMachineScaleSetRead(d, meta)
 }
 
@@ -508,7 +543,7 @@ func resourceWindowsVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta
 			automaticOSUpgradeIsEnabled = *policy.AutomaticOSUpgradePolicy.EnableAutomaticOSUpgrade
 		}
 	}
-	if d.HasChange("automatic_os_upgrade_policy") {
+	if d.HasChanges("automatic_os_upgrade_policy", "rolling_upgrade_policy") {
 		upgradePolicy := compute.UpgradePolicy{}
 		if existing.VirtualMachineScaleSetProperties.UpgradePolicy == nil {
 			upgradePolicy = compute.UpgradePolicy{
@@ -527,6 +562,10 @@ func resourceWindowsVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta
 			// we can guarantee this always has a value since it'll have been expanded and thus is safe to de-ref
 			automaticOSUpgradeIsEnabled = *upgradePolicy.AutomaticOSUpgradePolicy.EnableAutomaticOSUpgrade
 		}
+		if d.HasChange("rolling_upgrade_policy") && upgradePolicy.Mode != compute.UpgradeModeManual {
+			upgradePolicy.RollingUpgradePolicy = ExpandVirtualMachineScaleSetRollingUpgradePolicy(d.Get("rolling_upgrade_policy").([]interface{}))
+		}
+
 		updateProps.UpgradePolicy = &upgradePolicy
 	}
 
@@ -724,16 +763,23 @@ func resourceWindowsVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta
 		updateProps.VirtualMachineProfile.ExtensionProfile = extensionProfile
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		update.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
 	}
 
 	update.VirtualMachineScaleSetUpdateProperties = &updateProps
 
+	_, hasHealthExtension, err := expandVirtualMachineScaleSetExtensions(d.Get("extension").(*pluginsdk.Set).List())
+	if err != nil {
+		return err
+	}
+
 	metaData := virtualMachineScaleSetUpdateMetaData{
 		AutomaticOSUpgradeIsEnabled:  automaticOSUpgradeIsEnabled,
 		CanRollInstancesWhenRequired: meta.(*clients.Client).Features.VirtualMachineScaleSet.RollInstancesWhenRequired,
 		UpdateInstances:              updateInstances,
+		HealthMonitoringEnabled:      d.Get("health_probe_id").(string) != "" || hasHealthExtension,
+		RollingUpgradePolicy:         ExpandVirtualMachineScaleSetRollingUpgradePolicy(d.Get("rolling_upgrade_policy").([]interface{})),
 		Client:                       meta.(*clients.Client).Compute,
 		Existing:                     existing,
 		ID:                           id,
@@ -744,6 +790,18 @@ func resourceWindowsVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
+	if d.HasChange("extension") {
+		if extensionNames := virtualMachineScaleSetExtensionNames(d.Get("extension").(*pluginsdk.Set).List()); len(extensionNames) > 0 {
+			summary, err := retrieveVirtualMachineScaleSetExtensionsSummary(ctx, client, *id)
+			if err != nil {
+				return err
+			}
+			if err := checkVirtualMachineScaleSetExtensionsProvisioned(summary, extensionNames); err != nil {
+				return fmt.Errorf("provisioning Extensions for Windows %s: %+v", id, err)
+			}
+		}
+	}
+
 	return resourceWindowsVirtualMachineScaleSetRead(d, meta)
 }
 
@@ -803,6 +861,12 @@ func resourceWindowsVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta i
 	d.Set("do_not_run_extensions_on_overprovisioned_machines", props.DoNotRunExtensionsOnOverprovisionedVMs)
 	d.Set("overprovision", props.Overprovision)
 	d.Set("platform_fault_domain_count", props.PlatformFaultDomainCount)
+
+	proximityPlacementGroupId := ""
+	if props.ProximityPlacementGroup != nil && props.ProximityPlacementGroup.ID != nil {
+		proximityPlacementGroupId = *props.ProximityPlacementGroup.ID
+	}
+	d.Set("proximity_placement_group_id", proximityPlacementGroupId)
 	d.Set("single_placement_group", props.SinglePlacementGroup)
 	d.Set("unique_id", props.UniqueID)
 
@@ -815,6 +879,15 @@ func resourceWindowsVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta i
 		if err := d.Set("automatic_os_upgrade_policy", flattenedAutomatic); err != nil {
 			return fmt.Errorf("setting `automatic_os_upgrade_policy`: %+v", err)
 		}
+
+		// when using the Manual upgrade mode the Rolling Upgrade Policy is only used by the provider, so isn't returned
+		rollingUpgradePolicy := d.Get("rolling_upgrade_policy").([]interface{})
+		if policy.Mode != compute.UpgradeModeManual {
+			rollingUpgradePolicy = FlattenVirtualMachineScaleSetRollingUpgradePolicy(policy.RollingUpgradePolicy)
+		}
+		if err := d.Set("rolling_upgrade_policy", rollingUpgradePolicy); err != nil {
+			return fmt.Errorf("setting `rolling_upgrade_policy`: %+v", err)
+		}
 	}
 
 	rule := string(compute.Default)
@@ -915,6 +988,14 @@ func resourceWindowsVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta i
 		}
 		d.Set("extension", extensionProfile)
 
+		extensionsSummary, err := retrieveVirtualMachineScaleSetExtensionsSummary(ctx, client, *id)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("extension_instance_view_summary", flattenVirtualMachineScaleSetExtensionsSummary(extensionsSummary)); err != nil {
+			return fmt.Errorf("setting `extension_instance_view_summary`: %+v", err)
+		}
+
 		encryptionAtHostEnabled := false
 
 		d.Set("encryption_at_host_enabled", encryptionAtHostEnabled)
@@ -1,180 +1,228 @@
 package dns
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/dns/mgmt/dns"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/parse"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/sdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
 
-func dnsARecord() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Create: dnsARecordCreateUpdate,
-		Read:   dnsARecordRead,
-		Update: dnsARecordCreateUpdate,
-		Delete: dnsARecordDelete,
-
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
-			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+type DnsARecordResourceModel struct {
+	Name              string            `tfschema:"name"`
+	ResourceGroupName string            `tfschema:"resource_group_name"`
+	ZoneName          string            `tfschema:"zone_name"`
+	Records           []string          `tfschema:"records"`
+	TTL               int64             `tfschema:"ttl"`
+	Fqdn              string            `tfschema:"fqdn"`
+	Tags              map[string]string `tfschema:"tags"`
+}
+
+type DnsARecordResource struct{}
+
+var _ sdk.ResourceWithUpdate = DnsARecordResource{}
+
+func (r DnsARecordResource) ResourceType() string {
+	return "azurestack_dns_a_record"
+}
+
+func (r DnsARecordResource) ModelObject() interface{} {
+	return &DnsARecordResourceModel{}
+}
+
+func (r DnsARecordResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ARecordID
+}
+
+func (r DnsARecordResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"zone_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+
+		"records": {
+			Type:     pluginsdk.TypeSet,
+			Required: true,
+			Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+			Set:      pluginsdk.HashString,
+		},
+
+		"ttl": {
+			Type:     pluginsdk.TypeInt,
+			Required: true,
 		},
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.ARecordID(id)
-			return err
-		}),
-
-		Schema: map[string]*pluginsdk.Schema{
-			"name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
-
-			"resource_group_name": commonschema.ResourceGroupName(),
-
-			"zone_name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-			},
-
-			"records": {
-				Type:     pluginsdk.TypeSet,
-				Required: true,
-				Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
-				Set:      pluginsdk.HashString,
-			},
-
-			"ttl": {
-				Type:     pluginsdk.TypeInt,
-				Required: true,
-			},
-
-			"fqdn": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
-			},
-
-			"tags": tags.Schema(),
+
+		"tags": tags.Schema(),
+	}
+}
+
+func (r DnsARecordResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"fqdn": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
 		},
 	}
 }
 
-func dnsARecordCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
-	defer cancel()
+func (r DnsARecordResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
 
-	name := d.Get("name").(string)
-	resGroup := d.Get("resource_group_name").(string)
-	zoneName := d.Get("zone_name").(string)
+			var model DnsARecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	resourceId := parse.NewARecordID(subscriptionId, resGroup, zoneName, name)
+			id := parse.NewARecordID(subscriptionId, model.ResourceGroupName, model.ZoneName, model.Name)
 
-	if d.IsNewResource() {
-		existing, err := client.Get(ctx, resGroup, zoneName, name, dns.A)
-		if err != nil {
-			if !utils.ResponseWasNotFound(existing.Response) {
-				return fmt.Errorf("checking for presence of existing DNS A Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.AName, dns.A)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
 			}
-		}
 
-		if !utils.ResponseWasNotFound(existing.Response) {
-			return tf.ImportAsExistsError("azurestack_dns_a_record", resourceId.ID())
-		}
-	}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
 
-	ttl := int64(d.Get("ttl").(int))
-	t := d.Get("tags").(map[string]interface{})
-	recordsRaw := d.Get("records").(*pluginsdk.Set).List()
+			if err := r.createOrUpdate(ctx, metadata, id, model); err != nil {
+				return err
+			}
 
-	parameters := dns.RecordSet{
-		Name: &name,
-		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata: tags.Expand(t),
-			TTL:      &ttl,
-			ARecords: expandazurestackDnsARecords(recordsRaw),
+			metadata.SetID(id)
+			return nil
 		},
 	}
+}
 
-	eTag := ""
-	ifNoneMatch := "" // set to empty to allow updates to records after creation
-	if _, err := client.CreateOrUpdate(ctx, resGroup, zoneName, name, dns.A, parameters, eTag, ifNoneMatch); err != nil {
-		return fmt.Errorf("creating/updating DNS A Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
-	}
+func (r DnsARecordResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	d.SetId(resourceId.ID())
+			id, err := parse.ARecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	return dnsARecordRead(d, meta)
-}
+			resp, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.AName, dns.A)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
 
-func dnsARecordRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+			model := DnsARecordResourceModel{
+				Name:              id.AName,
+				ResourceGroupName: id.ResourceGroup,
+				ZoneName:          id.DnszoneName,
+				Records:           []string{},
+				Tags:              map[string]string{},
+			}
 
-	id, err := parse.ARecordID(d.Id())
-	if err != nil {
-		return err
-	}
+			if props := resp.RecordSetProperties; props != nil {
+				model.Fqdn = pointer.ToString(props.Fqdn)
+				model.TTL = pointer.ToInt64(props.TTL)
+				model.Records = flattenDnsARecords(props.ARecords)
+				model.Tags = tags.ToTypedObject(props.Metadata)
+			}
 
-	resp, err := dnsClient.Get(ctx, id.ResourceGroup, id.DnszoneName, id.AName, dns.A)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("reading DNS A record %s: %+v", id.AName, err)
+			return metadata.Encode(&model)
+		},
 	}
+}
 
-	d.Set("name", id.AName)
-	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("zone_name", id.DnszoneName)
+func (r DnsARecordResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ARecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	d.Set("fqdn", resp.Fqdn)
-	d.Set("ttl", resp.TTL)
+			var model DnsARecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	if err := d.Set("records", flattenazurestackDnsARecords(resp.ARecords)); err != nil {
-		return fmt.Errorf("setting `records`: %+v", err)
+			return r.createOrUpdate(ctx, metadata, *id, model)
+		},
 	}
+}
+
+func (r DnsARecordResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+
+			id, err := parse.ARecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.AName, dns.A, "")
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
 
-	return tags.FlattenAndSet(d, resp.Metadata)
+			return nil
+		},
+	}
 }
 
-func dnsARecordDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r DnsARecordResource) createOrUpdate(ctx context.Context, metadata sdk.ResourceMetaData, id parse.ARecordId, model DnsARecordResourceModel) error {
+	client := metadata.Client.Dns.RecordSetsClient
 
-	id, err := parse.ARecordID(d.Id())
-	if err != nil {
-		return err
+	parameters := dns.RecordSet{
+		Name: pointer.FromString(id.AName),
+		RecordSetProperties: &dns.RecordSetProperties{
+			Metadata: tags.FromTypedObject(model.Tags),
+			TTL:      pointer.FromInt64(model.TTL),
+			ARecords: expandDnsARecords(model.Records),
+		},
 	}
 
-	resp, err := dnsClient.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.AName, dns.A, "")
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("deleting DNS A Record %s: %+v", id.AName, err)
+	eTag := ""
+	ifNoneMatch := "" // set to empty to allow updates to records after creation
+	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.DnszoneName, id.AName, dns.A, parameters, eTag, ifNoneMatch); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
 	}
 
 	return nil
 }
 
-func expandazurestackDnsARecords(input []interface{}) *[]dns.ARecord {
+func expandDnsARecords(input []string) *[]dns.ARecord {
 	records := make([]dns.ARecord, len(input))
 
 	for i, v := range input {
-		ipv4 := v.(string)
+		ipv4 := v
 		records[i] = dns.ARecord{
 			Ipv4Address: &ipv4,
 		}
@@ -183,7 +231,7 @@ func expandazurestackDnsARecords(input []interface{}) *[]dns.ARecord {
 	return &records
 }
 
-func flattenazurestackDnsARecords(records *[]dns.ARecord) []string {
+func flattenDnsARecords(records *[]dns.ARecord) []string {
 	if records == nil {
 		return []string{}
 	}
@@ -1,182 +1,229 @@
 package dns
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/dns/mgmt/dns"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/parse"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/sdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/set"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
 
-func dnsAAAARecord() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Create: dnsAaaaRecordCreateUpdate,
-		Read:   dnsAaaaRecordRead,
-		Update: dnsAaaaRecordCreateUpdate,
-		Delete: dnsAaaaRecordDelete,
-
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
-			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
-		},
+type DnsAAAARecordResourceModel struct {
+	Name              string            `tfschema:"name"`
+	ResourceGroupName string            `tfschema:"resource_group_name"`
+	ZoneName          string            `tfschema:"zone_name"`
+	Records           []string          `tfschema:"records"`
+	TTL               int64             `tfschema:"ttl"`
+	Fqdn              string            `tfschema:"fqdn"`
+	Tags              map[string]string `tfschema:"tags"`
+}
 
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.AaaaRecordID(id)
-			return err
-		}),
+type DnsAAAARecordResource struct{}
 
-		Schema: map[string]*pluginsdk.Schema{
-			"name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
+var _ sdk.ResourceWithUpdate = DnsAAAARecordResource{}
 
-			"resource_group_name": commonschema.ResourceGroupName(),
+func (r DnsAAAARecordResource) ResourceType() string {
+	return "azurestack_dns_aaaa_record"
+}
 
-			"zone_name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-			},
+func (r DnsAAAARecordResource) ModelObject() interface{} {
+	return &DnsAAAARecordResourceModel{}
+}
 
-			"records": {
-				Type:     pluginsdk.TypeSet,
-				Optional: true,
-				Elem: &pluginsdk.Schema{
-					Type:         pluginsdk.TypeString,
-					ValidateFunc: validation.IsIPv6Address,
-				},
-				Set: set.HashIPv6Address,
-			},
+func (r DnsAAAARecordResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.AaaaRecordID
+}
 
-			"ttl": {
-				Type:     pluginsdk.TypeInt,
-				Required: true,
-			},
+func (r DnsAAAARecordResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"zone_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
 
-			"fqdn": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
+		"records": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.IsIPv6Address,
 			},
+			Set: set.HashIPv6Address,
+		},
 
-			"tags": tags.Schema(),
+		"ttl": {
+			Type:     pluginsdk.TypeInt,
+			Required: true,
 		},
+
+		"tags": tags.Schema(),
 	}
 }
 
-func dnsAaaaRecordCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
-	defer cancel()
+func (r DnsAAAARecordResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"fqdn": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
 
-	name := d.Get("name").(string)
-	resGroup := d.Get("resource_group_name").(string)
-	zoneName := d.Get("zone_name").(string)
+func (r DnsAAAARecordResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
 
-	resourceId := parse.NewAaaaRecordID(subscriptionId, resGroup, zoneName, name)
+			var model DnsAAAARecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	if d.IsNewResource() {
-		existing, err := client.Get(ctx, resGroup, zoneName, name, dns.AAAA)
-		if err != nil {
-			if !utils.ResponseWasNotFound(existing.Response) {
-				return fmt.Errorf("checking for presence of existing DNS AAAA Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
+			id := parse.NewAaaaRecordID(subscriptionId, model.ResourceGroupName, model.ZoneName, model.Name)
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.AAAAName, dns.AAAA)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
 			}
-		}
 
-		if !utils.ResponseWasNotFound(existing.Response) {
-			return tf.ImportAsExistsError("azurestack_dns_aaaa_record", resourceId.ID())
-		}
-	}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
 
-	ttl := int64(d.Get("ttl").(int))
-	t := d.Get("tags").(map[string]interface{})
-	recordsRaw := d.Get("records").(*pluginsdk.Set).List()
+			if err := r.createOrUpdate(ctx, metadata, id, model); err != nil {
+				return err
+			}
 
-	parameters := dns.RecordSet{
-		Name: &name,
-		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata:    tags.Expand(t),
-			TTL:         &ttl,
-			AaaaRecords: expandazurestackDnsAaaaRecords(recordsRaw),
+			metadata.SetID(id)
+			return nil
 		},
 	}
+}
 
-	eTag := ""
-	ifNoneMatch := "" // set to empty to allow updates to records after creation
-	if _, err := client.CreateOrUpdate(ctx, resGroup, zoneName, name, dns.AAAA, parameters, eTag, ifNoneMatch); err != nil {
-		return fmt.Errorf("creating/updating DNS AAAA Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
-	}
+func (r DnsAAAARecordResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	d.SetId(resourceId.ID())
+			id, err := parse.AaaaRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	return dnsAaaaRecordRead(d, meta)
-}
+			resp, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.AAAAName, dns.AAAA)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
 
-func dnsAaaaRecordRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+			model := DnsAAAARecordResourceModel{
+				Name:              id.AAAAName,
+				ResourceGroupName: id.ResourceGroup,
+				ZoneName:          id.DnszoneName,
+				Records:           []string{},
+				Tags:              map[string]string{},
+			}
 
-	id, err := parse.AaaaRecordID(d.Id())
-	if err != nil {
-		return err
-	}
+			if props := resp.RecordSetProperties; props != nil {
+				model.Fqdn = pointer.ToString(props.Fqdn)
+				model.TTL = pointer.ToInt64(props.TTL)
+				model.Records = flattenDnsAAAARecords(props.AaaaRecords)
+				model.Tags = tags.ToTypedObject(props.Metadata)
+			}
 
-	resp, err := dnsClient.Get(ctx, id.ResourceGroup, id.DnszoneName, id.AAAAName, dns.AAAA)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("reading DNS AAAA record %s: %v", id.AAAAName, err)
+			return metadata.Encode(&model)
+		},
 	}
+}
 
-	d.Set("name", id.AAAAName)
-	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("zone_name", id.DnszoneName)
+func (r DnsAAAARecordResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.AaaaRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	d.Set("fqdn", resp.Fqdn)
-	d.Set("ttl", resp.TTL)
+			var model DnsAAAARecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	if err := d.Set("records", flattenazurestackDnsAaaaRecords(resp.AaaaRecords)); err != nil {
-		return fmt.Errorf("setting `records`: %+v", err)
+			return r.createOrUpdate(ctx, metadata, *id, model)
+		},
 	}
+}
+
+func (r DnsAAAARecordResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+
+			id, err := parse.AaaaRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.AAAAName, dns.AAAA, "")
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
 
-	return tags.FlattenAndSet(d, resp.Metadata)
+			return nil
+		},
+	}
 }
 
-func dnsAaaaRecordDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r DnsAAAARecordResource) createOrUpdate(ctx context.Context, metadata sdk.ResourceMetaData, id parse.AaaaRecordId, model DnsAAAARecordResourceModel) error {
+	client := metadata.Client.Dns.RecordSetsClient
 
-	id, err := parse.AaaaRecordID(d.Id())
-	if err != nil {
-		return err
+	parameters := dns.RecordSet{
+		Name: pointer.FromString(id.AAAAName),
+		RecordSetProperties: &dns.RecordSetProperties{
+			Metadata:    tags.FromTypedObject(model.Tags),
+			TTL:         pointer.FromInt64(model.TTL),
+			AaaaRecords: expandDnsAAAARecords(model.Records),
+		},
 	}
 
-	resp, err := dnsClient.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.AAAAName, dns.AAAA, "")
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("deleting DNS AAAA Record %s: %+v", id.AAAAName, err)
+	eTag := ""
+	ifNoneMatch := "" // set to empty to allow updates to records after creation
+	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.DnszoneName, id.AAAAName, dns.AAAA, parameters, eTag, ifNoneMatch); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
 	}
 
 	return nil
 }
 
-func expandazurestackDnsAaaaRecords(input []interface{}) *[]dns.AaaaRecord {
+func expandDnsAAAARecords(input []string) *[]dns.AaaaRecord {
 	records := make([]dns.AaaaRecord, len(input))
 
 	for i, v := range input {
@@ -189,7 +236,7 @@ func expandazurestackDnsAaaaRecords(input []interface{}) *[]dns.AaaaRecord {
 	return &records
 }
 
-func flattenazurestackDnsAaaaRecords(records *[]dns.AaaaRecord) []string {
+func flattenDnsAAAARecords(records *[]dns.AaaaRecord) []string {
 	if records == nil {
 		return []string{}
 	}
@@ -1,6 +1,7 @@
 package dns
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -9,170 +10,212 @@ import (
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/parse"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/sdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
 
-func dnsCNameRecord() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Create: dnsCNameRecordCreateUpdate,
-		Read:   dnsCNameRecordRead,
-		Update: dnsCNameRecordCreateUpdate,
-		Delete: dnsCNameRecordDelete,
-
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
-			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+type DnsCNameRecordResourceModel struct {
+	Name              string            `tfschema:"name"`
+	ResourceGroupName string            `tfschema:"resource_group_name"`
+	ZoneName          string            `tfschema:"zone_name"`
+	Record            string            `tfschema:"record"`
+	TTL               int64             `tfschema:"ttl"`
+	Fqdn              string            `tfschema:"fqdn"`
+	Tags              map[string]string `tfschema:"tags"`
+}
+
+type DnsCNameRecordResource struct{}
+
+var _ sdk.ResourceWithUpdate = DnsCNameRecordResource{}
+
+func (r DnsCNameRecordResource) ResourceType() string {
+	return "azurestack_dns_cname_record"
+}
+
+func (r DnsCNameRecordResource) ModelObject() interface{} {
+	return &DnsCNameRecordResourceModel{}
+}
+
+func (r DnsCNameRecordResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.CnameRecordID
+}
+
+func (r DnsCNameRecordResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"zone_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+
+		"record": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"ttl": {
+			Type:     pluginsdk.TypeInt,
+			Required: true,
 		},
 
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.CnameRecordID(id)
-			return err
-		}),
-
-		Schema: map[string]*pluginsdk.Schema{
-			"name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
-
-			"resource_group_name": commonschema.ResourceGroupName(),
-
-			"zone_name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-			},
-
-			"record": {
-				Type:     pluginsdk.TypeString,
-				Optional: true,
-			},
-
-			"ttl": {
-				Type:     pluginsdk.TypeInt,
-				Required: true,
-			},
-
-			"fqdn": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
-			},
-
-			"tags": tags.Schema(),
+		"tags": tags.Schema(),
+	}
+}
+
+func (r DnsCNameRecordResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"fqdn": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
 		},
 	}
 }
 
-func dnsCNameRecordCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
-	defer cancel()
+func (r DnsCNameRecordResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
 
-	name := d.Get("name").(string)
-	resGroup := d.Get("resource_group_name").(string)
-	zoneName := d.Get("zone_name").(string)
+			var model DnsCNameRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	resourceId := parse.NewCnameRecordID(subscriptionId, resGroup, zoneName, name)
+			id := parse.NewCnameRecordID(subscriptionId, model.ResourceGroupName, model.ZoneName, model.Name)
 
-	if d.IsNewResource() {
-		existing, err := client.Get(ctx, resGroup, zoneName, name, dns.CNAME)
-		if err != nil {
-			if !utils.ResponseWasNotFound(existing.Response) {
-				return fmt.Errorf("checking for presence of existing DNS CNAME Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.CNAMEName, dns.CNAME)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
 			}
-		}
 
-		if !utils.ResponseWasNotFound(existing.Response) {
-			return tf.ImportAsExistsError("azurestack_dns_cname_record", resourceId.ID())
-		}
-	}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
 
-	ttl := int64(d.Get("ttl").(int))
-	record := d.Get("record").(string)
-	t := d.Get("tags").(map[string]interface{})
+			if err := r.createOrUpdate(ctx, metadata, id, model); err != nil {
+				return err
+			}
 
-	parameters := dns.RecordSet{
-		Name: &name,
-		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata:    tags.Expand(t),
-			TTL:         &ttl,
-			CnameRecord: &dns.CnameRecord{},
+			metadata.SetID(id)
+			return nil
 		},
 	}
+}
 
-	if record != "" {
-		parameters.RecordSetProperties.CnameRecord.Cname = pointer.FromString(record)
-	}
+func (r DnsCNameRecordResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	eTag := ""
-	ifNoneMatch := "" // set to empty to allow updates to records after creation
-	if _, err := client.CreateOrUpdate(ctx, resGroup, zoneName, name, dns.CNAME, parameters, eTag, ifNoneMatch); err != nil {
-		return fmt.Errorf("creating/updating CNAME Record %q (DNS Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
-	}
+			id, err := parse.CnameRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	d.SetId(resourceId.ID())
+			resp, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.CNAMEName, dns.CNAME)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
 
-	return dnsCNameRecordRead(d, meta)
-}
+			model := DnsCNameRecordResourceModel{
+				Name:              id.CNAMEName,
+				ResourceGroupName: id.ResourceGroup,
+				ZoneName:          id.DnszoneName,
+				Tags:              map[string]string{},
+			}
 
-func dnsCNameRecordRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+			if props := resp.RecordSetProperties; props != nil {
+				model.Fqdn = pointer.ToString(props.Fqdn)
+				model.TTL = pointer.ToInt64(props.TTL)
+				if props.CnameRecord != nil {
+					model.Record = pointer.ToString(props.CnameRecord.Cname)
+				}
+				model.Tags = tags.ToTypedObject(props.Metadata)
+			}
 
-	id, err := parse.CnameRecordID(d.Id())
-	if err != nil {
-		return err
+			return metadata.Encode(&model)
+		},
 	}
+}
 
-	resp, err := dnsClient.Get(ctx, id.ResourceGroup, id.DnszoneName, id.CNAMEName, dns.CNAME)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("retrieving CNAME Record %s (DNS Zone %q / Resource Group %q): %+v", id.CNAMEName, id.DnszoneName, id.ResourceGroup, err)
+func (r DnsCNameRecordResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.CnameRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model DnsCNameRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			return r.createOrUpdate(ctx, metadata, *id, model)
+		},
 	}
+}
 
-	d.Set("name", id.CNAMEName)
-	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("zone_name", id.DnszoneName)
+func (r DnsCNameRecordResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	d.Set("fqdn", resp.Fqdn)
-	d.Set("ttl", resp.TTL)
+			id, err := parse.CnameRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	if props := resp.RecordSetProperties; props != nil {
-		cname := ""
-		if props.CnameRecord != nil && props.CnameRecord.Cname != nil {
-			cname = *props.CnameRecord.Cname
-		}
-		d.Set("record", cname)
-	}
+			resp, err := client.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.CNAMEName, dns.CNAME, "")
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
 
-	return tags.FlattenAndSet(d, resp.Metadata)
+			return nil
+		},
+	}
 }
 
-func dnsCNameRecordDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r DnsCNameRecordResource) createOrUpdate(ctx context.Context, metadata sdk.ResourceMetaData, id parse.CnameRecordId, model DnsCNameRecordResourceModel) error {
+	client := metadata.Client.Dns.RecordSetsClient
 
-	id, err := parse.CnameRecordID(d.Id())
-	if err != nil {
-		return err
+	parameters := dns.RecordSet{
+		Name: pointer.FromString(id.CNAMEName),
+		RecordSetProperties: &dns.RecordSetProperties{
+			Metadata:    tags.FromTypedObject(model.Tags),
+			TTL:         pointer.FromInt64(model.TTL),
+			CnameRecord: &dns.CnameRecord{},
+		},
 	}
 
-	resp, err := dnsClient.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.CNAMEName, dns.CNAME, "")
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("deleting CNAME Record %q (DNS Zone %q / Resource Group %q): %+v", id.CNAMEName, id.DnszoneName, id.ResourceGroup, err)
+	if model.Record != "" {
+		parameters.RecordSetProperties.CnameRecord.Cname = pointer.FromString(model.Record)
+	}
+
+	eTag := ""
+	ifNoneMatch := "" // set to empty to allow updates to records after creation
+	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.DnszoneName, id.CNAMEName, dns.CNAME, parameters, eTag, ifNoneMatch); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
 	}
 
 	return nil
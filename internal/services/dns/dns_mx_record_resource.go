@@ -2,223 +2,256 @@ package dns
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/dns/mgmt/dns"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/parse"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/sdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
 
-func dnsMxRecord() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Create: dnsMxRecordCreateUpdate,
-		Read:   dnsMxRecordRead,
-		Update: dnsMxRecordCreateUpdate,
-		Delete: dnsMxRecordDelete,
-
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
-			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+type DnsMxRecordResourceModel struct {
+	Name              string             `tfschema:"name"`
+	ResourceGroupName string             `tfschema:"resource_group_name"`
+	ZoneName          string             `tfschema:"zone_name"`
+	Record            []DnsMxRecordModel `tfschema:"record"`
+	TTL               int64              `tfschema:"ttl"`
+	Fqdn              string             `tfschema:"fqdn"`
+	Tags              map[string]string  `tfschema:"tags"`
+}
+
+type DnsMxRecordModel struct {
+	Preference string `tfschema:"preference"`
+	Exchange   string `tfschema:"exchange"`
+}
+
+type DnsMxRecordResource struct{}
+
+var _ sdk.ResourceWithUpdate = DnsMxRecordResource{}
+
+func (r DnsMxRecordResource) ResourceType() string {
+	return "azurestack_dns_mx_record"
+}
+
+func (r DnsMxRecordResource) ModelObject() interface{} {
+	return &DnsMxRecordResourceModel{}
+}
+
+func (r DnsMxRecordResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.MxRecordID
+}
+
+func (r DnsMxRecordResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  "@",
 		},
 
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.MxRecordID(id)
-			return err
-		}),
-
-		Schema: map[string]*pluginsdk.Schema{
-			"name": {
-				Type:     pluginsdk.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  "@",
-			},
+		"resource_group_name": commonschema.ResourceGroupName(),
 
-			"resource_group_name": commonschema.ResourceGroupName(),
+		"zone_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
 
-			"zone_name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-			},
+		"record": {
+			Type:     pluginsdk.TypeSet,
+			Required: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"preference": {
+						// TODO: this should become an Int
+						Type:     pluginsdk.TypeString,
+						Required: true,
+					},
 
-			"record": {
-				Type:     pluginsdk.TypeSet,
-				Required: true,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"preference": {
-							// TODO: this should become an Int
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
-
-						"exchange": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
+					"exchange": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
 					},
 				},
-				Set: dnsMxRecordHash,
 			},
+			Set: dnsMxRecordHash,
+		},
 
-			"ttl": {
-				Type:     pluginsdk.TypeInt,
-				Required: true,
-			},
+		"ttl": {
+			Type:     pluginsdk.TypeInt,
+			Required: true,
+		},
 
-			"fqdn": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
-			},
+		"tags": tags.Schema(),
+	}
+}
 
-			"tags": tags.Schema(),
+func (r DnsMxRecordResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"fqdn": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
 		},
 	}
 }
 
-func dnsMxRecordCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
-	defer cancel()
+func (r DnsMxRecordResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
 
-	name := d.Get("name").(string)
-	resGroup := d.Get("resource_group_name").(string)
-	zoneName := d.Get("zone_name").(string)
+			var model DnsMxRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	resourceId := parse.NewMxRecordID(subscriptionId, resGroup, zoneName, name)
+			id := parse.NewMxRecordID(subscriptionId, model.ResourceGroupName, model.ZoneName, model.Name)
 
-	if d.IsNewResource() {
-		existing, err := client.Get(ctx, resGroup, zoneName, name, dns.MX)
-		if err != nil {
-			if !utils.ResponseWasNotFound(existing.Response) {
-				return fmt.Errorf("checking for presence of existing DNS MX Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.MXName, dns.MX)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
 			}
-		}
 
-		if !utils.ResponseWasNotFound(existing.Response) {
-			return tf.ImportAsExistsError("azurestack_dns_mx_record", resourceId.ID())
-		}
-	}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
 
-	ttl := int64(d.Get("ttl").(int))
-	t := d.Get("tags").(map[string]interface{})
+			if err := r.createOrUpdate(ctx, metadata, id, model); err != nil {
+				return err
+			}
 
-	parameters := dns.RecordSet{
-		Name: &name,
-		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata:  tags.Expand(t),
-			TTL:       &ttl,
-			MxRecords: expandazurestackDnsMxRecords(d),
+			metadata.SetID(id)
+			return nil
 		},
 	}
+}
 
-	if _, err := client.CreateOrUpdate(ctx, resGroup, zoneName, name, dns.MX, parameters, "", ""); err != nil {
-		return fmt.Errorf("creating/updating DNS MX Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
-	}
+func (r DnsMxRecordResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	d.SetId(resourceId.ID())
+			id, err := parse.MxRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	return dnsMxRecordRead(d, meta)
-}
+			resp, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.MXName, dns.MX)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
 
-func dnsMxRecordRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+			model := DnsMxRecordResourceModel{
+				Name:              id.MXName,
+				ResourceGroupName: id.ResourceGroup,
+				ZoneName:          id.DnszoneName,
+				Record:            []DnsMxRecordModel{},
+				Tags:              map[string]string{},
+			}
 
-	id, err := parse.MxRecordID(d.Id())
-	if err != nil {
-		return err
-	}
+			if props := resp.RecordSetProperties; props != nil {
+				model.Fqdn = pointer.ToString(props.Fqdn)
+				model.TTL = pointer.ToInt64(props.TTL)
+				model.Record = flattenDnsMxRecords(props.MxRecords)
+				model.Tags = tags.ToTypedObject(props.Metadata)
+			}
 
-	resp, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.MXName, dns.MX)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("reading DNS MX record %s: %v", id.MXName, err)
+			return metadata.Encode(&model)
+		},
 	}
+}
 
-	d.Set("name", id.MXName)
-	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("zone_name", id.DnszoneName)
+func (r DnsMxRecordResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.MxRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	d.Set("ttl", resp.TTL)
-	d.Set("fqdn", resp.Fqdn)
+			var model DnsMxRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	if err := d.Set("record", flattenazurestackDnsMxRecords(resp.MxRecords)); err != nil {
-		return err
+			return r.createOrUpdate(ctx, metadata, *id, model)
+		},
 	}
-	return tags.FlattenAndSet(d, resp.Metadata)
 }
 
-func dnsMxRecordDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r DnsMxRecordResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	id, err := parse.MxRecordID(d.Id())
-	if err != nil {
-		return err
-	}
+			id, err := parse.MxRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	resp, err := client.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.MXName, dns.MX, "")
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("deleting DNS MX Record %s: %+v", id.MXName, err)
-	}
+			resp, err := client.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.MXName, dns.MX, "")
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
 
-	return nil
+			return nil
+		},
+	}
 }
 
-// flatten creates an array of map where preference is a string to suit
-// the expectations of the ResourceData schema, so that this data can be
-// managed by Terradata state.
-func flattenazurestackDnsMxRecords(records *[]dns.MxRecord) []map[string]interface{} {
-	results := make([]map[string]interface{}, 0)
+func (r DnsMxRecordResource) createOrUpdate(ctx context.Context, metadata sdk.ResourceMetaData, id parse.MxRecordId, model DnsMxRecordResourceModel) error {
+	client := metadata.Client.Dns.RecordSetsClient
 
-	if records != nil {
-		for _, record := range *records {
-			preferenceI32 := *record.Preference
-			preference := strconv.Itoa(int(preferenceI32))
-			results = append(results, map[string]interface{}{
-				"preference": preference,
-				"exchange":   *record.Exchange,
-			})
-		}
+	parameters := dns.RecordSet{
+		Name: pointer.FromString(id.MXName),
+		RecordSetProperties: &dns.RecordSetProperties{
+			Metadata:  tags.FromTypedObject(model.Tags),
+			TTL:       pointer.FromInt64(model.TTL),
+			MxRecords: expandDnsMxRecords(model.Record),
+		},
 	}
 
-	return results
+	eTag := ""
+	ifNoneMatch := "" // set to empty to allow updates to records after creation
+	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.DnszoneName, id.MXName, dns.MX, parameters, eTag, ifNoneMatch); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	return nil
 }
 
-// expand creates an array of dns.MxRecord, that is, the array needed
+// expandDnsMxRecords creates an array of dns.MxRecord, that is, the array needed
 // by azure-sdk-for-go to manipulate azure resources, hence Preference
 // is an int32
-func expandazurestackDnsMxRecords(d *pluginsdk.ResourceData) *[]dns.MxRecord {
-	recordStrings := d.Get("record").(*pluginsdk.Set).List()
-	records := make([]dns.MxRecord, len(recordStrings))
+func expandDnsMxRecords(input []DnsMxRecordModel) *[]dns.MxRecord {
+	records := make([]dns.MxRecord, len(input))
 
-	for i, v := range recordStrings {
-		mxrecord := v.(map[string]interface{})
-		preference := mxrecord["preference"].(string)
-		i64, _ := strconv.ParseInt(preference, 10, 32)
-		i32 := int32(i64)
-		exchange := mxrecord["exchange"].(string)
+	for i, v := range input {
+		i64, _ := strconv.ParseInt(v.Preference, 10, 32)
+		preference := int32(i64)
+		exchange := v.Exchange
 
 		records[i] = dns.MxRecord{
-			Preference: &i32,
+			Preference: &preference,
 			Exchange:   &exchange,
 		}
 	}
@@ -226,6 +259,28 @@ func expandazurestackDnsMxRecords(d *pluginsdk.ResourceData) *[]dns.MxRecord {
 	return &records
 }
 
+// flattenDnsMxRecords returns the records with the preference as a string to suit
+// the expectations of the schema, so that this data can be managed by Terraform state.
+func flattenDnsMxRecords(records *[]dns.MxRecord) []DnsMxRecordModel {
+	results := make([]DnsMxRecordModel, 0)
+
+	if records != nil {
+		for _, record := range *records {
+			preference := ""
+			if record.Preference != nil {
+				preference = strconv.Itoa(int(*record.Preference))
+			}
+
+			results = append(results, DnsMxRecordModel{
+				Preference: preference,
+				Exchange:   pointer.ToString(record.Exchange),
+			})
+		}
+	}
+
+	return results
+}
+
 func dnsMxRecordHash(v interface{}) int {
 	var buf bytes.Buffer
 
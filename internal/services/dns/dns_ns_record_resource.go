@@ -1,6 +1,7 @@
 package dns
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -9,221 +10,255 @@ import (
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/parse"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/sdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
 
-func dnsNsRecord() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Create: dnsNsRecordCreate,
-		Read:   dnsNsRecordRead,
-		Update: dnsNsRecordUpdate,
-		Delete: dnsNsRecordDelete,
-
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
-			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
-		},
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.NsRecordID(id)
-			return err
-		}),
-
-		Schema: map[string]*pluginsdk.Schema{
-			"name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
-
-			"resource_group_name": commonschema.ResourceGroupName(),
-
-			"zone_name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
-
-			"records": {
-				Type:     pluginsdk.TypeList,
-				Required: true,
-				Elem: &pluginsdk.Schema{
-					Type: pluginsdk.TypeString,
-				},
-			},
+type DnsNsRecordResourceModel struct {
+	Name              string            `tfschema:"name"`
+	ResourceGroupName string            `tfschema:"resource_group_name"`
+	ZoneName          string            `tfschema:"zone_name"`
+	Records           []string          `tfschema:"records"`
+	TTL               int64             `tfschema:"ttl"`
+	Fqdn              string            `tfschema:"fqdn"`
+	Tags              map[string]string `tfschema:"tags"`
+}
 
-			"ttl": {
-				Type:     pluginsdk.TypeInt,
-				Required: true,
-			},
+type DnsNsRecordResource struct{}
 
-			"fqdn": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
-			},
+var _ sdk.ResourceWithUpdate = DnsNsRecordResource{}
 
-			"tags": tags.Schema(),
-		},
-	}
+func (r DnsNsRecordResource) ResourceType() string {
+	return "azurestack_dns_ns_record"
 }
 
-func dnsNsRecordCreate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
-	defer cancel()
-
-	name := d.Get("name").(string)
-	resGroup := d.Get("resource_group_name").(string)
-	zoneName := d.Get("zone_name").(string)
+func (r DnsNsRecordResource) ModelObject() interface{} {
+	return &DnsNsRecordResourceModel{}
+}
 
-	resourceId := parse.NewNsRecordID(subscriptionId, resGroup, zoneName, name)
+func (r DnsNsRecordResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.NsRecordID
+}
 
-	existing, err := client.Get(ctx, resGroup, zoneName, name, dns.NS)
-	if err != nil {
-		if !utils.ResponseWasNotFound(existing.Response) {
-			return fmt.Errorf("checking for presence of existing DNS NS Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
-		}
-	}
+func (r DnsNsRecordResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
 
-	if !utils.ResponseWasNotFound(existing.Response) {
-		return tf.ImportAsExistsError("azurestack_dns_ns_record", resourceId.ID())
-	}
+		"resource_group_name": commonschema.ResourceGroupName(),
 
-	ttl := int64(d.Get("ttl").(int))
-	t := d.Get("tags").(map[string]interface{})
+		"zone_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
 
-	recordsRaw := d.Get("records").([]interface{})
-	records := expandazurestackDnsNsRecords(recordsRaw)
+		"records": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
 
-	parameters := dns.RecordSet{
-		Name: &name,
-		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata:  tags.Expand(t),
-			TTL:       &ttl,
-			NsRecords: records,
+		"ttl": {
+			Type:     pluginsdk.TypeInt,
+			Required: true,
 		},
-	}
 
-	eTag := ""
-	ifNoneMatch := "" // set to empty to allow updates to records after creation
-	if _, err := client.CreateOrUpdate(ctx, resGroup, zoneName, name, dns.NS, parameters, eTag, ifNoneMatch); err != nil {
-		return fmt.Errorf("creating DNS NS Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
+		"tags": tags.Schema(),
 	}
-
-	d.SetId(resourceId.ID())
-
-	return dnsNsRecordRead(d, meta)
 }
 
-func dnsNsRecordUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
-	defer cancel()
-
-	id, err := parse.NsRecordID(d.Id())
-	if err != nil {
-		return err
-	}
-
-	existing, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.NSName, dns.NS)
-	if err != nil {
-		return fmt.Errorf("retrieving NS %q (DNS Zone %q / Resource Group %q): %+v", id.NSName, id.DnszoneName, id.ResourceGroup, err)
-	}
-
-	if existing.RecordSetProperties == nil {
-		return fmt.Errorf("retrieving NS %q (DNS Zone %q / Resource Group %q): `properties` was nil", id.NSName, id.DnszoneName, id.ResourceGroup)
+func (r DnsNsRecordResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"fqdn": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
 	}
+}
 
-	if d.HasChange("records") {
-		recordsRaw := d.Get("records").([]interface{})
-		records := expandazurestackDnsNsRecords(recordsRaw)
-		existing.RecordSetProperties.NsRecords = records
-	}
+func (r DnsNsRecordResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model DnsNsRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewNsRecordID(subscriptionId, model.ResourceGroupName, model.ZoneName, model.Name)
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.NSName, dns.NS)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
+
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := dns.RecordSet{
+				Name: pointer.FromString(id.NSName),
+				RecordSetProperties: &dns.RecordSetProperties{
+					Metadata:  tags.FromTypedObject(model.Tags),
+					TTL:       pointer.FromInt64(model.TTL),
+					NsRecords: expandDnsNsRecords(model.Records),
+				},
+			}
 
-	if d.HasChange("tags") {
-		t := d.Get("tags").(map[string]interface{})
-		existing.RecordSetProperties.Metadata = tags.Expand(t)
-	}
+			eTag := ""
+			ifNoneMatch := "" // set to empty to allow updates to records after creation
+			if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.DnszoneName, id.NSName, dns.NS, parameters, eTag, ifNoneMatch); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
 
-	if d.HasChange("ttl") {
-		existing.RecordSetProperties.TTL = pointer.FromInt64(int64(d.Get("ttl").(int)))
+			metadata.SetID(id)
+			return nil
+		},
 	}
+}
 
-	eTag := ""
-	ifNoneMatch := "" // set to empty to allow updates to records after creation
-	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.DnszoneName, id.NSName, dns.NS, existing, eTag, ifNoneMatch); err != nil {
-		return fmt.Errorf("updating DNS NS Record %q (Zone %q / Resource Group %q): %s", id.NSName, id.DnszoneName, id.ResourceGroup, err)
+func (r DnsNsRecordResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+
+			id, err := parse.NsRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.NSName, dns.NS)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			model := DnsNsRecordResourceModel{
+				Name:              id.NSName,
+				ResourceGroupName: id.ResourceGroup,
+				ZoneName:          id.DnszoneName,
+				Records:           []string{},
+				Tags:              map[string]string{},
+			}
+
+			if props := resp.RecordSetProperties; props != nil {
+				model.Fqdn = pointer.ToString(props.Fqdn)
+				model.TTL = pointer.ToInt64(props.TTL)
+				model.Records = flattenDnsNsRecords(props.NsRecords)
+				model.Tags = tags.ToTypedObject(props.Metadata)
+			}
+
+			return metadata.Encode(&model)
+		},
 	}
-
-	return dnsNsRecordRead(d, meta)
 }
 
-func dnsNsRecordRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r DnsNsRecordResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+
+			id, err := parse.NsRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model DnsNsRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.NSName, dns.NS)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			if existing.RecordSetProperties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", id)
+			}
+
+			if metadata.ResourceData.HasChange("records") {
+				existing.RecordSetProperties.NsRecords = expandDnsNsRecords(model.Records)
+			}
+
+			if metadata.ResourceData.HasChanges("tags", "tags_all") {
+				existing.RecordSetProperties.Metadata = tags.FromTypedObject(model.Tags)
+			}
+
+			if metadata.ResourceData.HasChange("ttl") {
+				existing.RecordSetProperties.TTL = pointer.FromInt64(model.TTL)
+			}
+
+			eTag := ""
+			ifNoneMatch := "" // set to empty to allow updates to records after creation
+			if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.DnszoneName, id.NSName, dns.NS, existing, eTag, ifNoneMatch); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
 
-	id, err := parse.NsRecordID(d.Id())
-	if err != nil {
-		return err
-	}
-
-	resp, err := dnsClient.Get(ctx, id.ResourceGroup, id.DnszoneName, id.NSName, dns.NS)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			d.SetId("")
 			return nil
-		}
-		return fmt.Errorf("reading DNS NS record %s: %+v", id.NSName, err)
+		},
 	}
+}
 
-	d.Set("name", id.NSName)
-	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("zone_name", id.DnszoneName)
+func (r DnsNsRecordResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	d.Set("ttl", resp.TTL)
-	d.Set("fqdn", resp.Fqdn)
+			id, err := parse.NsRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	if props := resp.RecordSetProperties; props != nil {
-		if err := d.Set("records", flattenazurestackDnsNsRecords(props.NsRecords)); err != nil {
-			return fmt.Errorf("settings `records`: %+v", err)
-		}
-	}
+			resp, err := client.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.NSName, dns.NS, "")
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
 
-	return tags.FlattenAndSet(d, resp.Metadata)
+			return nil
+		},
+	}
 }
 
-func dnsNsRecordDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func expandDnsNsRecords(input []string) *[]dns.NsRecord {
+	records := make([]dns.NsRecord, len(input))
+	for i, v := range input {
+		record := v
 
-	id, err := parse.NsRecordID(d.Id())
-	if err != nil {
-		return err
-	}
+		nsRecord := dns.NsRecord{
+			Nsdname: &record,
+		}
 
-	resp, err := dnsClient.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.NSName, dns.NS, "")
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("deleting DNS NS Record %s: %+v", id.NSName, err)
+		records[i] = nsRecord
 	}
-
-	return nil
+	return &records
 }
 
-func flattenazurestackDnsNsRecords(records *[]dns.NsRecord) []interface{} {
+func flattenDnsNsRecords(records *[]dns.NsRecord) []string {
 	if records == nil {
-		return []interface{}{}
+		return []string{}
 	}
 
-	results := make([]interface{}, 0)
+	results := make([]string, 0)
 	for _, record := range *records {
 		if record.Nsdname == nil {
 			continue
@@ -234,17 +269,3 @@ func flattenazurestackDnsNsRecords(records *[]dns.NsRecord) []interface{} {
 
 	return results
 }
-
-func expandazurestackDnsNsRecords(input []interface{}) *[]dns.NsRecord {
-	records := make([]dns.NsRecord, len(input))
-	for i, v := range input {
-		record := v.(string)
-
-		nsRecord := dns.NsRecord{
-			Nsdname: &record,
-		}
-
-		records[i] = nsRecord
-	}
-	return &records
-}
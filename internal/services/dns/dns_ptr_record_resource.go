@@ -1,200 +1,251 @@
 package dns
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/dns/mgmt/dns"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/parse"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/sdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
 
-func dnsPtrRecord() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Create: dnsPtrRecordCreateUpdate,
-		Read:   dnsPtrRecordRead,
-		Update: dnsPtrRecordCreateUpdate,
-		Delete: dnsPtrRecordDelete,
-
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
-			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+type DnsPtrRecordResourceModel struct {
+	Name              string            `tfschema:"name"`
+	ResourceGroupName string            `tfschema:"resource_group_name"`
+	ZoneName          string            `tfschema:"zone_name"`
+	Records           []string          `tfschema:"records"`
+	TTL               int64             `tfschema:"ttl"`
+	Fqdn              string            `tfschema:"fqdn"`
+	Tags              map[string]string `tfschema:"tags"`
+}
+
+type DnsPtrRecordResource struct{}
+
+var _ sdk.ResourceWithUpdate = DnsPtrRecordResource{}
+
+func (r DnsPtrRecordResource) ResourceType() string {
+	return "azurestack_dns_ptr_record"
+}
+
+func (r DnsPtrRecordResource) ModelObject() interface{} {
+	return &DnsPtrRecordResourceModel{}
+}
+
+func (r DnsPtrRecordResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.PtrRecordID
+}
+
+func (r DnsPtrRecordResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
 		},
 
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.PtrRecordID(id)
-			return err
-		}),
-		Schema: map[string]*pluginsdk.Schema{
-			"name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
-
-			"resource_group_name": commonschema.ResourceGroupName(),
-
-			"zone_name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-			},
-
-			"records": {
-				Type:     pluginsdk.TypeSet,
-				Required: true,
-				Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
-				Set:      pluginsdk.HashString,
-			},
-
-			"ttl": {
-				Type:     pluginsdk.TypeInt,
-				Required: true,
-			},
-
-			"fqdn": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
-			},
-
-			"tags": tags.Schema(),
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"zone_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
 		},
+
+		"records": {
+			Type:     pluginsdk.TypeSet,
+			Required: true,
+			Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+			Set:      pluginsdk.HashString,
+		},
+
+		"ttl": {
+			Type:     pluginsdk.TypeInt,
+			Required: true,
+		},
+
+		"tags": tags.Schema(),
 	}
 }
 
-func dnsPtrRecordCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
-	defer cancel()
+func (r DnsPtrRecordResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"fqdn": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
 
-	name := d.Get("name").(string)
-	resGroup := d.Get("resource_group_name").(string)
-	zoneName := d.Get("zone_name").(string)
+func (r DnsPtrRecordResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
 
-	resourceId := parse.NewPtrRecordID(subscriptionId, resGroup, zoneName, name)
+			var model DnsPtrRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	if d.IsNewResource() {
-		existing, err := client.Get(ctx, resGroup, zoneName, name, dns.PTR)
-		if err != nil {
-			if !utils.ResponseWasNotFound(existing.Response) {
-				return fmt.Errorf("checking for presence of existing DNS PTR Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
+			id := parse.NewPtrRecordID(subscriptionId, model.ResourceGroupName, model.ZoneName, model.Name)
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.PTRName, dns.PTR)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
 			}
-		}
 
-		if !utils.ResponseWasNotFound(existing.Response) {
-			return tf.ImportAsExistsError("azurestack_dns_ptr_record", resourceId.ID())
-		}
-	}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
 
-	ttl := int64(d.Get("ttl").(int))
-	t := d.Get("tags").(map[string]interface{})
+			if err := r.createOrUpdate(ctx, metadata, id, model); err != nil {
+				return err
+			}
 
-	parameters := dns.RecordSet{
-		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata:   tags.Expand(t),
-			TTL:        &ttl,
-			PtrRecords: expandazurestackDnsPtrRecords(d),
+			metadata.SetID(id)
+			return nil
 		},
 	}
+}
 
-	eTag := ""
-	ifNoneMatch := "" // set to empty to allow updates to records after creation
-	if _, err := client.CreateOrUpdate(ctx, resGroup, zoneName, name, dns.PTR, parameters, eTag, ifNoneMatch); err != nil {
-		return fmt.Errorf("creating/updating DNS PTR Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
-	}
+func (r DnsPtrRecordResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	d.SetId(resourceId.ID())
+			id, err := parse.PtrRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	return dnsPtrRecordRead(d, meta)
-}
+			resp, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.PTRName, dns.PTR)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
 
-func dnsPtrRecordRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client)
-	dnsClient := client.Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+			model := DnsPtrRecordResourceModel{
+				Name:              id.PTRName,
+				ResourceGroupName: id.ResourceGroup,
+				ZoneName:          id.DnszoneName,
+				Records:           []string{},
+				Tags:              map[string]string{},
+			}
 
-	id, err := parse.PtrRecordID(d.Id())
-	if err != nil {
-		return err
+			if props := resp.RecordSetProperties; props != nil {
+				model.Fqdn = pointer.ToString(props.Fqdn)
+				model.TTL = pointer.ToInt64(props.TTL)
+				model.Records = flattenDnsPtrRecords(props.PtrRecords)
+				model.Tags = tags.ToTypedObject(props.Metadata)
+			}
+
+			return metadata.Encode(&model)
+		},
 	}
+}
 
-	resp, err := dnsClient.Get(ctx, id.ResourceGroup, id.DnszoneName, id.PTRName, dns.PTR)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			d.SetId("")
-			return nil
-		}
+func (r DnsPtrRecordResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.PtrRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model DnsPtrRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-		return fmt.Errorf("reading DNS PTR record %s: %+v", id.PTRName, err)
+			return r.createOrUpdate(ctx, metadata, *id, model)
+		},
 	}
+}
+
+func (r DnsPtrRecordResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+
+			id, err := parse.PtrRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	d.Set("name", id.PTRName)
-	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("zone_name", id.DnszoneName)
-	d.Set("ttl", resp.TTL)
-	d.Set("fqdn", resp.Fqdn)
+			resp, err := client.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.PTRName, dns.PTR, "")
+			if err != nil {
+				if resp.StatusCode == http.StatusNotFound {
+					return nil
+				}
 
-	if err := d.Set("records", flattenazurestackDnsPtrRecords(resp.PtrRecords)); err != nil {
-		return err
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
 	}
-	return tags.FlattenAndSet(d, resp.Metadata)
 }
 
-func dnsPtrRecordDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client)
-	dnsClient := client.Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r DnsPtrRecordResource) createOrUpdate(ctx context.Context, metadata sdk.ResourceMetaData, id parse.PtrRecordId, model DnsPtrRecordResourceModel) error {
+	client := metadata.Client.Dns.RecordSetsClient
 
-	id, err := parse.PtrRecordID(d.Id())
-	if err != nil {
-		return err
+	parameters := dns.RecordSet{
+		RecordSetProperties: &dns.RecordSetProperties{
+			Metadata:   tags.FromTypedObject(model.Tags),
+			TTL:        pointer.FromInt64(model.TTL),
+			PtrRecords: expandDnsPtrRecords(model.Records),
+		},
 	}
 
-	resp, err := dnsClient.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.PTRName, dns.PTR, "")
-	if err != nil {
-		if resp.StatusCode == http.StatusNotFound {
-			return nil
-		}
-
-		return fmt.Errorf("deleting DNS PTR Record %s: %+v", id.PTRName, err)
+	eTag := ""
+	ifNoneMatch := "" // set to empty to allow updates to records after creation
+	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.DnszoneName, id.PTRName, dns.PTR, parameters, eTag, ifNoneMatch); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
 	}
 
 	return nil
 }
 
-func flattenazurestackDnsPtrRecords(records *[]dns.PtrRecord) []string {
-	results := make([]string, 0)
+func expandDnsPtrRecords(input []string) *[]dns.PtrRecord {
+	records := make([]dns.PtrRecord, len(input))
 
-	if records != nil {
-		for _, record := range *records {
-			results = append(results, *record.Ptrdname)
+	for i, v := range input {
+		fqdn := v
+		records[i] = dns.PtrRecord{
+			Ptrdname: &fqdn,
 		}
 	}
 
-	return results
+	return &records
 }
 
-func expandazurestackDnsPtrRecords(d *pluginsdk.ResourceData) *[]dns.PtrRecord {
-	recordStrings := d.Get("records").(*pluginsdk.Set).List()
-	records := make([]dns.PtrRecord, len(recordStrings))
+func flattenDnsPtrRecords(records *[]dns.PtrRecord) []string {
+	results := make([]string, 0)
 
-	for i, v := range recordStrings {
-		fqdn := v.(string)
-		records[i] = dns.PtrRecord{
-			Ptrdname: &fqdn,
+	if records != nil {
+		for _, record := range *records {
+			if record.Ptrdname == nil {
+				continue
+			}
+
+			results = append(results, *record.Ptrdname)
 		}
 	}
 
-	return &records
+	return results
 }
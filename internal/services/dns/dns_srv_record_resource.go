@@ -2,234 +2,297 @@ package dns
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/dns/mgmt/dns"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/parse"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/sdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
 
-func dnsSrvRecord() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Create: dnsSrvRecordCreateUpdate,
-		Read:   dnsSrvRecordRead,
-		Update: dnsSrvRecordCreateUpdate,
-		Delete: dnsSrvRecordDelete,
-
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
-			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+type DnsSrvRecordResourceModel struct {
+	Name              string              `tfschema:"name"`
+	ResourceGroupName string              `tfschema:"resource_group_name"`
+	ZoneName          string              `tfschema:"zone_name"`
+	Record            []DnsSrvRecordModel `tfschema:"record"`
+	TTL               int64               `tfschema:"ttl"`
+	Fqdn              string              `tfschema:"fqdn"`
+	Tags              map[string]string   `tfschema:"tags"`
+}
+
+type DnsSrvRecordModel struct {
+	Priority int64  `tfschema:"priority"`
+	Weight   int64  `tfschema:"weight"`
+	Port     int64  `tfschema:"port"`
+	Target   string `tfschema:"target"`
+}
+
+type DnsSrvRecordResource struct{}
+
+var _ sdk.ResourceWithUpdate = DnsSrvRecordResource{}
+
+func (r DnsSrvRecordResource) ResourceType() string {
+	return "azurestack_dns_srv_record"
+}
+
+func (r DnsSrvRecordResource) ModelObject() interface{} {
+	return &DnsSrvRecordResourceModel{}
+}
+
+func (r DnsSrvRecordResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.SrvRecordID
+}
+
+func (r DnsSrvRecordResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
 		},
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.SrvRecordID(id)
-			return err
-		}),
-		Schema: map[string]*pluginsdk.Schema{
-			"name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
 
-			"resource_group_name": commonschema.ResourceGroupName(),
+		"resource_group_name": commonschema.ResourceGroupName(),
 
-			"zone_name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-			},
+		"zone_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
 
-			"record": {
-				Type:     pluginsdk.TypeSet,
-				Required: true,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"priority": {
-							Type:     pluginsdk.TypeInt,
-							Required: true,
-						},
-
-						"weight": {
-							Type:     pluginsdk.TypeInt,
-							Required: true,
-						},
-
-						"port": {
-							Type:     pluginsdk.TypeInt,
-							Required: true,
-						},
-
-						"target": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
+		"record": {
+			Type:     pluginsdk.TypeSet,
+			Required: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"priority": {
+						Type:     pluginsdk.TypeInt,
+						Required: true,
+					},
+
+					"weight": {
+						Type:     pluginsdk.TypeInt,
+						Required: true,
+					},
+
+					"port": {
+						Type:     pluginsdk.TypeInt,
+						Required: true,
+					},
+
+					"target": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
 					},
 				},
-				Set: dnsSrvRecordHash,
 			},
+			Set: dnsSrvRecordHash,
+		},
 
-			"ttl": {
-				Type:     pluginsdk.TypeInt,
-				Required: true,
-			},
+		"ttl": {
+			Type:     pluginsdk.TypeInt,
+			Required: true,
+		},
 
-			"fqdn": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
-			},
+		"tags": tags.Schema(),
+	}
+}
 
-			"tags": tags.Schema(),
+func (r DnsSrvRecordResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"fqdn": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
 		},
 	}
 }
 
-func dnsSrvRecordCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
-	defer cancel()
+func (r DnsSrvRecordResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
 
-	name := d.Get("name").(string)
-	resGroup := d.Get("resource_group_name").(string)
-	zoneName := d.Get("zone_name").(string)
+			var model DnsSrvRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	resourceId := parse.NewSrvRecordID(subscriptionId, resGroup, zoneName, name)
+			id := parse.NewSrvRecordID(subscriptionId, model.ResourceGroupName, model.ZoneName, model.Name)
 
-	if d.IsNewResource() {
-		existing, err := client.Get(ctx, resGroup, zoneName, name, dns.SRV)
-		if err != nil {
-			if !utils.ResponseWasNotFound(existing.Response) {
-				return fmt.Errorf("checking for presence of existing DNS SRV Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.SRVName, dns.SRV)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
 			}
-		}
 
-		if !utils.ResponseWasNotFound(existing.Response) {
-			return tf.ImportAsExistsError("azurestack_dns_srv_record", resourceId.ID())
-		}
-	}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
 
-	ttl := int64(d.Get("ttl").(int))
-	t := d.Get("tags").(map[string]interface{})
+			if err := r.createOrUpdate(ctx, metadata, id, model); err != nil {
+				return err
+			}
 
-	parameters := dns.RecordSet{
-		Name: &name,
-		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata:   tags.Expand(t),
-			TTL:        &ttl,
-			SrvRecords: expandazurestackDnsSrvRecords(d),
+			metadata.SetID(id)
+			return nil
 		},
 	}
+}
 
-	eTag := ""
-	ifNoneMatch := "" // set to empty to allow updates to records after creation
-	if _, err := client.CreateOrUpdate(ctx, resGroup, zoneName, name, dns.SRV, parameters, eTag, ifNoneMatch); err != nil {
-		return fmt.Errorf("creating/updating DNS SRV Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
-	}
+func (r DnsSrvRecordResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	d.SetId(resourceId.ID())
+			id, err := parse.SrvRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	return dnsSrvRecordRead(d, meta)
-}
+			resp, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.SRVName, dns.SRV)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
 
-func dnsSrvRecordRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+			model := DnsSrvRecordResourceModel{
+				Name:              id.SRVName,
+				ResourceGroupName: id.ResourceGroup,
+				ZoneName:          id.DnszoneName,
+				Record:            []DnsSrvRecordModel{},
+				Tags:              map[string]string{},
+			}
 
-	id, err := parse.SrvRecordID(d.Id())
-	if err != nil {
-		return err
-	}
+			if props := resp.RecordSetProperties; props != nil {
+				model.Fqdn = pointer.ToString(props.Fqdn)
+				model.TTL = pointer.ToInt64(props.TTL)
+				model.Record = flattenDnsSrvRecords(props.SrvRecords)
+				model.Tags = tags.ToTypedObject(props.Metadata)
+			}
 
-	resp, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.SRVName, dns.SRV)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("reading DNS SRV record %s: %v", id.SRVName, err)
+			return metadata.Encode(&model)
+		},
 	}
+}
 
-	d.Set("name", id.SRVName)
-	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("zone_name", id.DnszoneName)
-	d.Set("ttl", resp.TTL)
-	d.Set("fqdn", resp.Fqdn)
+func (r DnsSrvRecordResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.SrvRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model DnsSrvRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	if err := d.Set("record", flattenazurestackDnsSrvRecords(resp.SrvRecords)); err != nil {
-		return err
+			return r.createOrUpdate(ctx, metadata, *id, model)
+		},
 	}
-	return tags.FlattenAndSet(d, resp.Metadata)
 }
 
-func dnsSrvRecordDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r DnsSrvRecordResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	id, err := parse.SrvRecordID(d.Id())
-	if err != nil {
-		return err
-	}
+			id, err := parse.SrvRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	resp, err := client.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.SRVName, dns.SRV, "")
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("deleting DNS SRV Record %s: %+v", id.SRVName, err)
-	}
+			resp, err := client.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.SRVName, dns.SRV, "")
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
 
-	return nil
+			return nil
+		},
+	}
 }
 
-func flattenazurestackDnsSrvRecords(records *[]dns.SrvRecord) []map[string]interface{} {
-	results := make([]map[string]interface{}, 0)
+func (r DnsSrvRecordResource) createOrUpdate(ctx context.Context, metadata sdk.ResourceMetaData, id parse.SrvRecordId, model DnsSrvRecordResourceModel) error {
+	client := metadata.Client.Dns.RecordSetsClient
 
-	if records != nil {
-		for _, record := range *records {
-			results = append(results, map[string]interface{}{
-				"priority": *record.Priority,
-				"weight":   *record.Weight,
-				"port":     *record.Port,
-				"target":   *record.Target,
-			})
-		}
+	parameters := dns.RecordSet{
+		Name: pointer.FromString(id.SRVName),
+		RecordSetProperties: &dns.RecordSetProperties{
+			Metadata:   tags.FromTypedObject(model.Tags),
+			TTL:        pointer.FromInt64(model.TTL),
+			SrvRecords: expandDnsSrvRecords(model.Record),
+		},
 	}
 
-	return results
+	eTag := ""
+	ifNoneMatch := "" // set to empty to allow updates to records after creation
+	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.DnszoneName, id.SRVName, dns.SRV, parameters, eTag, ifNoneMatch); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	return nil
 }
 
-func expandazurestackDnsSrvRecords(d *pluginsdk.ResourceData) *[]dns.SrvRecord {
-	recordStrings := d.Get("record").(*pluginsdk.Set).List()
-	records := make([]dns.SrvRecord, len(recordStrings))
+func expandDnsSrvRecords(input []DnsSrvRecordModel) *[]dns.SrvRecord {
+	records := make([]dns.SrvRecord, len(input))
 
-	for i, v := range recordStrings {
-		record := v.(map[string]interface{})
-		priority := int32(record["priority"].(int))
-		weight := int32(record["weight"].(int))
-		port := int32(record["port"].(int))
-		target := record["target"].(string)
+	for i, v := range input {
+		priority := int32(v.Priority)
+		weight := int32(v.Weight)
+		port := int32(v.Port)
+		target := v.Target
 
-		srvRecord := dns.SrvRecord{
+		records[i] = dns.SrvRecord{
 			Priority: &priority,
 			Weight:   &weight,
 			Port:     &port,
 			Target:   &target,
 		}
-
-		records[i] = srvRecord
 	}
 
 	return &records
 }
 
+func flattenDnsSrvRecords(records *[]dns.SrvRecord) []DnsSrvRecordModel {
+	results := make([]DnsSrvRecordModel, 0)
+
+	if records != nil {
+		for _, record := range *records {
+			result := DnsSrvRecordModel{
+				Target: pointer.ToString(record.Target),
+			}
+			if record.Priority != nil {
+				result.Priority = int64(*record.Priority)
+			}
+			if record.Weight != nil {
+				result.Weight = int64(*record.Weight)
+			}
+			if record.Port != nil {
+				result.Port = int64(*record.Port)
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
 func dnsSrvRecordHash(v interface{}) int {
 	var buf bytes.Buffer
 
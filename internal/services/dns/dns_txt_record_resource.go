@@ -1,207 +1,242 @@
 package dns
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/dns/mgmt/dns"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/parse"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/dns/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/sdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
 
-func dnsTxtRecord() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Create: dnsTxtRecordCreateUpdate,
-		Read:   dnsTxtRecordRead,
-		Update: dnsTxtRecordCreateUpdate,
-		Delete: dnsTxtRecordDelete,
-
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
-			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+type DnsTxtRecordResourceModel struct {
+	Name              string              `tfschema:"name"`
+	ResourceGroupName string              `tfschema:"resource_group_name"`
+	ZoneName          string              `tfschema:"zone_name"`
+	Record            []DnsTxtRecordModel `tfschema:"record"`
+	TTL               int64               `tfschema:"ttl"`
+	Fqdn              string              `tfschema:"fqdn"`
+	Tags              map[string]string   `tfschema:"tags"`
+}
+
+type DnsTxtRecordModel struct {
+	Value string `tfschema:"value"`
+}
+
+type DnsTxtRecordResource struct{}
+
+var _ sdk.ResourceWithUpdate = DnsTxtRecordResource{}
+
+func (r DnsTxtRecordResource) ResourceType() string {
+	return "azurestack_dns_txt_record"
+}
+
+func (r DnsTxtRecordResource) ModelObject() interface{} {
+	return &DnsTxtRecordResourceModel{}
+}
+
+func (r DnsTxtRecordResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.TxtRecordID
+}
+
+func (r DnsTxtRecordResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
 		},
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.TxtRecordID(id)
-			return err
-		}),
-		Schema: map[string]*pluginsdk.Schema{
-			"name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
 
-			"resource_group_name": commonschema.ResourceGroupName(),
+		"resource_group_name": commonschema.ResourceGroupName(),
 
-			"zone_name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-			},
+		"zone_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
 
-			"record": {
-				Type:     pluginsdk.TypeSet,
-				Required: true,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"value": {
-							Type:         pluginsdk.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringLenBetween(1, 1024),
-						},
+		"record": {
+			Type:     pluginsdk.TypeSet,
+			Required: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"value": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringLenBetween(1, 1024),
 					},
 				},
 			},
+		},
 
-			"ttl": {
-				Type:     pluginsdk.TypeInt,
-				Required: true,
-			},
+		"ttl": {
+			Type:     pluginsdk.TypeInt,
+			Required: true,
+		},
 
-			"fqdn": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
-			},
+		"tags": tags.Schema(),
+	}
+}
 
-			"tags": tags.Schema(),
+func (r DnsTxtRecordResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"fqdn": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
 		},
 	}
 }
 
-func dnsTxtRecordCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
-	defer cancel()
+func (r DnsTxtRecordResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model DnsTxtRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	name := d.Get("name").(string)
-	resGroup := d.Get("resource_group_name").(string)
-	zoneName := d.Get("zone_name").(string)
+			id := parse.NewTxtRecordID(subscriptionId, model.ResourceGroupName, model.ZoneName, model.Name)
 
-	resourceId := parse.NewTxtRecordID(subscriptionId, resGroup, zoneName, name)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.TXTName, dns.TXT)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
 
-	if d.IsNewResource() {
-		existing, err := client.Get(ctx, resGroup, zoneName, name, dns.TXT)
-		if err != nil {
 			if !utils.ResponseWasNotFound(existing.Response) {
-				return fmt.Errorf("checking for presence of existing DNS TXT Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
 			}
-		}
-
-		if !utils.ResponseWasNotFound(existing.Response) {
-			return tf.ImportAsExistsError("azurestack_dns_txt_record", resourceId.ID())
-		}
-	}
 
-	ttl := int64(d.Get("ttl").(int))
-	t := d.Get("tags").(map[string]interface{})
+			if err := r.createOrUpdate(ctx, metadata, id, model); err != nil {
+				return err
+			}
 
-	parameters := dns.RecordSet{
-		Name: &name,
-		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata:   tags.Expand(t),
-			TTL:        &ttl,
-			TxtRecords: expandazurestackDnsTxtRecords(d),
+			metadata.SetID(id)
+			return nil
 		},
 	}
+}
 
-	eTag := ""
-	ifNoneMatch := "" // set to empty to allow updates to records after creation
-	if _, err := client.CreateOrUpdate(ctx, resGroup, zoneName, name, dns.TXT, parameters, eTag, ifNoneMatch); err != nil {
-		return fmt.Errorf("creating/updating DNS TXT Record %q (Zone %q / Resource Group %q): %s", name, zoneName, resGroup, err)
-	}
+func (r DnsTxtRecordResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	d.SetId(resourceId.ID())
+			id, err := parse.TxtRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	return dnsTxtRecordRead(d, meta)
-}
+			resp, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.TXTName, dns.TXT)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
 
-func dnsTxtRecordRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+			model := DnsTxtRecordResourceModel{
+				Name:              id.TXTName,
+				ResourceGroupName: id.ResourceGroup,
+				ZoneName:          id.DnszoneName,
+				Record:            []DnsTxtRecordModel{},
+				Tags:              map[string]string{},
+			}
 
-	id, err := parse.TxtRecordID(d.Id())
-	if err != nil {
-		return err
-	}
+			if props := resp.RecordSetProperties; props != nil {
+				model.Fqdn = pointer.ToString(props.Fqdn)
+				model.TTL = pointer.ToInt64(props.TTL)
+				model.Record = flattenDnsTxtRecords(props.TxtRecords)
+				model.Tags = tags.ToTypedObject(props.Metadata)
+			}
 
-	resp, err := client.Get(ctx, id.ResourceGroup, id.DnszoneName, id.TXTName, dns.TXT)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("reading DNS TXT record %s: %+v", id.TXTName, err)
+			return metadata.Encode(&model)
+		},
 	}
+}
+
+func (r DnsTxtRecordResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.TxtRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	d.Set("name", id.TXTName)
-	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("zone_name", id.DnszoneName)
-	d.Set("ttl", resp.TTL)
-	d.Set("fqdn", resp.Fqdn)
+			var model DnsTxtRecordResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	if err := d.Set("record", flattenazurestackDnsTxtRecords(resp.TxtRecords)); err != nil {
-		return err
+			return r.createOrUpdate(ctx, metadata, *id, model)
+		},
 	}
-	return tags.FlattenAndSet(d, resp.Metadata)
 }
 
-func dnsTxtRecordDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Dns.RecordSetsClient
-	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r DnsTxtRecordResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dns.RecordSetsClient
 
-	id, err := parse.TxtRecordID(d.Id())
-	if err != nil {
-		return err
-	}
+			id, err := parse.TxtRecordID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	resp, err := client.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.TXTName, dns.TXT, "")
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("deleting DNS TXT Record %s: %+v", id.TXTName, err)
-	}
+			resp, err := client.Delete(ctx, id.ResourceGroup, id.DnszoneName, id.TXTName, dns.TXT, "")
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
 
-	return nil
+			return nil
+		},
+	}
 }
 
-func flattenazurestackDnsTxtRecords(records *[]dns.TxtRecord) []map[string]interface{} {
-	results := make([]map[string]interface{}, 0)
-
-	if records != nil {
-		for _, record := range *records {
-			txtRecord := make(map[string]interface{})
+func (r DnsTxtRecordResource) createOrUpdate(ctx context.Context, metadata sdk.ResourceMetaData, id parse.TxtRecordId, model DnsTxtRecordResourceModel) error {
+	client := metadata.Client.Dns.RecordSetsClient
 
-			if v := record.Value; v != nil {
-				value := strings.Join(*v, "")
-				txtRecord["value"] = value
-			}
+	parameters := dns.RecordSet{
+		Name: pointer.FromString(id.TXTName),
+		RecordSetProperties: &dns.RecordSetProperties{
+			Metadata:   tags.FromTypedObject(model.Tags),
+			TTL:        pointer.FromInt64(model.TTL),
+			TxtRecords: expandDnsTxtRecords(model.Record),
+		},
+	}
 
-			results = append(results, txtRecord)
-		}
+	eTag := ""
+	ifNoneMatch := "" // set to empty to allow updates to records after creation
+	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.DnszoneName, id.TXTName, dns.TXT, parameters, eTag, ifNoneMatch); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
 	}
 
-	return results
+	return nil
 }
 
-func expandazurestackDnsTxtRecords(d *pluginsdk.ResourceData) *[]dns.TxtRecord {
-	recordStrings := d.Get("record").(*pluginsdk.Set).List()
-	records := make([]dns.TxtRecord, len(recordStrings))
+func expandDnsTxtRecords(input []DnsTxtRecordModel) *[]dns.TxtRecord {
+	records := make([]dns.TxtRecord, len(input))
 
 	segmentLen := 254
-	for i, v := range recordStrings {
-		record := v.(map[string]interface{})
-		v := record["value"].(string)
+	for i, record := range input {
+		v := record.Value
 
 		var value []string
 		for len(v) > segmentLen {
@@ -210,12 +245,28 @@ func expandazurestackDnsTxtRecords(d *pluginsdk.ResourceData) *[]dns.TxtRecord {
 		}
 		value = append(value, v)
 
-		txtRecord := dns.TxtRecord{
+		records[i] = dns.TxtRecord{
 			Value: &value,
 		}
-
-		records[i] = txtRecord
 	}
 
 	return &records
 }
+
+func flattenDnsTxtRecords(records *[]dns.TxtRecord) []DnsTxtRecordModel {
+	results := make([]DnsTxtRecordModel, 0)
+
+	if records != nil {
+		for _, record := range *records {
+			txtRecord := DnsTxtRecordModel{}
+
+			if v := record.Value; v != nil {
+				txtRecord.Value = strings.Join(*v, "")
+			}
+
+			results = append(results, txtRecord)
+		}
+	}
+
+	return results
+}
@@ -34,15 +34,7 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurestack_dns_a_record":     dnsARecord(),
-		"azurestack_dns_aaaa_record":  dnsAAAARecord(),
-		"azurestack_dns_cname_record": dnsCNameRecord(),
-		"azurestack_dns_mx_record":    dnsMxRecord(),
-		"azurestack_dns_ns_record":    dnsNsRecord(),
-		"azurestack_dns_ptr_record":   dnsPtrRecord(),
-		"azurestack_dns_srv_record":   dnsSrvRecord(),
-		"azurestack_dns_txt_record":   dnsTxtRecord(),
-		"azurestack_dns_zone":         dnsZone(),
+		"azurestack_dns_zone": dnsZone(),
 	}
 }
 
@@ -53,5 +45,14 @@ func (r Registration) DataSources() []sdk.DataSource {
 
 // Resources returns a list of Resources supported by this Service
 func (r Registration) Resources() []sdk.Resource {
-	return []sdk.Resource{}
+	return []sdk.Resource{
+		DnsARecordResource{},
+		DnsAAAARecordResource{},
+		DnsCNameRecordResource{},
+		DnsMxRecordResource{},
+		DnsNsRecordResource{},
+		DnsPtrRecordResource{},
+		DnsSrvRecordResource{},
+		DnsTxtRecordResource{},
+	}
 }
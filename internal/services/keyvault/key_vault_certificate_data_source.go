@@ -0,0 +1,348 @@
+package keyvault
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurestack/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func keyVaultCertificateDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: keyVaultCertificateDataSourceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: keyVaultValidate.NestedItemName,
+			},
+
+			"key_vault_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: keyVaultValidate.VaultID,
+			},
+
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"certificate_policy": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"issuer_parameters": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+
+						"key_properties": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"exportable": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+
+									"key_size": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+
+									"key_type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"reuse_key": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+
+						"lifetime_action": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"action": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"action_type": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+											},
+										},
+									},
+
+									"trigger": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"days_before_expiry": {
+													Type:     schema.TypeInt,
+													Computed: true,
+												},
+
+												"lifetime_percentage": {
+													Type:     schema.TypeInt,
+													Computed: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+
+						"secret_properties": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"content_type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+
+						"x509_certificate_properties": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"extended_key_usage": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+
+									"key_usage": {
+										Type:     schema.TypeSet,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+
+									"subject": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"subject_alternative_names": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"emails": {
+													Type:     schema.TypeSet,
+													Computed: true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+													Set: schema.HashString,
+												},
+
+												"dns_names": {
+													Type:     schema.TypeSet,
+													Computed: true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+													Set: schema.HashString,
+												},
+
+												"upns": {
+													Type:     schema.TypeSet,
+													Computed: true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+													Set: schema.HashString,
+												},
+											},
+										},
+									},
+
+									"validity_in_months": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"versionless_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"secret_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"versionless_secret_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"certificate_data": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"certificate_data_base64": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"thumbprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"expires": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"not_before": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tags.SchemaDataSource(),
+		},
+	}
+}
+
+func keyVaultCertificateDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+	keyVaultId, err := parse.VaultID(d.Get("key_vault_id").(string))
+	if err != nil {
+		return err
+	}
+
+	keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("Error looking up Certificate %q vault url from id %q: %+v", name, *keyVaultId, err)
+	}
+
+	// an empty version indicates the latest version
+	resp, err := client.GetCertificate(ctx, *keyVaultBaseUri, name, version)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("KeyVault Certificate %q (KeyVault URI %q) does not exist", name, *keyVaultBaseUri)
+		}
+		return fmt.Errorf("Error making Read request on Azure KeyVault Certificate %s: %+v", name, err)
+	}
+
+	// the version may have changed, so parse the updated id
+	respID, err := parse.ParseNestedItemID(*resp.ID)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(*resp.ID)
+
+	d.Set("name", respID.Name)
+	d.Set("key_vault_id", keyVaultId.ID())
+	d.Set("version", respID.Version)
+	d.Set("versionless_id", fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(respID.KeyVaultBaseUrl, "/"), respID.NestedItemType, respID.Name))
+
+	if err := d.Set("certificate_policy", flattenKeyVaultCertificatePolicy(resp.Policy)); err != nil {
+		return fmt.Errorf("setting `certificate_policy`: %+v", err)
+	}
+
+	secretId := ""
+	versionlessSecretId := ""
+	if resp.Sid != nil {
+		secretId = *resp.Sid
+		sid, err := parse.ParseOptionallyVersionedNestedItemID(*resp.Sid)
+		if err != nil {
+			return fmt.Errorf("parsing Secret ID %q: %+v", *resp.Sid, err)
+		}
+		versionlessSecretId = fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(sid.KeyVaultBaseUrl, "/"), sid.NestedItemType, sid.Name)
+	}
+	d.Set("secret_id", secretId)
+	d.Set("versionless_secret_id", versionlessSecretId)
+
+	certificateData := ""
+	certificateDataBase64 := ""
+	if contents := resp.Cer; contents != nil {
+		certificateData = strings.ToUpper(hex.EncodeToString(*contents))
+		certificateDataBase64 = base64.StdEncoding.EncodeToString(*contents)
+	}
+	d.Set("certificate_data", certificateData)
+	d.Set("certificate_data_base64", certificateDataBase64)
+
+	thumbprint, err := flattenKeyVaultCertificateThumbprint(resp.X509Thumbprint)
+	if err != nil {
+		return err
+	}
+	d.Set("thumbprint", thumbprint)
+
+	expires := ""
+	notBefore := ""
+	if attributes := resp.Attributes; attributes != nil {
+		if v := attributes.Expires; v != nil {
+			expires = time.Time(*v).Format(time.RFC3339)
+		}
+
+		if v := attributes.NotBefore; v != nil {
+			notBefore = time.Time(*v).Format(time.RFC3339)
+		}
+	}
+	d.Set("expires", expires)
+	d.Set("not_before", notBefore)
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
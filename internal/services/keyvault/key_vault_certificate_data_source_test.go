@@ -0,0 +1,73 @@
+package keyvault_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type KeyVaultCertificateDataSource struct{}
+
+func TestAccKeyVaultCertificateDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_key_vault_certificate", "test")
+	r := KeyVaultCertificateDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("certificate_data").Exists(),
+				check.That(data.ResourceName).Key("certificate_data_base64").Exists(),
+				check.That(data.ResourceName).Key("thumbprint").Exists(),
+				check.That(data.ResourceName).Key("secret_id").Exists(),
+				check.That(data.ResourceName).Key("certificate_policy.0.key_properties.0.key_size").HasValue("2048"),
+				check.That(data.ResourceName).Key("certificate_policy.0.key_properties.0.key_type").HasValue("RSA"),
+			),
+		},
+	})
+}
+
+func TestAccKeyVaultCertificateDataSource_generated(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_key_vault_certificate", "test")
+	r := KeyVaultCertificateDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.generated(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("certificate_data").Exists(),
+				check.That(data.ResourceName).Key("certificate_policy.0.issuer_parameters.0.name").HasValue("Self"),
+				check.That(data.ResourceName).Key("certificate_policy.0.secret_properties.0.content_type").HasValue("application/x-pkcs12"),
+				check.That(data.ResourceName).Key("certificate_policy.0.x509_certificate_properties.0.subject").HasValue("CN=hello-world"),
+				check.That(data.ResourceName).Key("certificate_policy.0.x509_certificate_properties.0.validity_in_months").HasValue("12"),
+				check.That(data.ResourceName).Key("expires").Exists(),
+				check.That(data.ResourceName).Key("not_before").Exists(),
+			),
+		},
+	})
+}
+
+func (KeyVaultCertificateDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_key_vault_certificate" "test" {
+  name         = azurestack_key_vault_certificate.test.name
+  key_vault_id = azurestack_key_vault.test.id
+}
+`, KeyVaultCertificateResource{}.basicImportPFX(data))
+}
+
+func (KeyVaultCertificateDataSource) generated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_key_vault_certificate" "test" {
+  name         = azurestack_key_vault_certificate.test.name
+  key_vault_id = azurestack_key_vault.test.id
+}
+`, KeyVaultCertificateResource{}.basicGenerate(data))
+}
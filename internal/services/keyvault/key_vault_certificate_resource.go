@@ -0,0 +1,1068 @@
+package keyvault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurestack/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func keyVaultCertificate() *schema.Resource {
+	return &schema.Resource{
+		Create: keyVaultCertificateCreate,
+		Read:   keyVaultCertificateRead,
+		Update: keyVaultCertificateUpdate,
+		Delete: keyVaultCertificateDelete,
+		Importer: &schema.ResourceImporter{
+			State: nestedItemResourceImporter,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: keyVaultValidate.NestedItemName,
+			},
+
+			"key_vault_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: keyVaultValidate.VaultID,
+			},
+
+			"certificate": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				AtLeastOneOf: []string{"certificate", "certificate_policy"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"contents": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsBase64,
+						},
+
+						"password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+
+			"certificate_policy": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				AtLeastOneOf: []string{"certificate", "certificate_policy"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"issuer_parameters": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+								},
+							},
+						},
+
+						"key_properties": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"exportable": {
+										Type:     schema.TypeBool,
+										Required: true,
+										ForceNew: true,
+									},
+
+									"key_size": {
+										Type:     schema.TypeInt,
+										Required: true,
+										ForceNew: true,
+										ValidateFunc: validation.IntInSlice([]int{
+											2048,
+											3072,
+											4096,
+										}),
+									},
+
+									"key_type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(keyvault.RSA),
+										}, false),
+									},
+
+									"reuse_key": {
+										Type:     schema.TypeBool,
+										Required: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+
+						"lifetime_action": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"action": {
+										Type:     schema.TypeList,
+										Required: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"action_type": {
+													Type:     schema.TypeString,
+													Required: true,
+													ForceNew: true,
+													ValidateFunc: validation.StringInSlice([]string{
+														string(keyvault.AutoRenew),
+														string(keyvault.EmailContacts),
+													}, false),
+												},
+											},
+										},
+									},
+
+									"trigger": {
+										Type:     schema.TypeList,
+										Required: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"days_before_expiry": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													ForceNew:     true,
+													ValidateFunc: validation.IntAtLeast(1),
+												},
+
+												"lifetime_percentage": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													ForceNew:     true,
+													ValidateFunc: validation.IntBetween(1, 99),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+
+						"secret_properties": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"content_type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											"application/x-pkcs12",
+											"application/x-pem-file",
+										}, false),
+									},
+								},
+							},
+						},
+
+						"x509_certificate_properties": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"extended_key_usage": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Computed: true,
+										ForceNew: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+
+									"key_usage": {
+										Type:     schema.TypeSet,
+										Required: true,
+										ForceNew: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												string(keyvault.CRLSign),
+												string(keyvault.DataEncipherment),
+												string(keyvault.DecipherOnly),
+												string(keyvault.DigitalSignature),
+												string(keyvault.EncipherOnly),
+												string(keyvault.KeyAgreement),
+												string(keyvault.KeyCertSign),
+												string(keyvault.KeyEncipherment),
+												string(keyvault.NonRepudiation),
+											}, false),
+										},
+									},
+
+									"subject": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"subject_alternative_names": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Computed: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"emails": {
+													Type:     schema.TypeSet,
+													Optional: true,
+													ForceNew: true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+													Set: schema.HashString,
+												},
+
+												"dns_names": {
+													Type:     schema.TypeSet,
+													Optional: true,
+													ForceNew: true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+													Set: schema.HashString,
+												},
+
+												"upns": {
+													Type:     schema.TypeSet,
+													Optional: true,
+													ForceNew: true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+													Set: schema.HashString,
+												},
+											},
+										},
+									},
+
+									"validity_in_months": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			// Computed
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"versionless_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"secret_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"versionless_secret_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"certificate_data": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"certificate_data_base64": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"thumbprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"certificate_attribute": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"expires": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"not_before": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"recovery_level": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"updated": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+}
+
+func keyVaultCertificateCreate(d *schema.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	log.Print("[INFO] preparing arguments for AzureStack KeyVault Certificate creation.")
+
+	name := d.Get("name").(string)
+	keyVaultId, err := parse.VaultID(d.Get("key_vault_id").(string))
+	if err != nil {
+		return err
+	}
+
+	keyVaultBaseUrl, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("looking up Certificate %q vault url from id %q: %+v", name, *keyVaultId, err)
+	}
+
+	existing, err := client.GetCertificate(ctx, *keyVaultBaseUrl, name, "")
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing Certificate %q (Key Vault %q): %s", name, *keyVaultBaseUrl, err)
+		}
+	}
+
+	if existing.ID != nil && *existing.ID != "" {
+		return tf.ImportAsExistsError("azurestack_key_vault_certificate", *existing.ID)
+	}
+
+	t := d.Get("tags").(map[string]interface{})
+	policy := expandKeyVaultCertificatePolicy(d.Get("certificate_policy").([]interface{}))
+
+	if v, ok := d.GetOk("certificate"); ok {
+		certificate := expandKeyVaultCertificate(v.([]interface{}))
+		parameters := keyvault.CertificateImportParameters{
+			Base64EncodedCertificate: utils.String(certificate.contents),
+			Password:                 utils.String(certificate.password),
+			CertificatePolicy:        policy,
+			Tags:                     tags.Expand(t),
+		}
+
+		if _, err := client.ImportCertificate(ctx, *keyVaultBaseUrl, name, parameters); err != nil {
+			return fmt.Errorf("importing Certificate %q (Key Vault %q): %+v", name, *keyVaultBaseUrl, err)
+		}
+	} else {
+		if policy == nil {
+			return fmt.Errorf("either `certificate` or `certificate_policy` must be specified to create Certificate %q", name)
+		}
+
+		parameters := keyvault.CertificateCreateParameters{
+			CertificatePolicy: policy,
+			Tags:              tags.Expand(t),
+		}
+
+		if _, err := client.CreateCertificate(ctx, *keyVaultBaseUrl, name, parameters); err != nil {
+			return fmt.Errorf("creating Certificate %q (Key Vault %q): %+v", name, *keyVaultBaseUrl, err)
+		}
+
+		log.Printf("[DEBUG] Waiting for Certificate %q (Key Vault %q) to finish provisioning..", name, *keyVaultBaseUrl)
+		timeout, _ := ctx.Deadline()
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"inProgress"},
+			Target:     []string{"completed"},
+			Refresh:    keyVaultCertificateCreationRefreshFunc(ctx, client, *keyVaultBaseUrl, name),
+			MinTimeout: 15 * time.Second,
+			Timeout:    time.Until(timeout),
+		}
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("waiting for Certificate %q (Key Vault %q) to finish provisioning: %+v", name, *keyVaultBaseUrl, err)
+		}
+	}
+
+	// "" indicates the latest version
+	read, err := client.GetCertificate(ctx, *keyVaultBaseUrl, name, "")
+	if err != nil {
+		return err
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read KeyVault Certificate '%s' (in key vault '%s')", name, *keyVaultBaseUrl)
+	}
+
+	d.SetId(*read.ID)
+
+	return keyVaultCertificateRead(d, meta)
+}
+
+func keyVaultCertificateCreationRefreshFunc(ctx context.Context, client *keyvault.BaseClient, keyVaultBaseUrl string, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		log.Printf("[DEBUG] Checking the provisioning status of Certificate %q (Key Vault %q)", name, keyVaultBaseUrl)
+		operation, err := client.GetCertificateOperation(ctx, keyVaultBaseUrl, name)
+		if err != nil {
+			return nil, "", fmt.Errorf("retrieving the pending operation for Certificate %q (Key Vault %q): %+v", name, keyVaultBaseUrl, err)
+		}
+
+		if operation.Status == nil {
+			return nil, "", fmt.Errorf("the pending operation for Certificate %q (Key Vault %q) had no status", name, keyVaultBaseUrl)
+		}
+
+		if strings.EqualFold(*operation.Status, "failed") {
+			message := ""
+			if operation.Error != nil && operation.Error.Message != nil {
+				message = *operation.Error.Message
+			}
+			return nil, *operation.Status, fmt.Errorf("provisioning Certificate %q (Key Vault %q) failed: %s", name, keyVaultBaseUrl, message)
+		}
+
+		return operation, *operation.Status, nil
+	}
+}
+
+func keyVaultCertificateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ParseNestedItemID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChanges("tags", "tags_all") {
+		parameters := keyvault.CertificateUpdateParameters{
+			Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+		}
+
+		if _, err := client.UpdateCertificate(ctx, id.KeyVaultBaseUrl, id.Name, "", parameters); err != nil {
+			return fmt.Errorf("updating Certificate %q (Key Vault %q): %+v", id.Name, id.KeyVaultBaseUrl, err)
+		}
+	}
+
+	return keyVaultCertificateRead(d, meta)
+}
+
+func keyVaultCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	resourcesClient := meta.(*clients.Client).Resource
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ParseNestedItemID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	keyVaultIdRaw, err := keyVaultsClient.KeyVaultIDFromBaseUrl(ctx, resourcesClient, id.KeyVaultBaseUrl)
+	if err != nil {
+		return fmt.Errorf("Error retrieving the Resource ID the Key Vault at URL %q: %s", id.KeyVaultBaseUrl, err)
+	}
+	if keyVaultIdRaw == nil {
+		log.Printf("[DEBUG] Unable to determine the Resource ID for the Key Vault at URL %q - removing from state!", id.KeyVaultBaseUrl)
+		d.SetId("")
+		return nil
+	}
+	keyVaultId, err := parse.VaultID(*keyVaultIdRaw)
+	if err != nil {
+		return err
+	}
+
+	ok, err := keyVaultsClient.Exists(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("Error checking if key vault %q for Certificate %q in Vault at url %q exists: %v", *keyVaultId, id.Name, id.KeyVaultBaseUrl, err)
+	}
+	if !ok {
+		log.Printf("[DEBUG] Certificate %q Key Vault %q was not found in Key Vault at URI %q - removing from state", id.Name, *keyVaultId, id.KeyVaultBaseUrl)
+		d.SetId("")
+		return nil
+	}
+
+	// we always want to get the latest version
+	resp, err := client.GetCertificate(ctx, id.KeyVaultBaseUrl, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Certificate %q was not found in Key Vault at URI %q - removing from state", id.Name, id.KeyVaultBaseUrl)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error making Read request on Azure KeyVault Certificate %s: %+v", id.Name, err)
+	}
+
+	// the version may have changed, so parse the updated id
+	respID, err := parse.ParseNestedItemID(*resp.ID)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", respID.Name)
+	d.Set("key_vault_id", keyVaultId.ID())
+
+	if err := d.Set("certificate_policy", flattenKeyVaultCertificatePolicy(resp.Policy)); err != nil {
+		return fmt.Errorf("setting `certificate_policy`: %+v", err)
+	}
+
+	if err := d.Set("certificate_attribute", flattenKeyVaultCertificateAttribute(resp.Attributes)); err != nil {
+		return fmt.Errorf("setting `certificate_attribute`: %+v", err)
+	}
+
+	d.Set("version", respID.Version)
+	d.Set("versionless_id", fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(respID.KeyVaultBaseUrl, "/"), respID.NestedItemType, respID.Name))
+
+	secretId := ""
+	versionlessSecretId := ""
+	if resp.Sid != nil {
+		secretId = *resp.Sid
+		sid, err := parse.ParseOptionallyVersionedNestedItemID(*resp.Sid)
+		if err != nil {
+			return fmt.Errorf("parsing Secret ID %q: %+v", *resp.Sid, err)
+		}
+		versionlessSecretId = fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(sid.KeyVaultBaseUrl, "/"), sid.NestedItemType, sid.Name)
+	}
+	d.Set("secret_id", secretId)
+	d.Set("versionless_secret_id", versionlessSecretId)
+
+	certificateData := ""
+	certificateDataBase64 := ""
+	if contents := resp.Cer; contents != nil {
+		certificateData = strings.ToUpper(hex.EncodeToString(*contents))
+		certificateDataBase64 = base64.StdEncoding.EncodeToString(*contents)
+	}
+	d.Set("certificate_data", certificateData)
+	d.Set("certificate_data_base64", certificateDataBase64)
+
+	thumbprint, err := flattenKeyVaultCertificateThumbprint(resp.X509Thumbprint)
+	if err != nil {
+		return err
+	}
+	d.Set("thumbprint", thumbprint)
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func keyVaultCertificateDelete(d *schema.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	resourcesClient := meta.(*clients.Client).Resource
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ParseNestedItemID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	keyVaultIdRaw, err := keyVaultsClient.KeyVaultIDFromBaseUrl(ctx, resourcesClient, id.KeyVaultBaseUrl)
+	if err != nil {
+		return fmt.Errorf("Error retrieving the Resource ID the Key Vault at URL %q: %s", id.KeyVaultBaseUrl, err)
+	}
+	if keyVaultIdRaw == nil {
+		return fmt.Errorf("Unable to determine the Resource ID for the Key Vault at URL %q", id.KeyVaultBaseUrl)
+	}
+	keyVaultId, err := parse.VaultID(*keyVaultIdRaw)
+	if err != nil {
+		return err
+	}
+
+	ok, err := keyVaultsClient.Exists(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("Error checking if key vault %q for Certificate %q in Vault at url %q exists: %v", *keyVaultId, id.Name, id.KeyVaultBaseUrl, err)
+	}
+	if !ok {
+		log.Printf("[DEBUG] Certificate %q Key Vault %q was not found in Key Vault at URI %q - removing from state", id.Name, *keyVaultId, id.KeyVaultBaseUrl)
+		d.SetId("")
+		return nil
+	}
+
+	shouldPurge := meta.(*clients.Client).Features.KeyVault.PurgeSoftDeleteOnDestroy
+	description := fmt.Sprintf("Certificate %q (Key Vault %q)", id.Name, id.KeyVaultBaseUrl)
+	deleter := deleteAndPurgeCertificate{
+		client:      client,
+		keyVaultUri: id.KeyVaultBaseUrl,
+		name:        id.Name,
+	}
+	if err := deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ deleteAndPurgeNestedItem = deleteAndPurgeCertificate{}
+
+type deleteAndPurgeCertificate struct {
+	client      *keyvault.BaseClient
+	keyVaultUri string
+	name        string
+}
+
+func (d deleteAndPurgeCertificate) DeleteNestedItem(ctx context.Context) (autorest.Response, error) {
+	resp, err := d.client.DeleteCertificate(ctx, d.keyVaultUri, d.name)
+	return resp.Response, err
+}
+
+func (d deleteAndPurgeCertificate) NestedItemHasBeenDeleted(ctx context.Context) (autorest.Response, error) {
+	resp, err := d.client.GetCertificate(ctx, d.keyVaultUri, d.name, "")
+	return resp.Response, err
+}
+
+func (d deleteAndPurgeCertificate) PurgeNestedItem(ctx context.Context) (autorest.Response, error) {
+	return d.client.PurgeDeletedCertificate(ctx, d.keyVaultUri, d.name)
+}
+
+func (d deleteAndPurgeCertificate) NestedItemHasBeenPurged(ctx context.Context) (autorest.Response, error) {
+	resp, err := d.client.GetDeletedCertificate(ctx, d.keyVaultUri, d.name)
+	return resp.Response, err
+}
+
+type keyVaultCertificateImport struct {
+	contents string
+	password string
+}
+
+func expandKeyVaultCertificate(input []interface{}) keyVaultCertificateImport {
+	if len(input) == 0 || input[0] == nil {
+		return keyVaultCertificateImport{}
+	}
+
+	v := input[0].(map[string]interface{})
+	return keyVaultCertificateImport{
+		contents: v["contents"].(string),
+		password: v["password"].(string),
+	}
+}
+
+func expandKeyVaultCertificatePolicy(input []interface{}) *keyvault.CertificatePolicy {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	policyRaw := input[0].(map[string]interface{})
+	policy := keyvault.CertificatePolicy{}
+
+	if issuers := policyRaw["issuer_parameters"].([]interface{}); len(issuers) > 0 && issuers[0] != nil {
+		issuer := issuers[0].(map[string]interface{})
+		policy.IssuerParameters = &keyvault.IssuerParameters{
+			Name: utils.String(issuer["name"].(string)),
+		}
+	}
+
+	if properties := policyRaw["key_properties"].([]interface{}); len(properties) > 0 && properties[0] != nil {
+		props := properties[0].(map[string]interface{})
+		policy.KeyProperties = &keyvault.KeyProperties{
+			Exportable: utils.Bool(props["exportable"].(bool)),
+			KeySize:    utils.Int32(int32(props["key_size"].(int))),
+			KeyType:    utils.String(props["key_type"].(string)),
+			ReuseKey:   utils.Bool(props["reuse_key"].(bool)),
+		}
+	}
+
+	lifetimeActions := make([]keyvault.LifetimeAction, 0)
+	for _, v := range policyRaw["lifetime_action"].([]interface{}) {
+		if v == nil {
+			continue
+		}
+		action := v.(map[string]interface{})
+		lifetimeAction := keyvault.LifetimeAction{}
+
+		if actions := action["action"].([]interface{}); len(actions) > 0 && actions[0] != nil {
+			act := actions[0].(map[string]interface{})
+			lifetimeAction.Action = &keyvault.Action{
+				ActionType: keyvault.ActionType(act["action_type"].(string)),
+			}
+		}
+
+		if triggers := action["trigger"].([]interface{}); len(triggers) > 0 && triggers[0] != nil {
+			trigger := triggers[0].(map[string]interface{})
+			lifetimeAction.Trigger = &keyvault.Trigger{}
+
+			if days := trigger["days_before_expiry"].(int); days > 0 {
+				lifetimeAction.Trigger.DaysBeforeExpiry = utils.Int32(int32(days))
+			}
+
+			if percentage := trigger["lifetime_percentage"].(int); percentage > 0 {
+				lifetimeAction.Trigger.LifetimePercentage = utils.Int32(int32(percentage))
+			}
+		}
+
+		lifetimeActions = append(lifetimeActions, lifetimeAction)
+	}
+	policy.LifetimeActions = &lifetimeActions
+
+	if properties := policyRaw["secret_properties"].([]interface{}); len(properties) > 0 && properties[0] != nil {
+		props := properties[0].(map[string]interface{})
+		policy.SecretProperties = &keyvault.SecretProperties{
+			ContentType: utils.String(props["content_type"].(string)),
+		}
+	}
+
+	if properties := policyRaw["x509_certificate_properties"].([]interface{}); len(properties) > 0 && properties[0] != nil {
+		cert := properties[0].(map[string]interface{})
+
+		ekus := make([]string, 0)
+		for _, v := range cert["extended_key_usage"].([]interface{}) {
+			ekus = append(ekus, v.(string))
+		}
+
+		keyUsage := make([]keyvault.KeyUsageType, 0)
+		for _, v := range cert["key_usage"].(*schema.Set).List() {
+			keyUsage = append(keyUsage, keyvault.KeyUsageType(v.(string)))
+		}
+
+		policy.X509CertificateProperties = &keyvault.X509CertificateProperties{
+			Subject:                 utils.String(cert["subject"].(string)),
+			Ekus:                    &ekus,
+			KeyUsage:                &keyUsage,
+			SubjectAlternativeNames: expandKeyVaultCertificateSubjectAlternativeNames(cert["subject_alternative_names"].([]interface{})),
+			ValidityInMonths:        utils.Int32(int32(cert["validity_in_months"].(int))),
+		}
+	}
+
+	return &policy
+}
+
+func expandKeyVaultCertificateSubjectAlternativeNames(input []interface{}) *keyvault.SubjectAlternativeNames {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	sans := input[0].(map[string]interface{})
+
+	emails := make([]string, 0)
+	for _, v := range sans["emails"].(*schema.Set).List() {
+		emails = append(emails, v.(string))
+	}
+
+	dnsNames := make([]string, 0)
+	for _, v := range sans["dns_names"].(*schema.Set).List() {
+		dnsNames = append(dnsNames, v.(string))
+	}
+
+	upns := make([]string, 0)
+	for _, v := range sans["upns"].(*schema.Set).List() {
+		upns = append(upns, v.(string))
+	}
+
+	return &keyvault.SubjectAlternativeNames{
+		Emails:   &emails,
+		DNSNames: &dnsNames,
+		Upns:     &upns,
+	}
+}
+
+func flattenKeyVaultCertificatePolicy(input *keyvault.CertificatePolicy) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	issuerParameters := make([]interface{}, 0)
+	if issuer := input.IssuerParameters; issuer != nil {
+		name := ""
+		if issuer.Name != nil {
+			name = *issuer.Name
+		}
+		issuerParameters = append(issuerParameters, map[string]interface{}{
+			"name": name,
+		})
+	}
+
+	keyProperties := make([]interface{}, 0)
+	if props := input.KeyProperties; props != nil {
+		exportable := false
+		if props.Exportable != nil {
+			exportable = *props.Exportable
+		}
+		keySize := 0
+		if props.KeySize != nil {
+			keySize = int(*props.KeySize)
+		}
+		keyType := ""
+		if props.KeyType != nil {
+			keyType = *props.KeyType
+		}
+		reuseKey := false
+		if props.ReuseKey != nil {
+			reuseKey = *props.ReuseKey
+		}
+		keyProperties = append(keyProperties, map[string]interface{}{
+			"exportable": exportable,
+			"key_size":   keySize,
+			"key_type":   keyType,
+			"reuse_key":  reuseKey,
+		})
+	}
+
+	lifetimeActions := make([]interface{}, 0)
+	if input.LifetimeActions != nil {
+		for _, action := range *input.LifetimeActions {
+			actions := make([]interface{}, 0)
+			if act := action.Action; act != nil {
+				actions = append(actions, map[string]interface{}{
+					"action_type": string(act.ActionType),
+				})
+			}
+
+			triggers := make([]interface{}, 0)
+			if trigger := action.Trigger; trigger != nil {
+				daysBeforeExpiry := 0
+				if trigger.DaysBeforeExpiry != nil {
+					daysBeforeExpiry = int(*trigger.DaysBeforeExpiry)
+				}
+				lifetimePercentage := 0
+				if trigger.LifetimePercentage != nil {
+					lifetimePercentage = int(*trigger.LifetimePercentage)
+				}
+				triggers = append(triggers, map[string]interface{}{
+					"days_before_expiry":  daysBeforeExpiry,
+					"lifetime_percentage": lifetimePercentage,
+				})
+			}
+
+			lifetimeActions = append(lifetimeActions, map[string]interface{}{
+				"action":  actions,
+				"trigger": triggers,
+			})
+		}
+	}
+
+	secretProperties := make([]interface{}, 0)
+	if props := input.SecretProperties; props != nil {
+		contentType := ""
+		if props.ContentType != nil {
+			contentType = *props.ContentType
+		}
+		secretProperties = append(secretProperties, map[string]interface{}{
+			"content_type": contentType,
+		})
+	}
+
+	certificateProperties := make([]interface{}, 0)
+	if props := input.X509CertificateProperties; props != nil {
+		extendedKeyUsage := make([]interface{}, 0)
+		if props.Ekus != nil {
+			for _, v := range *props.Ekus {
+				extendedKeyUsage = append(extendedKeyUsage, v)
+			}
+		}
+
+		keyUsage := make([]interface{}, 0)
+		if props.KeyUsage != nil {
+			for _, v := range *props.KeyUsage {
+				keyUsage = append(keyUsage, string(v))
+			}
+		}
+
+		subject := ""
+		if props.Subject != nil {
+			subject = *props.Subject
+		}
+
+		validityInMonths := 0
+		if props.ValidityInMonths != nil {
+			validityInMonths = int(*props.ValidityInMonths)
+		}
+
+		certificateProperties = append(certificateProperties, map[string]interface{}{
+			"extended_key_usage":        extendedKeyUsage,
+			"key_usage":                 schema.NewSet(schema.HashString, keyUsage),
+			"subject":                   subject,
+			"subject_alternative_names": flattenKeyVaultCertificateSubjectAlternativeNames(props.SubjectAlternativeNames),
+			"validity_in_months":        validityInMonths,
+		})
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"issuer_parameters":           issuerParameters,
+			"key_properties":              keyProperties,
+			"lifetime_action":             lifetimeActions,
+			"secret_properties":           secretProperties,
+			"x509_certificate_properties": certificateProperties,
+		},
+	}
+}
+
+func flattenKeyVaultCertificateSubjectAlternativeNames(input *keyvault.SubjectAlternativeNames) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	emails := make([]interface{}, 0)
+	if input.Emails != nil {
+		for _, v := range *input.Emails {
+			emails = append(emails, v)
+		}
+	}
+
+	dnsNames := make([]interface{}, 0)
+	if input.DNSNames != nil {
+		for _, v := range *input.DNSNames {
+			dnsNames = append(dnsNames, v)
+		}
+	}
+
+	upns := make([]interface{}, 0)
+	if input.Upns != nil {
+		for _, v := range *input.Upns {
+			upns = append(upns, v)
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"emails":    schema.NewSet(schema.HashString, emails),
+			"dns_names": schema.NewSet(schema.HashString, dnsNames),
+			"upns":      schema.NewSet(schema.HashString, upns),
+		},
+	}
+}
+
+func flattenKeyVaultCertificateAttribute(input *keyvault.CertificateAttributes) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	created := ""
+	if input.Created != nil {
+		created = time.Time(*input.Created).Format(time.RFC3339)
+	}
+
+	enabled := false
+	if input.Enabled != nil {
+		enabled = *input.Enabled
+	}
+
+	expires := ""
+	if input.Expires != nil {
+		expires = time.Time(*input.Expires).Format(time.RFC3339)
+	}
+
+	notBefore := ""
+	if input.NotBefore != nil {
+		notBefore = time.Time(*input.NotBefore).Format(time.RFC3339)
+	}
+
+	updated := ""
+	if input.Updated != nil {
+		updated = time.Time(*input.Updated).Format(time.RFC3339)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"created":        created,
+			"enabled":        enabled,
+			"expires":        expires,
+			"not_before":     notBefore,
+			"recovery_level": string(input.RecoveryLevel),
+			"updated":        updated,
+		},
+	}
+}
+
+// flattenKeyVaultCertificateThumbprint converts the URL-encoded base64 thumbprint returned by
+// the API into the upper-case hex representation used by the Portal and most tooling
+func flattenKeyVaultCertificateThumbprint(input *string) (string, error) {
+	if input == nil {
+		return "", nil
+	}
+
+	thumbprint, err := base64.RawURLEncoding.DecodeString(*input)
+	if err != nil {
+		return "", fmt.Errorf("decoding the Certificate Thumbprint %q: %+v", *input, err)
+	}
+
+	return strings.ToUpper(hex.EncodeToString(thumbprint)), nil
+}
@@ -433,8 +433,7 @@ func keyVaultKeyDelete(d *schema.ResourceData, meta interface{}) error {
 		return nil
 	}
 
-	// shouldPurge := meta.(*clients.Client).Features.KeyVault.PurgeSoftDeleteOnDestroy
-	shouldPurge := false
+	shouldPurge := meta.(*clients.Client).Features.KeyVault.PurgeSoftDeleteOnDestroy
 	description := fmt.Sprintf("Key %q (Key Vault %q)", id.Name, id.KeyVaultBaseUrl)
 	deleter := deleteAndPurgeKey{
 		client:      client,
@@ -219,6 +219,21 @@ func keyVaultCreate(d *schema.ResourceData, meta interface{}) error {
 		return tf.ImportAsExistsError("azurestack_key_vault", id.ID())
 	}
 
+	// soft-deleted Key Vaults are only checked for when opted-in, since older stamps don't support this API
+	recoverSoftDeletedKeyVault := false
+	if meta.(*clients.Client).Features.KeyVault.RecoverSoftDeletedKeyVaults {
+		softDeletedKeyVault, err := client.GetDeleted(ctx, id.Name, location)
+		if err != nil {
+			// If Terraform lacks permission to read at the Subscription we'll get 403, not 404
+			if !utils.ResponseWasNotFound(softDeletedKeyVault.Response) && !utils.ResponseWasForbidden(softDeletedKeyVault.Response) {
+				return fmt.Errorf("checking for the presence of an existing Soft-Deleted Key Vault %q (Location %q): %+v", id.Name, location, err)
+			}
+		} else {
+			log.Printf("[DEBUG] Found an existing Soft-Deleted Key Vault %q (Location %q) - recovering", id.Name, location)
+			recoverSoftDeletedKeyVault = true
+		}
+	}
+
 	tenantUUID := uuid.FromStringOrNil(d.Get("tenant_id").(string))
 	enabledForDeployment := d.Get("enabled_for_deployment").(bool)
 	enabledForDiskEncryption := d.Get("enabled_for_disk_encryption").(bool)
@@ -257,6 +272,12 @@ func keyVaultCreate(d *schema.ResourceData, meta interface{}) error {
 		Tags: tags.Expand(t),
 	}
 
+	if recoverSoftDeletedKeyVault {
+		// soft delete can't be disabled on a Key Vault which is being recovered
+		parameters.Properties.CreateMode = keyvault.CreateModeRecover
+		parameters.Properties.EnableSoftDelete = nil
+	}
+
 	// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
 	virtualNetworkNames := make([]string, 0)
 	for _, v := range subnetIds {
@@ -425,7 +446,7 @@ func keyVaultUpdate(d *schema.ResourceData, meta interface{}) error {
 		update.Properties.TenantID = &tenantUUID
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		t := d.Get("tags").(map[string]interface{})
 		update.Tags = tags.Expand(t)
 	}
@@ -564,6 +585,22 @@ func keyVaultDelete(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	// purging is only possible when soft delete is enabled on the Key Vault
+	softDeleteEnabled := read.Properties.EnableSoftDelete != nil && *read.Properties.EnableSoftDelete
+	if meta.(*clients.Client).Features.KeyVault.PurgeSoftDeleteOnDestroy && softDeleteEnabled {
+		log.Printf("[DEBUG] %s marked for purge - executing purge", *id)
+		future, err := client.PurgeDeleted(ctx, id.Name, *read.Location)
+		if err != nil {
+			return fmt.Errorf("purging %s: %+v", *id, err)
+		}
+
+		log.Printf("[DEBUG] Waiting for purge of %s..", *id)
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("waiting for purge of %s: %+v", *id, err)
+		}
+		log.Printf("[DEBUG] Purged %s.", *id)
+	}
+
 	meta.(*clients.Client).KeyVault.Purge(*id)
 
 	return nil
@@ -355,7 +355,7 @@ func keyVaultSecretDelete(d *schema.ResourceData, meta interface{}) error {
 		return nil
 	}
 
-	shouldPurge := false
+	shouldPurge := meta.(*clients.Client).Features.KeyVault.PurgeSoftDeleteOnDestroy
 	description := fmt.Sprintf("Secret %q (Key Vault %q)", id.Name, id.KeyVaultBaseUrl)
 	deleter := deleteAndPurgeSecret{
 		client:      client,
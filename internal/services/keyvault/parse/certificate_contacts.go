@@ -0,0 +1,51 @@
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceid"
+)
+
+var _ resourceid.Formatter = CertificateContactsId{}
+
+type CertificateContactsId struct {
+	KeyVaultBaseUrl string
+}
+
+func NewCertificateContactsID(keyVaultBaseUrl string) (*CertificateContactsId, error) {
+	keyVaultUrl, err := url.Parse(keyVaultBaseUrl)
+	if err != nil || keyVaultBaseUrl == "" {
+		return nil, fmt.Errorf("parsing %q: %+v", keyVaultBaseUrl, err)
+	}
+	if hostParts := strings.Split(keyVaultUrl.Host, ":"); len(hostParts) > 1 {
+		keyVaultUrl.Host = hostParts[0]
+	}
+
+	return &CertificateContactsId{
+		KeyVaultBaseUrl: keyVaultUrl.String(),
+	}, nil
+}
+
+func (c CertificateContactsId) ID() string {
+	// example: https://tharvey-keyvault.vault.azure.net/certificates/contacts
+	return fmt.Sprintf("%s/certificates/contacts", strings.TrimSuffix(c.KeyVaultBaseUrl, "/"))
+}
+
+// CertificateContactsID parses a Key Vault Certificate Contacts ID into a CertificateContactsId object
+func CertificateContactsID(input string) (*CertificateContactsId, error) {
+	idURL, err := url.ParseRequestURI(input)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse Azure KeyVault Certificate Contacts Id: %s", err)
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(idURL.Path, "/"), "/")
+	if path != "certificates/contacts" {
+		return nil, fmt.Errorf("KeyVault Certificate Contacts should be in the format `certificates/contacts`, got %q", path)
+	}
+
+	return &CertificateContactsId{
+		KeyVaultBaseUrl: fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
+	}, nil
+}
@@ -0,0 +1,69 @@
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceid"
+)
+
+var _ resourceid.Formatter = IssuerId{}
+
+type IssuerId struct {
+	KeyVaultBaseUrl string
+	Name            string
+}
+
+func NewIssuerID(keyVaultBaseUrl, name string) (*IssuerId, error) {
+	keyVaultUrl, err := url.Parse(keyVaultBaseUrl)
+	if err != nil || keyVaultBaseUrl == "" {
+		return nil, fmt.Errorf("parsing %q: %+v", keyVaultBaseUrl, err)
+	}
+	if hostParts := strings.Split(keyVaultUrl.Host, ":"); len(hostParts) > 1 {
+		keyVaultUrl.Host = hostParts[0]
+	}
+
+	return &IssuerId{
+		KeyVaultBaseUrl: keyVaultUrl.String(),
+		Name:            name,
+	}, nil
+}
+
+func (i IssuerId) ID() string {
+	// example: https://tharvey-keyvault.vault.azure.net/certificates/issuers/bird
+	segments := []string{
+		strings.TrimSuffix(i.KeyVaultBaseUrl, "/"),
+		"certificates",
+		"issuers",
+		i.Name,
+	}
+	return strings.Join(segments, "/")
+}
+
+// IssuerID parses a Key Vault Certificate Issuer ID into an IssuerId object
+func IssuerID(input string) (*IssuerId, error) {
+	idURL, err := url.ParseRequestURI(input)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse Azure KeyVault Certificate Issuer Id: %s", err)
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(idURL.Path, "/"), "/")
+	components := strings.Split(path, "/")
+	if len(components) != 3 {
+		return nil, fmt.Errorf("KeyVault Certificate Issuer should contain 3 segments, got %d from %q", len(components), path)
+	}
+
+	if components[0] != "certificates" || components[1] != "issuers" {
+		return nil, fmt.Errorf("KeyVault Certificate Issuer should be in the format `certificates/issuers/{name}`, got %q", path)
+	}
+
+	if components[2] == "" {
+		return nil, fmt.Errorf("KeyVault Certificate Issuer name should not be empty in %q", path)
+	}
+
+	return &IssuerId{
+		KeyVaultBaseUrl: fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
+		Name:            components[2],
+	}, nil
+}
@@ -0,0 +1,108 @@
+package parse
+
+import "testing"
+
+func TestNewIssuerID(t *testing.T) {
+	cases := []struct {
+		Scenario        string
+		keyVaultBaseUrl string
+		Expected        string
+		ExpectError     bool
+	}{
+		{
+			Scenario:        "empty values",
+			keyVaultBaseUrl: "",
+			Expected:        "",
+			ExpectError:     true,
+		},
+		{
+			Scenario:        "valid, no port",
+			keyVaultBaseUrl: "https://test.vault.azure.net",
+			Expected:        "https://test.vault.azure.net/certificates/issuers/test",
+			ExpectError:     false,
+		},
+		{
+			Scenario:        "valid, with port",
+			keyVaultBaseUrl: "https://test.vault.azure.net:443",
+			Expected:        "https://test.vault.azure.net/certificates/issuers/test",
+			ExpectError:     false,
+		},
+	}
+	for _, tc := range cases {
+		id, err := NewIssuerID(tc.keyVaultBaseUrl, "test")
+		if err != nil {
+			if !tc.ExpectError {
+				t.Fatalf("Got error for New Resource ID '%s': %+v", tc.keyVaultBaseUrl, err)
+				return
+			}
+			continue
+		}
+		if id.ID() != tc.Expected {
+			t.Fatalf("Expected id for %q to be %q, got %q", tc.keyVaultBaseUrl, tc.Expected, id.ID())
+		}
+	}
+}
+
+func TestIssuerID(t *testing.T) {
+	cases := []struct {
+		Input       string
+		Expected    IssuerId
+		ExpectError bool
+	}{
+		{
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/certificates",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/certificates/issuers",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/certificates/bird/fdf067c93bbb4b22bff4d8b7a9a56217",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/certificates/issuers/bird",
+			ExpectError: false,
+			Expected: IssuerId{
+				Name:            "bird",
+				KeyVaultBaseUrl: "https://my-keyvault.vault.azure.net/",
+			},
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/certificates/issuers/bird/XXX",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		issuerId, err := IssuerID(tc.Input)
+		if err != nil {
+			if tc.ExpectError {
+				continue
+			}
+
+			t.Fatalf("Got error for ID '%s': %+v", tc.Input, err)
+		}
+
+		if tc.ExpectError {
+			t.Fatalf("Expected an error for ID '%s' but didn't get one", tc.Input)
+		}
+
+		if tc.Expected.KeyVaultBaseUrl != issuerId.KeyVaultBaseUrl {
+			t.Fatalf("Expected 'KeyVaultBaseUrl' to be '%s', got '%s' for ID '%s'", tc.Expected.KeyVaultBaseUrl, issuerId.KeyVaultBaseUrl, tc.Input)
+		}
+
+		if tc.Expected.Name != issuerId.Name {
+			t.Fatalf("Expected 'Name' to be '%s', got '%s' for ID '%s'", tc.Expected.Name, issuerId.Name, tc.Input)
+		}
+
+		if tc.Input != issuerId.ID() {
+			t.Fatalf("Expected 'ID()' to be '%s', got '%s'", tc.Input, issuerId.ID())
+		}
+	}
+}
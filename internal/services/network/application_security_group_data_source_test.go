@@ -0,0 +1,40 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type ApplicationSecurityGroupDataSource struct{}
+
+func TestAccApplicationSecurityGroupDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_application_security_group", "test")
+	r := ApplicationSecurityGroupDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("location").Exists(),
+				check.That(data.ResourceName).Key("name").Exists(),
+				check.That(data.ResourceName).Key("resource_group_name").Exists(),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags.Hello").HasValue("World"),
+			),
+		},
+	})
+}
+
+func (ApplicationSecurityGroupDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_application_security_group" "test" {
+  name                = azurestack_application_security_group.test.name
+  resource_group_name = azurestack_application_security_group.test.resource_group_name
+}
+`, ApplicationSecurityGroupResource{}.complete(data))
+}
@@ -82,6 +82,13 @@ func networkInterfaceDataSource() *pluginsdk.Resource {
 							Computed: true,
 						},
 
+						"application_security_group_ids": {
+							Type:     pluginsdk.TypeSet,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+							Set:      pluginsdk.HashString,
+						},
+
 						"application_gateway_backend_address_pools_ids": {
 							Type:     pluginsdk.TypeSet,
 							Computed: true,
@@ -7,7 +7,6 @@ import (
 
 type networkInterfaceUpdateInformation struct {
 	applicationGatewayBackendAddressPoolIDs []string
-	applicationSecurityGroupIDs             []string
 	loadBalancerBackendAddressPoolIDs       []string
 	loadBalancerInboundNatRuleIDs           []string
 	networkSecurityGroupID                  string
@@ -29,7 +28,6 @@ func parseFieldsFromNetworkInterface(input network.InterfacePropertiesFormat) ne
 		return output
 	}
 
-	applicationSecurityGroupIds := make(map[string]struct{})
 	applicationGatewayBackendAddressPoolIds := make(map[string]struct{})
 	loadBalancerBackendAddressPoolIds := make(map[string]struct{})
 	loadBalancerInboundNatRuleIds := make(map[string]struct{})
@@ -41,14 +39,6 @@ func parseFieldsFromNetworkInterface(input network.InterfacePropertiesFormat) ne
 			}
 
 			props := *v.InterfaceIPConfigurationPropertiesFormat
-			if props.ApplicationSecurityGroups != nil {
-				for _, asg := range *props.ApplicationSecurityGroups {
-					if asg.ID != nil {
-						applicationSecurityGroupIds[*asg.ID] = struct{}{}
-					}
-				}
-			}
-
 			if props.ApplicationGatewayBackendAddressPools != nil {
 				for _, pool := range *props.ApplicationGatewayBackendAddressPools {
 					if pool.ID != nil {
@@ -77,7 +67,6 @@ func parseFieldsFromNetworkInterface(input network.InterfacePropertiesFormat) ne
 
 	return networkInterfaceUpdateInformation{
 		applicationGatewayBackendAddressPoolIDs: mapToSlice(applicationGatewayBackendAddressPoolIds),
-		applicationSecurityGroupIDs:             mapToSlice(applicationSecurityGroupIds),
 		loadBalancerBackendAddressPoolIDs:       mapToSlice(loadBalancerBackendAddressPoolIds),
 		loadBalancerInboundNatRuleIDs:           mapToSlice(loadBalancerInboundNatRuleIds),
 		networkSecurityGroupID:                  networkSecurityGroupId,
@@ -87,13 +76,6 @@ func parseFieldsFromNetworkInterface(input network.InterfacePropertiesFormat) ne
 func mapFieldsToNetworkInterface(input *[]network.InterfaceIPConfiguration, info networkInterfaceUpdateInformation) *[]network.InterfaceIPConfiguration {
 	output := input
 
-	applicationSecurityGroups := make([]network.ApplicationSecurityGroup, 0)
-	for _, id := range info.applicationSecurityGroupIDs {
-		applicationSecurityGroups = append(applicationSecurityGroups, network.ApplicationSecurityGroup{
-			ID: pointer.FromString(id),
-		})
-	}
-
 	applicationGatewayBackendAddressPools := make([]network.ApplicationGatewayBackendAddressPool, 0)
 	for _, id := range info.applicationGatewayBackendAddressPoolIDs {
 		applicationGatewayBackendAddressPools = append(applicationGatewayBackendAddressPools, network.ApplicationGatewayBackendAddressPool{
@@ -124,7 +106,6 @@ func mapFieldsToNetworkInterface(input *[]network.InterfaceIPConfiguration, info
 			continue
 		}
 
-		config.ApplicationSecurityGroups = &applicationSecurityGroups
 		config.ApplicationGatewayBackendAddressPools = &applicationGatewayBackendAddressPools
 		config.LoadBalancerBackendAddressPools = &loadBalancerBackendAddressPools
 		config.LoadBalancerInboundNatRules = &loadBalancerInboundNatRules
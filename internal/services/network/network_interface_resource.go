@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/state"
@@ -104,6 +105,16 @@ func networkInterface() *pluginsdk.Resource {
 							ValidateFunc: resourceid.ValidateResourceIDOrEmpty,
 						},
 
+						"application_security_group_ids": {
+							Type:     pluginsdk.TypeSet,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validate.ApplicationSecurityGroupID,
+							},
+							Set: pluginsdk.HashString,
+						},
+
 						"primary": {
 							Type:     pluginsdk.TypeBool,
 							Optional: true,
@@ -324,7 +335,7 @@ func networkInterfaceUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		update.InterfacePropertiesFormat.IPConfigurations = existing.InterfacePropertiesFormat.IPConfigurations
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		tagsRaw := d.Get("tags").(map[string]interface{})
 		update.Tags = tags.Expand(tagsRaw)
 	} else {
@@ -520,6 +531,10 @@ func expandNetworkInterfaceIPConfigurations(input []interface{}) (*[]network.Int
 			properties.Primary = pointer.FromBool(v.(bool))
 		}
 
+		if v, ok := data["application_security_group_ids"]; ok {
+			properties.ApplicationSecurityGroups = expandNetworkSecurityRuleApplicationSecurityGroups(v.(*pluginsdk.Set).List())
+		}
+
 		name := data["name"].(string)
 		ipConfigs = append(ipConfigs, network.InterfaceIPConfiguration{
 			Name:                                     &name,
@@ -585,13 +600,14 @@ func flattenNetworkInterfaceIPConfigurations(input *[]network.InterfaceIPConfigu
 		}
 
 		result = append(result, map[string]interface{}{
-			"name":                          name,
-			"primary":                       primary,
-			"private_ip_address":            privateIPAddress,
-			"private_ip_address_allocation": string(props.PrivateIPAllocationMethod),
-			"private_ip_address_version":    privateIPAddressVersion,
-			"public_ip_address_id":          publicIPAddressId,
-			"subnet_id":                     subnetId,
+			"application_security_group_ids": flattenNetworkSecurityRuleApplicationSecurityGroups(props.ApplicationSecurityGroups),
+			"name":                           name,
+			"primary":                        primary,
+			"private_ip_address":             privateIPAddress,
+			"private_ip_address_allocation":  string(props.PrivateIPAllocationMethod),
+			"private_ip_address_version":     privateIPAddressVersion,
+			"public_ip_address_id":           publicIPAddressId,
+			"subnet_id":                      subnetId,
 		})
 	}
 	return result
@@ -30,6 +30,37 @@ func TestAccNetworkInterface_basic(t *testing.T) {
 	})
 }
 
+func TestAccNetworkInterface_applicationSecurityGroups(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_network_interface", "test")
+	r := NetworkInterfaceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.applicationSecurityGroups(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("ip_configuration.0.application_security_group_ids.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("ip_configuration.0.application_security_group_ids.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccNetworkInterface_disappears(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurestack_network_interface", "test")
 	r := NetworkInterfaceResource{}
@@ -293,6 +324,41 @@ resource "azurestack_network_interface" "test" {
 `, r.template(data), data.RandomInteger)
 }
 
+func (r NetworkInterfaceResource) applicationSecurityGroups(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_application_security_group" "first" {
+  name                = "acctest-first-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_application_security_group" "second" {
+  name                = "acctest-second-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_network_interface" "test" {
+  name                = "acctestni-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  ip_configuration {
+    name                          = "primary"
+    subnet_id                     = azurestack_subnet.test.id
+    private_ip_address_allocation = "Dynamic"
+
+    application_security_group_ids = [
+      azurestack_application_security_group.first.id,
+      azurestack_application_security_group.second.id,
+    ]
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
 func (r NetworkInterfaceResource) withMultipleParameters(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
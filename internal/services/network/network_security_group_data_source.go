@@ -100,6 +100,20 @@ func networkSecurityGroupDataSource() *pluginsdk.Resource {
 							Set:      pluginsdk.HashString,
 						},
 
+						"source_application_security_group_ids": {
+							Type:     pluginsdk.TypeSet,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+							Set:      pluginsdk.HashString,
+						},
+
+						"destination_application_security_group_ids": {
+							Type:     pluginsdk.TypeSet,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+							Set:      pluginsdk.HashString,
+						},
+
 						"access": {
 							Type:     pluginsdk.TypeString,
 							Computed: true,
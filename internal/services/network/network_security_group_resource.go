@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/set"
@@ -131,6 +132,26 @@ func networkSecurityGroup() *pluginsdk.Resource {
 							Set:      pluginsdk.HashString,
 						},
 
+						"source_application_security_group_ids": {
+							Type:     pluginsdk.TypeSet,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validate.ApplicationSecurityGroupID,
+							},
+							Set: pluginsdk.HashString,
+						},
+
+						"destination_application_security_group_ids": {
+							Type:     pluginsdk.TypeSet,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validate.ApplicationSecurityGroupID,
+							},
+							Set: pluginsdk.HashString,
+						},
+
 						"access": {
 							Type:     pluginsdk.TypeString,
 							Required: true,
@@ -347,6 +368,14 @@ func expandazurestackSecurityRules(d *pluginsdk.ResourceData) ([]network.Securit
 			properties.DestinationAddressPrefixes = &destinationAddressPrefixes
 		}
 
+		if r, ok := sgRule["source_application_security_group_ids"].(*pluginsdk.Set); ok && r.Len() > 0 {
+			properties.SourceApplicationSecurityGroups = expandNetworkSecurityRuleApplicationSecurityGroups(r.List())
+		}
+
+		if r, ok := sgRule["destination_application_security_group_ids"].(*pluginsdk.Set); ok && r.Len() > 0 {
+			properties.DestinationApplicationSecurityGroups = expandNetworkSecurityRuleApplicationSecurityGroups(r.List())
+		}
+
 		rules = append(rules, network.SecurityRule{
 			Name:                         &name,
 			SecurityRulePropertiesFormat: &properties,
@@ -396,6 +425,9 @@ func flattenNetworkSecurityRules(rules *[]network.SecurityRule) []map[string]int
 					sgRule["source_port_ranges"] = set.FromStringSlice(*props.SourcePortRanges)
 				}
 
+				sgRule["source_application_security_group_ids"] = pluginsdk.NewSet(pluginsdk.HashString, flattenNetworkSecurityRuleApplicationSecurityGroups(props.SourceApplicationSecurityGroups))
+				sgRule["destination_application_security_group_ids"] = pluginsdk.NewSet(pluginsdk.HashString, flattenNetworkSecurityRuleApplicationSecurityGroups(props.DestinationApplicationSecurityGroups))
+
 				sgRule["protocol"] = string(props.Protocol)
 				sgRule["priority"] = int(*props.Priority)
 				sgRule["access"] = string(props.Access)
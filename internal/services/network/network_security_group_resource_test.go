@@ -60,6 +60,21 @@ func TestAccNetworkSecurityGroup_singleRule(t *testing.T) {
 	})
 }
 
+func TestAccNetworkSecurityGroup_applicationSecurityGroups(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_network_security_group", "test")
+	r := NetworkSecurityGroupResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.applicationSecurityGroups(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("security_rule.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccNetworkSecurityGroup_update(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurestack_network_security_group", "test")
 	r := NetworkSecurityGroupResource{}
@@ -310,6 +325,49 @@ resource "azurestack_network_security_group" "test" {
 `, data.RandomInteger, data.Locations.Primary)
 }
 
+func (NetworkSecurityGroupResource) applicationSecurityGroups(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_application_security_group" "first" {
+  name                = "acctest-first%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_application_security_group" "second" {
+  name                = "acctest-second%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_network_security_group" "test" {
+  name                = "acctestnsg-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  security_rule {
+    name                                       = "test123"
+    priority                                   = 100
+    direction                                  = "Inbound"
+    access                                     = "Allow"
+    protocol                                   = "Tcp"
+    source_application_security_group_ids      = [azurestack_application_security_group.first.id]
+    destination_application_security_group_ids = [azurestack_application_security_group.second.id]
+    source_port_range                          = "*"
+    destination_port_range                     = "*"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
 func (NetworkSecurityGroupResource) anotherRule(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurestack" {
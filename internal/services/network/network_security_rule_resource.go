@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/suppress"
@@ -125,6 +126,26 @@ func networkSecurityRule() *pluginsdk.Resource {
 				ConflictsWith: []string{"destination_address_prefix"},
 			},
 
+			"source_application_security_group_ids": {
+				Type:     pluginsdk.TypeSet,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validate.ApplicationSecurityGroupID,
+				},
+				Set: pluginsdk.HashString,
+			},
+
+			"destination_application_security_group_ids": {
+				Type:     pluginsdk.TypeSet,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validate.ApplicationSecurityGroupID,
+				},
+				Set: pluginsdk.HashString,
+			},
+
 			"access": {
 				Type:     pluginsdk.TypeString,
 				Required: true,
@@ -249,6 +270,14 @@ func networkSecurityRuleCreateUpdate(d *pluginsdk.ResourceData, meta interface{}
 		rule.SecurityRulePropertiesFormat.DestinationAddressPrefixes = &destinationAddressPrefixes
 	}
 
+	if r, ok := d.GetOk("source_application_security_group_ids"); ok {
+		rule.SecurityRulePropertiesFormat.SourceApplicationSecurityGroups = expandNetworkSecurityRuleApplicationSecurityGroups(r.(*pluginsdk.Set).List())
+	}
+
+	if r, ok := d.GetOk("destination_application_security_group_ids"); ok {
+		rule.SecurityRulePropertiesFormat.DestinationApplicationSecurityGroups = expandNetworkSecurityRuleApplicationSecurityGroups(r.(*pluginsdk.Set).List())
+	}
+
 	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.NetworkSecurityGroupName, id.Name, rule)
 	if err != nil {
 		return fmt.Errorf("creating/updating %s: %+v", id, err)
@@ -300,6 +329,14 @@ func networkSecurityRuleRead(d *pluginsdk.ResourceData, meta interface{}) error
 		d.Set("access", string(props.Access))
 		d.Set("priority", int(*props.Priority))
 		d.Set("direction", string(props.Direction))
+
+		if err := d.Set("source_application_security_group_ids", flattenNetworkSecurityRuleApplicationSecurityGroups(props.SourceApplicationSecurityGroups)); err != nil {
+			return fmt.Errorf("setting `source_application_security_group_ids`: %+v", err)
+		}
+
+		if err := d.Set("destination_application_security_group_ids", flattenNetworkSecurityRuleApplicationSecurityGroups(props.DestinationApplicationSecurityGroups)); err != nil {
+			return fmt.Errorf("setting `destination_application_security_group_ids`: %+v", err)
+		}
 	}
 
 	return nil
@@ -332,3 +369,27 @@ func networkSecurityRuleDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 
 	return nil
 }
+
+func expandNetworkSecurityRuleApplicationSecurityGroups(input []interface{}) *[]network.ApplicationSecurityGroup {
+	output := make([]network.ApplicationSecurityGroup, 0)
+	for _, v := range input {
+		output = append(output, network.ApplicationSecurityGroup{
+			ID: utils.String(v.(string)),
+		})
+	}
+	return &output
+}
+
+func flattenNetworkSecurityRuleApplicationSecurityGroups(input *[]network.ApplicationSecurityGroup) []interface{} {
+	output := make([]interface{}, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		if v.ID != nil {
+			output = append(output, *v.ID)
+		}
+	}
+	return output
+}
@@ -95,6 +95,23 @@ func TestAccNetworkSecurityRule_augmented(t *testing.T) {
 	})
 }
 
+func TestAccNetworkSecurityRule_applicationSecurityGroups(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_network_security_rule", "test")
+	r := NetworkSecurityRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.applicationSecurityGroups(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("source_application_security_group_ids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("destination_application_security_group_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (t NetworkSecurityRuleResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.SecurityRuleID(state.ID)
 	if err != nil {
@@ -162,6 +179,51 @@ resource "azurestack_network_security_rule" "test" {
 `, data.RandomInteger, data.Locations.Primary)
 }
 
+func (NetworkSecurityRuleResource) applicationSecurityGroups(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_application_security_group" "first" {
+  name                = "acctest-first%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+}
+
+resource "azurestack_application_security_group" "second" {
+  name                = "acctest-second%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+}
+
+resource "azurestack_network_security_group" "test" {
+  name                = "acctestnsg-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_network_security_rule" "test" {
+  name                                       = "test123"
+  resource_group_name                        = azurestack_resource_group.test.name
+  network_security_group_name                = azurestack_network_security_group.test.name
+  priority                                   = 100
+  direction                                  = "Outbound"
+  access                                     = "Allow"
+  protocol                                   = "Tcp"
+  source_application_security_group_ids      = [azurestack_application_security_group.first.id]
+  destination_application_security_group_ids = [azurestack_application_security_group.second.id]
+  source_port_ranges                         = ["10000-40000"]
+  destination_port_ranges                    = ["80", "443", "8080", "8190"]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
 func (r NetworkSecurityRuleResource) requiresImport(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
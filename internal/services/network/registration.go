@@ -21,6 +21,7 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
+		"azurestack_application_security_group":         applicationSecurityGroupDataSource(),
 		"azurestack_network_interface":                  networkInterfaceDataSource(),
 		"azurestack_public_ip":                          publicIPDataSource(),
 		"azurestack_public_ips":                         publicIPsDataSource(),
@@ -37,11 +38,14 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
+		"azurestack_application_security_group":                         applicationSecurityGroup(),
 		"azurestack_network_interface":                                  networkInterface(),
 		"azurestack_public_ip":                                          publicIp(),
 		"azurestack_route_table":                                        routeTable(),
 		"azurestack_route":                                              resourceRoute(),
 		"azurestack_subnet":                                             subnet(),
+		"azurestack_subnet_network_security_group_association":          subnetNetworkSecurityGroupAssociation(),
+		"azurestack_subnet_route_table_association":                     subnetRouteTableAssociation(),
 		"azurestack_virtual_network":                                    virtualNetwork(),
 		"azurestack_network_security_group":                             networkSecurityGroup(),
 		"azurestack_network_security_rule":                              networkSecurityRule(),
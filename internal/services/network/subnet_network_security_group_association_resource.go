@@ -0,0 +1,243 @@
+package network
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func subnetNetworkSecurityGroupAssociation() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: subnetNetworkSecurityGroupAssociationCreate,
+		Read:   subnetNetworkSecurityGroupAssociationRead,
+		Delete: subnetNetworkSecurityGroupAssociationDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, _, err := parseSubnetNetworkSecurityGroupAssociationID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"subnet_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SubnetID,
+			},
+
+			"network_security_group_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NetworkSecurityGroupID,
+			},
+		},
+	}
+}
+
+func subnetNetworkSecurityGroupAssociationCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.SubnetsClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	log.Printf("[INFO] preparing arguments for Subnet <-> Network Security Group Association creation.")
+
+	subnetId, err := parse.SubnetID(d.Get("subnet_id").(string))
+	if err != nil {
+		return err
+	}
+
+	networkSecurityGroupId, err := parse.NetworkSecurityGroupID(d.Get("network_security_group_id").(string))
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(networkSecurityGroupId.Name, networkSecurityGroupResourceName)
+	defer locks.UnlockByName(networkSecurityGroupId.Name, networkSecurityGroupResourceName)
+
+	locks.ByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
+	defer locks.UnlockByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
+
+	locks.ByName(subnetId.Name, SubnetResourceName)
+	defer locks.UnlockByName(subnetId.Name, SubnetResourceName)
+
+	subnet, err := client.Get(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(subnet.Response) {
+			return fmt.Errorf("%s was not found", *subnetId)
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *subnetId, err)
+	}
+
+	resourceId := fmt.Sprintf("%s|%s", subnetId.ID(), networkSecurityGroupId.ID())
+
+	if props := subnet.SubnetPropertiesFormat; props != nil {
+		if nsg := props.NetworkSecurityGroup; nsg != nil {
+			// we're intentionally not checking the ID - if there's an NSG, it needs to be imported
+			if nsg.ID != nil && subnet.ID != nil {
+				return tf.ImportAsExistsError("azurestack_subnet_network_security_group_association", resourceId)
+			}
+		}
+
+		props.NetworkSecurityGroup = &network.SecurityGroup{
+			ID: pointer.FromString(networkSecurityGroupId.ID()),
+		}
+	}
+
+	future, err := client.CreateOrUpdate(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, subnet)
+	if err != nil {
+		return fmt.Errorf("updating Network Security Group Association for %s: %+v", *subnetId, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for completion of Network Security Group Association for %s: %+v", *subnetId, err)
+	}
+
+	timeout, _ := ctx.Deadline()
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending:    []string{string(network.Updating)},
+		Target:     []string{string(network.Succeeded)},
+		Refresh:    SubnetProvisioningStateRefreshFunc(ctx, client, *subnetId),
+		MinTimeout: 1 * time.Minute,
+		Timeout:    time.Until(timeout),
+	}
+	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for provisioning state of %s: %+v", *subnetId, err)
+	}
+
+	d.SetId(resourceId)
+
+	return subnetNetworkSecurityGroupAssociationRead(d, meta)
+}
+
+func subnetNetworkSecurityGroupAssociationRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.SubnetsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	subnetId, networkSecurityGroupId, err := parseSubnetNetworkSecurityGroupAssociationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	subnet, err := client.Get(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(subnet.Response) {
+			log.Printf("[DEBUG] %s could not be found - removing from state!", *subnetId)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *subnetId, err)
+	}
+
+	props := subnet.SubnetPropertiesFormat
+	if props == nil {
+		return fmt.Errorf("`properties` was nil for %s", *subnetId)
+	}
+
+	nsg := props.NetworkSecurityGroup
+	if nsg == nil || nsg.ID == nil || !strings.EqualFold(*nsg.ID, networkSecurityGroupId.ID()) {
+		log.Printf("[DEBUG] %s is not associated with %s - removing from state!", *subnetId, *networkSecurityGroupId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("subnet_id", subnetId.ID())
+	d.Set("network_security_group_id", networkSecurityGroupId.ID())
+
+	return nil
+}
+
+func subnetNetworkSecurityGroupAssociationDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.SubnetsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	subnetId, networkSecurityGroupId, err := parseSubnetNetworkSecurityGroupAssociationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(networkSecurityGroupId.Name, networkSecurityGroupResourceName)
+	defer locks.UnlockByName(networkSecurityGroupId.Name, networkSecurityGroupResourceName)
+
+	locks.ByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
+	defer locks.UnlockByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
+
+	locks.ByName(subnetId.Name, SubnetResourceName)
+	defer locks.UnlockByName(subnetId.Name, SubnetResourceName)
+
+	read, err := client.Get(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(read.Response) {
+			log.Printf("[DEBUG] %s could not be found - removing from state!", *subnetId)
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *subnetId, err)
+	}
+
+	props := read.SubnetPropertiesFormat
+	if props == nil {
+		return fmt.Errorf("`properties` was nil for %s", *subnetId)
+	}
+
+	if props.NetworkSecurityGroup == nil || props.NetworkSecurityGroup.ID == nil {
+		log.Printf("[DEBUG] %s has no Network Security Group - nothing to remove", *subnetId)
+		return nil
+	}
+
+	props.NetworkSecurityGroup = nil
+	read.SubnetPropertiesFormat = props
+
+	future, err := client.CreateOrUpdate(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, read)
+	if err != nil {
+		return fmt.Errorf("removing Network Security Group Association from %s: %+v", *subnetId, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for removal of Network Security Group Association from %s: %+v", *subnetId, err)
+	}
+
+	return nil
+}
+
+func parseSubnetNetworkSecurityGroupAssociationID(input string) (*parse.SubnetId, *parse.NetworkSecurityGroupId, error) {
+	splitId := strings.Split(input, "|")
+	if len(splitId) != 2 {
+		return nil, nil, fmt.Errorf("expected ID to be in the format {subnetId}|{networkSecurityGroupId} but got %q", input)
+	}
+
+	subnetId, err := parse.SubnetID(splitId[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	networkSecurityGroupId, err := parse.NetworkSecurityGroupID(splitId[1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return subnetId, networkSecurityGroupId, nil
+}
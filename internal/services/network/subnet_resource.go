@@ -58,6 +58,18 @@ func subnet() *pluginsdk.Resource {
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
 
+			// these are managed via the `azurestack_subnet_network_security_group_association`
+			// and `azurestack_subnet_route_table_association` resources
+			"network_security_group_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"route_table_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			/*
 
 				TODO do we put back?
@@ -174,8 +186,6 @@ func subnetUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return fmt.Errorf("retrieving %s: `properties` was nil", *id)
 	}
 
-	// TODO: locking on the NSG/Route Table if applicable
-
 	props := *existing.SubnetPropertiesFormat
 
 	if d.HasChange("address_prefix") {
@@ -249,6 +259,18 @@ func subnetRead(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	if props := resp.SubnetPropertiesFormat; props != nil {
 		d.Set("address_prefix", props.AddressPrefix)
+
+		networkSecurityGroupId := ""
+		if props.NetworkSecurityGroup != nil && props.NetworkSecurityGroup.ID != nil {
+			networkSecurityGroupId = *props.NetworkSecurityGroup.ID
+		}
+		d.Set("network_security_group_id", networkSecurityGroupId)
+
+		routeTableId := ""
+		if props.RouteTable != nil && props.RouteTable.ID != nil {
+			routeTableId = *props.RouteTable.ID
+		}
+		d.Set("route_table_id", routeTableId)
 	}
 
 	return nil
@@ -0,0 +1,243 @@
+package network
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func subnetRouteTableAssociation() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: subnetRouteTableAssociationCreate,
+		Read:   subnetRouteTableAssociationRead,
+		Delete: subnetRouteTableAssociationDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, _, err := parseSubnetRouteTableAssociationID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"subnet_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SubnetID,
+			},
+
+			"route_table_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.RouteTableID,
+			},
+		},
+	}
+}
+
+func subnetRouteTableAssociationCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.SubnetsClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	log.Printf("[INFO] preparing arguments for Subnet <-> Route Table Association creation.")
+
+	subnetId, err := parse.SubnetID(d.Get("subnet_id").(string))
+	if err != nil {
+		return err
+	}
+
+	routeTableId, err := parse.RouteTableID(d.Get("route_table_id").(string))
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(routeTableId.Name, routeTableResourceName)
+	defer locks.UnlockByName(routeTableId.Name, routeTableResourceName)
+
+	locks.ByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
+	defer locks.UnlockByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
+
+	locks.ByName(subnetId.Name, SubnetResourceName)
+	defer locks.UnlockByName(subnetId.Name, SubnetResourceName)
+
+	subnet, err := client.Get(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(subnet.Response) {
+			return fmt.Errorf("%s was not found", *subnetId)
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *subnetId, err)
+	}
+
+	resourceId := fmt.Sprintf("%s|%s", subnetId.ID(), routeTableId.ID())
+
+	if props := subnet.SubnetPropertiesFormat; props != nil {
+		if rt := props.RouteTable; rt != nil {
+			// we're intentionally not checking the ID - if there's a Route Table, it needs to be imported
+			if rt.ID != nil && subnet.ID != nil {
+				return tf.ImportAsExistsError("azurestack_subnet_route_table_association", resourceId)
+			}
+		}
+
+		props.RouteTable = &network.RouteTable{
+			ID: pointer.FromString(routeTableId.ID()),
+		}
+	}
+
+	future, err := client.CreateOrUpdate(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, subnet)
+	if err != nil {
+		return fmt.Errorf("updating Route Table Association for %s: %+v", *subnetId, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for completion of Route Table Association for %s: %+v", *subnetId, err)
+	}
+
+	timeout, _ := ctx.Deadline()
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending:    []string{string(network.Updating)},
+		Target:     []string{string(network.Succeeded)},
+		Refresh:    SubnetProvisioningStateRefreshFunc(ctx, client, *subnetId),
+		MinTimeout: 1 * time.Minute,
+		Timeout:    time.Until(timeout),
+	}
+	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for provisioning state of %s: %+v", *subnetId, err)
+	}
+
+	d.SetId(resourceId)
+
+	return subnetRouteTableAssociationRead(d, meta)
+}
+
+func subnetRouteTableAssociationRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.SubnetsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	subnetId, routeTableId, err := parseSubnetRouteTableAssociationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	subnet, err := client.Get(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(subnet.Response) {
+			log.Printf("[DEBUG] %s could not be found - removing from state!", *subnetId)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *subnetId, err)
+	}
+
+	props := subnet.SubnetPropertiesFormat
+	if props == nil {
+		return fmt.Errorf("`properties` was nil for %s", *subnetId)
+	}
+
+	routeTable := props.RouteTable
+	if routeTable == nil || routeTable.ID == nil || !strings.EqualFold(*routeTable.ID, routeTableId.ID()) {
+		log.Printf("[DEBUG] %s is not associated with %s - removing from state!", *subnetId, *routeTableId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("subnet_id", subnetId.ID())
+	d.Set("route_table_id", routeTableId.ID())
+
+	return nil
+}
+
+func subnetRouteTableAssociationDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.SubnetsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	subnetId, routeTableId, err := parseSubnetRouteTableAssociationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(routeTableId.Name, routeTableResourceName)
+	defer locks.UnlockByName(routeTableId.Name, routeTableResourceName)
+
+	locks.ByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
+	defer locks.UnlockByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
+
+	locks.ByName(subnetId.Name, SubnetResourceName)
+	defer locks.UnlockByName(subnetId.Name, SubnetResourceName)
+
+	read, err := client.Get(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(read.Response) {
+			log.Printf("[DEBUG] %s could not be found - removing from state!", *subnetId)
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *subnetId, err)
+	}
+
+	props := read.SubnetPropertiesFormat
+	if props == nil {
+		return fmt.Errorf("`properties` was nil for %s", *subnetId)
+	}
+
+	if props.RouteTable == nil || props.RouteTable.ID == nil {
+		log.Printf("[DEBUG] %s has no Route Table - nothing to remove", *subnetId)
+		return nil
+	}
+
+	props.RouteTable = nil
+	read.SubnetPropertiesFormat = props
+
+	future, err := client.CreateOrUpdate(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, read)
+	if err != nil {
+		return fmt.Errorf("removing Route Table Association from %s: %+v", *subnetId, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for removal of Route Table Association from %s: %+v", *subnetId, err)
+	}
+
+	return nil
+}
+
+func parseSubnetRouteTableAssociationID(input string) (*parse.SubnetId, *parse.RouteTableId, error) {
+	splitId := strings.Split(input, "|")
+	if len(splitId) != 2 {
+		return nil, nil, fmt.Errorf("expected ID to be in the format {subnetId}|{routeTableId} but got %q", input)
+	}
+
+	subnetId, err := parse.SubnetID(splitId[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	routeTableId, err := parse.RouteTableID(splitId[1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return subnetId, routeTableId, nil
+}
@@ -0,0 +1,175 @@
+package network_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+type SubnetRouteTableAssociationResource struct{}
+
+func TestAccSubnetRouteTableAssociation_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_subnet_route_table_association", "test")
+	r := SubnetRouteTableAssociationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azurestack_subnet.test").Key("route_table_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSubnetRouteTableAssociation_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_subnet_route_table_association", "test")
+	r := SubnetRouteTableAssociationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.requiresImport(data),
+			ExpectError: acceptance.RequiresImportError("azurestack_subnet_route_table_association"),
+		},
+	})
+}
+
+func TestAccSubnetRouteTableAssociation_withNetworkSecurityGroup(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_subnet_route_table_association", "test")
+	r := SubnetRouteTableAssociationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.withNetworkSecurityGroup(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azurestack_subnet_network_security_group_association.test").ExistsInAzure(SubnetNetworkSecurityGroupAssociationResource{}),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (SubnetRouteTableAssociationResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	splitId := strings.Split(state.ID, "|")
+	if len(splitId) != 2 {
+		return nil, fmt.Errorf("expected ID to be in the format {subnetId}|{routeTableId} but got %q", state.ID)
+	}
+
+	id, err := parse.SubnetID(splitId[0])
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Network.SubnetsClient.Get(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %+v", *id, err)
+	}
+
+	props := resp.SubnetPropertiesFormat
+	if props == nil || props.RouteTable == nil || props.RouteTable.ID == nil {
+		return pointer.FromBool(false), nil
+	}
+
+	return pointer.FromBool(strings.EqualFold(*props.RouteTable.ID, splitId[1])), nil
+}
+
+func (r SubnetRouteTableAssociationResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_subnet_route_table_association" "test" {
+  subnet_id      = azurestack_subnet.test.id
+  route_table_id = azurestack_route_table.test.id
+}
+`, r.template(data))
+}
+
+func (r SubnetRouteTableAssociationResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_subnet_route_table_association" "import" {
+  subnet_id      = azurestack_subnet_route_table_association.test.subnet_id
+  route_table_id = azurestack_subnet_route_table_association.test.route_table_id
+}
+`, r.basic(data))
+}
+
+func (r SubnetRouteTableAssociationResource) withNetworkSecurityGroup(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_network_security_group" "test" {
+  name                = "acctestnsg%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_subnet_network_security_group_association" "test" {
+  subnet_id                 = azurestack_subnet.test.id
+  network_security_group_id = azurestack_network_security_group.test.id
+}
+
+resource "azurestack_subnet_route_table_association" "test" {
+  subnet_id      = azurestack_subnet.test.id
+  route_table_id = azurestack_route_table.test.id
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (SubnetRouteTableAssociationResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_virtual_network" "test" {
+  name                = "acctestvirtnet%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_subnet" "test" {
+  name                 = "internal"
+  resource_group_name  = azurestack_resource_group.test.name
+  virtual_network_name = azurestack_virtual_network.test.name
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurestack_route_table" "test" {
+  name                = "acctestrt%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  route {
+    name                   = "first"
+    address_prefix         = "10.100.0.0/14"
+    next_hop_type          = "VirtualAppliance"
+    next_hop_in_ip_address = "10.10.1.1"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
@@ -6,15 +6,19 @@ import (
 )
 
 type Client struct {
-	DeploymentsClient *resources.DeploymentsClient
-	GroupsClient      *resources.GroupsClient
-	ProvidersClient   *resources.ProvidersClient
-	ResourcesClient   *resources.Client
+	DeploymentOperationsClient *resources.DeploymentOperationsClient
+	DeploymentsClient          *resources.DeploymentsClient
+	GroupsClient               *resources.GroupsClient
+	ProvidersClient            *resources.ProvidersClient
+	ResourcesClient            *resources.Client
 
 	options *common.ClientOptions
 }
 
 func NewClient(o *common.ClientOptions) *Client {
+	deploymentOperationsClient := resources.NewDeploymentOperationsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&deploymentOperationsClient.Client, o.ResourceManagerAuthorizer)
+
 	deploymentsClient := resources.NewDeploymentsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&deploymentsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -29,10 +33,11 @@ func NewClient(o *common.ClientOptions) *Client {
 	o.ConfigureClient(&resourcesClient.Client, o.ResourceManagerAuthorizer)
 
 	return &Client{
-		DeploymentsClient: &deploymentsClient,
-		GroupsClient:      &groupsClient,
-		ProvidersClient:   &providersClient,
-		ResourcesClient:   &resourcesClient,
+		DeploymentOperationsClient: &deploymentOperationsClient,
+		DeploymentsClient:          &deploymentsClient,
+		GroupsClient:               &groupsClient,
+		ProvidersClient:            &providersClient,
+		ResourcesClient:            &resourcesClient,
 
 		options: o,
 	}
@@ -241,6 +241,12 @@ func templateDeploymentDelete(d *schema.ResourceData, meta interface{}) error {
 	resourceGroup := id.ResourceGroup
 	name := id.Path["deployments"]
 
+	if meta.(*clients.Client).Features.TemplateDeployment.DeleteNestedItemsDuringDeletion {
+		if err := deleteItemsProvisionedByTemplate(ctx, meta.(*clients.Client).Resource, resourceGroup, name); err != nil {
+			return fmt.Errorf("deleting Nested Items from Template Deployment %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
 	if _, err = client.Delete(ctx, resourceGroup, name); err != nil {
 		return fmt.Errorf("deleting Template Deployment %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
@@ -48,5 +50,7 @@ func resourceGroupDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) er
 	// but needs to be fixed (resourcegroups -> resourceGroups)
 	d.SetId(*resp.ID)
 
-	return resourceGroupRead(d, meta)
+	d.Set("name", resp.Name)
+	d.Set("location", location.NormalizeNilable(resp.Location))
+	return tags.FlattenAndSet(d, resp.Tags)
 }
@@ -1,8 +1,11 @@
 package resource
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/resources/mgmt/resources"
@@ -10,157 +13,232 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/resource/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/resource/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/sdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
 
-func resourceGroup() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Create: resourceGroupCreateUpdate,
-		Read:   resourceGroupRead,
-		Update: resourceGroupCreateUpdate,
-		Delete: resourceGroupDelete,
-
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.ResourceGroupID(id)
-			return err
-		}),
-
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Create: pluginsdk.DefaultTimeout(90 * time.Minute),
-			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
-			Update: pluginsdk.DefaultTimeout(90 * time.Minute),
-			Delete: pluginsdk.DefaultTimeout(90 * time.Minute),
-		},
+type ResourceGroupResourceModel struct {
+	Name     string            `tfschema:"name"`
+	Location string            `tfschema:"location"`
+	Tags     map[string]string `tfschema:"tags"`
+}
 
-		Schema: map[string]*pluginsdk.Schema{
-			"name": commonschema.ResourceGroupName(),
+type ResourceGroupResource struct{}
 
-			"location": commonschema.Location(),
+var _ sdk.ResourceWithUpdate = ResourceGroupResource{}
 
-			"tags": commonschema.Tags(),
-		},
+func (r ResourceGroupResource) ResourceType() string {
+	return "azurestack_resource_group"
+}
+
+func (r ResourceGroupResource) ModelObject() interface{} {
+	return &ResourceGroupResourceModel{}
+}
+
+func (r ResourceGroupResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ResourceGroupID
+}
+
+func (r ResourceGroupResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"tags": commonschema.Tags(),
 	}
 }
 
-func resourceGroupCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Resource.GroupsClient
-	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r ResourceGroupResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
 
-	name := d.Get("name").(string)
-	location := location.Normalize(d.Get("location").(string))
-	t := d.Get("tags").(map[string]interface{})
+func (r ResourceGroupResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 90 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GroupsClient
 
-	if d.IsNewResource() {
-		existing, err := client.Get(ctx, name)
-		if err != nil {
-			if !utils.ResponseWasNotFound(existing.Response) {
-				return fmt.Errorf("checking for presence of existing resource group: %+v", err)
+			var model ResourceGroupResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
 			}
-		}
 
-		if existing.ID != nil && *existing.ID != "" {
-			return tf.ImportAsExistsError("azurestack_resource_group", *existing.ID)
-		}
-	}
+			existing, err := client.Get(ctx, model.Name)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing resource group: %+v", err)
+				}
+			}
 
-	parameters := resources.Group{
-		Location: pointer.FromString(location),
-		Tags:     tags.Expand(t),
-	}
+			if existing.ID != nil && *existing.ID != "" {
+				return tf.ImportAsExistsError(r.ResourceType(), *existing.ID)
+			}
 
-	if _, err := client.CreateOrUpdate(ctx, name, parameters); err != nil {
-		return fmt.Errorf("creating Resource Group %q: %+v", name, err)
-	}
+			if err := r.createOrUpdate(ctx, metadata, model); err != nil {
+				return err
+			}
 
-	resp, err := client.Get(ctx, name)
-	if err != nil {
-		return fmt.Errorf("retrieving Resource Group %q: %+v", name, err)
-	}
+			resp, err := client.Get(ctx, model.Name)
+			if err != nil {
+				return fmt.Errorf("retrieving Resource Group %q: %+v", model.Name, err)
+			}
 
-	// @tombuildsstuff: intentionally leaving this for now, since this'll need
-	// details in the upgrade notes given how the Resource Group ID is cased incorrectly
-	// but needs to be fixed (resourcegroups -> resourceGroups)
-	d.SetId(*resp.ID)
+			// @tombuildsstuff: intentionally leaving this for now, since this'll need
+			// details in the upgrade notes given how the Resource Group ID is cased incorrectly
+			// but needs to be fixed (resourcegroups -> resourceGroups)
+			metadata.ResourceData.SetId(*resp.ID)
 
-	return resourceGroupRead(d, meta)
+			return nil
+		},
+	}
 }
 
-func resourceGroupRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Resource.GroupsClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r ResourceGroupResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GroupsClient
 
-	id, err := parse.ResourceGroupID(d.Id())
-	if err != nil {
-		return err
-	}
+			id, err := parse.ResourceGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-	resp, err := client.Get(ctx, id.ResourceGroup)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			log.Printf("[INFO] Error reading resource group %q - removing from state", d.Id())
-			d.SetId("")
-			return nil
-		}
+			resp, err := client.Get(ctx, id.ResourceGroup)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
 
-		return fmt.Errorf("reading resource group: %+v", err)
-	}
+				return fmt.Errorf("reading resource group: %+v", err)
+			}
 
-	d.Set("name", resp.Name)
-	d.Set("location", location.NormalizeNilable(resp.Location))
-	return tags.FlattenAndSet(d, resp.Tags)
+			return metadata.Encode(&ResourceGroupResourceModel{
+				Name:     pointer.ToString(resp.Name),
+				Location: location.NormalizeNilable(resp.Location),
+				Tags:     tags.ToTypedObject(resp.Tags),
+			})
+		},
+	}
 }
 
-func resourceGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Resource.GroupsClient
-	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r ResourceGroupResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 90 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model ResourceGroupResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	id, err := parse.ResourceGroupID(d.Id())
-	if err != nil {
-		return err
+			return r.createOrUpdate(ctx, metadata, model)
+		},
 	}
+}
+
+func (r ResourceGroupResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 90 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GroupsClient
 
-	// conditionally check for nested resources and error if they exist
-	// TODO upgrade and enable
-	/*if meta.(*clients.Client).Features.ResourceGroup.PreventDeletionIfContainsResources {
-		resourceClient := meta.(*clients.Client).Resource.ResourcesClient
-		results, err := resourceClient.ListByResourceGroupComplete(ctx, id.ResourceGroup, "", "", utils.Int32(500))
-		if err != nil {
-			return fmt.Errorf("listing resources in %s: %v", *id, err)
-		}
-		nestedResourceIds := make([]string, 0)
-		for results.NotDone() {
-			val := results.Value()
-			if val.ID != nil {
-				nestedResourceIds = append(nestedResourceIds, *val.ID)
+			id, err := parse.ResourceGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
 			}
 
-			if err := results.NextWithContext(ctx); err != nil {
-				return fmt.Errorf("retrieving next page of nested items for %s: %+v", id, err)
+			// conditionally check for nested resources and error if they exist
+			if metadata.Client.Features.ResourceGroup.PreventDeletionIfContainsResources {
+				resourceClient := metadata.Client.Resource.ResourcesClient
+				results, err := resourceClient.ListByResourceGroupComplete(ctx, id.ResourceGroup, "", "", utils.Int32(500))
+				if err != nil {
+					return fmt.Errorf("listing resources in %s: %v", *id, err)
+				}
+				nestedResourceIds := make([]string, 0)
+				for results.NotDone() {
+					val := results.Value()
+					if val.ID != nil {
+						nestedResourceIds = append(nestedResourceIds, *val.ID)
+					}
+
+					if err := results.NextWithContext(ctx); err != nil {
+						return fmt.Errorf("retrieving next page of nested items for %s: %+v", id, err)
+					}
+				}
+
+				if len(nestedResourceIds) > 0 {
+					return resourceGroupContainsItemsError(id.ResourceGroup, nestedResourceIds)
+				}
 			}
-		}
 
-		if len(nestedResourceIds) > 0 {
-			return resourceGroupContainsItemsError(id.ResourceGroup, nestedResourceIds)
-		}
-	}*/
+			deleteFuture, err := client.Delete(ctx, id.ResourceGroup)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
 
-	deleteFuture, err := client.Delete(ctx, id.ResourceGroup)
-	if err != nil {
-		return fmt.Errorf("deleting %s: %+v", *id, err)
+			if err := deleteFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
 	}
+}
 
-	err = deleteFuture.WaitForCompletionRef(ctx, client.Client)
-	if err != nil {
-		return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
+func (r ResourceGroupResource) createOrUpdate(ctx context.Context, metadata sdk.ResourceMetaData, model ResourceGroupResourceModel) error {
+	client := metadata.Client.Resource.GroupsClient
+
+	parameters := resources.Group{
+		Location: pointer.FromString(location.Normalize(model.Location)),
+		Tags:     tags.FromTypedObject(model.Tags),
+	}
+
+	if _, err := client.CreateOrUpdate(ctx, model.Name, parameters); err != nil {
+		return fmt.Errorf("creating Resource Group %q: %+v", model.Name, err)
 	}
 
 	return nil
 }
+
+func resourceGroupContainsItemsError(name string, nestedResourceIds []string) error {
+	formattedResourceUris := make([]string, 0)
+	for _, id := range nestedResourceIds {
+		formattedResourceUris = append(formattedResourceUris, fmt.Sprintf("* `%s`", id))
+	}
+	sort.Strings(formattedResourceUris)
+
+	message := fmt.Sprintf(`deleting Resource Group %[1]q: the Resource Group still contains Resources.
+
+Terraform is configured to check for Resources within the Resource Group when deleting the Resource Group - and
+raise an error if nested Resources still exist to avoid unintentionally deleting these Resources.
+
+Terraform has detected that the following Resources still exist within the Resource Group:
+
+%[2]s
+
+This feature is intended to avoid the unintentional destruction of nested Resources provisioned through some
+other means (for example, an ARM Template Deployment) - as such you must either remove these Resources, or
+disable this behaviour using the feature flag %[3]q within the %[4]q block when configuring the Provider, for example:
+
+provider "azurestack" {
+  features {
+    resource_group {
+      prevent_deletion_if_contains_resources = false
+    }
+  }
+}
+
+When that feature flag is set, Terraform will skip checking for any Resources within the Resource Group and
+delete this using the Azure API directly (which will clear up any nested resources).
+
+More information on the %[4]q block can be found in the documentation:
+https://registry.terraform.io/providers/hashicorp/azurestack/latest/docs/guides/features-block
+`, name, strings.Join(formattedResourceUris, "\n"), "prevent_deletion_if_contains_resources", "features")
+
+	return errors.New(message)
+}
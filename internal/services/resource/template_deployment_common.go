@@ -0,0 +1,135 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/resources/mgmt/resources"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/resource/client"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+// deleteItemsProvisionedByTemplate deletes each of the Resources which were the target of an operation
+// within the specified Template Deployment - in the reverse order to which they were provisioned.
+func deleteItemsProvisionedByTemplate(ctx context.Context, client *client.Client, resourceGroup, deploymentName string) error {
+	log.Printf("[DEBUG] Retrieving the operations for Template Deployment %q (Resource Group %q)..", deploymentName, resourceGroup)
+	operations, err := client.DeploymentOperationsClient.ListComplete(ctx, resourceGroup, deploymentName, nil)
+	if err != nil {
+		return fmt.Errorf("listing operations for Template Deployment %q (Resource Group %q): %+v", deploymentName, resourceGroup, err)
+	}
+
+	targetResources := make([]resources.TargetResource, 0)
+	seen := make(map[string]struct{})
+	for operations.NotDone() {
+		operation := operations.Value()
+		if props := operation.Properties; props != nil && props.TargetResource != nil {
+			target := *props.TargetResource
+			if target.ID != nil && target.ResourceType != nil {
+				key := strings.ToLower(*target.ID)
+				if _, ok := seen[key]; !ok {
+					seen[key] = struct{}{}
+					targetResources = append(targetResources, target)
+				}
+			}
+		}
+
+		if err := operations.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("retrieving next page of operations for Template Deployment %q (Resource Group %q): %+v", deploymentName, resourceGroup, err)
+		}
+	}
+
+	providers := make(map[string]resources.Provider)
+	for i := len(targetResources) - 1; i >= 0; i-- {
+		resourceId := *targetResources[i].ID
+		resourceType := *targetResources[i].ResourceType
+
+		if strings.EqualFold(resourceType, "Microsoft.Resources/deployments") {
+			log.Printf("[DEBUG] Skipping nested Template Deployment %q", resourceId)
+			continue
+		}
+
+		providerNamespace := strings.Split(resourceType, "/")[0]
+		provider, ok := providers[strings.ToLower(providerNamespace)]
+		if !ok {
+			provider, err = client.ProvidersClient.Get(ctx, providerNamespace, "")
+			if err != nil {
+				return fmt.Errorf("retrieving Resource Provider %q: %+v", providerNamespace, err)
+			}
+			providers[strings.ToLower(providerNamespace)] = provider
+		}
+
+		apiVersion, err := apiVersionForResourceType(provider, resourceType)
+		if err != nil {
+			return fmt.Errorf("determining API Version for %q: %+v", resourceId, err)
+		}
+
+		log.Printf("[DEBUG] Deleting Nested Resource %q (API Version %q)..", resourceId, *apiVersion)
+		future, err := client.ResourcesClient.DeleteByID(ctx, resourceId, *apiVersion)
+		if err != nil {
+			if utils.WasNotFound(future.Response()) {
+				log.Printf("[DEBUG] Nested Resource %q has already been deleted", resourceId)
+				continue
+			}
+
+			return fmt.Errorf("deleting Nested Resource %q: %+v", resourceId, err)
+		}
+
+		if err := future.WaitForCompletionRef(ctx, client.ResourcesClient.Client); err != nil {
+			return fmt.Errorf("waiting for deletion of Nested Resource %q: %+v", resourceId, err)
+		}
+		log.Printf("[DEBUG] Deleted Nested Resource %q.", resourceId)
+	}
+
+	return nil
+}
+
+// apiVersionForResourceType returns the most recent stable API Version supported by the Resource Provider
+// for the specified Resource Type, falling back to the most recent preview API Version when no stable
+// API Version is available.
+func apiVersionForResourceType(provider resources.Provider, resourceType string) (*string, error) {
+	segments := strings.SplitN(resourceType, "/", 2)
+	if len(segments) != 2 {
+		return nil, fmt.Errorf("expected a Resource Type in the format `{namespace}/{type}` but got %q", resourceType)
+	}
+
+	if provider.Namespace == nil || !strings.EqualFold(*provider.Namespace, segments[0]) {
+		return nil, fmt.Errorf("the Resource Provider doesn't match the namespace %q", segments[0])
+	}
+
+	if provider.ResourceTypes == nil {
+		return nil, fmt.Errorf("the Resource Provider %q has no Resource Types", segments[0])
+	}
+
+	for _, v := range *provider.ResourceTypes {
+		if v.ResourceType == nil || !strings.EqualFold(*v.ResourceType, segments[1]) {
+			continue
+		}
+
+		if v.APIVersions == nil || len(*v.APIVersions) == 0 {
+			break
+		}
+
+		stable := make([]string, 0)
+		preview := make([]string, 0)
+		for _, apiVersion := range *v.APIVersions {
+			if strings.Contains(strings.ToLower(apiVersion), "preview") {
+				preview = append(preview, apiVersion)
+				continue
+			}
+			stable = append(stable, apiVersion)
+		}
+
+		candidates := stable
+		if len(candidates) == 0 {
+			candidates = preview
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(candidates)))
+
+		return utils.String(candidates[0]), nil
+	}
+
+	return nil, fmt.Errorf("no API Versions were found for the Resource Type %q", resourceType)
+}
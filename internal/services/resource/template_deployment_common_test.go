@@ -0,0 +1,91 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/resources/mgmt/resources"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func TestAPIVersionForResourceType(t *testing.T) {
+	provider := resources.Provider{
+		Namespace: utils.String("Microsoft.Network"),
+		ResourceTypes: &[]resources.ProviderResourceType{
+			{
+				ResourceType: utils.String("virtualNetworks"),
+				APIVersions:  &[]string{"2017-10-01", "2018-11-01", "2019-01-01-preview"},
+			},
+			{
+				ResourceType: utils.String("virtualNetworks/subnets"),
+				APIVersions:  &[]string{"2018-11-01-preview"},
+			},
+			{
+				ResourceType: utils.String("publicIPAddresses"),
+				APIVersions:  &[]string{},
+			},
+		},
+	}
+
+	testData := []struct {
+		Name         string
+		ResourceType string
+		Expected     *string
+	}{
+		{
+			Name:         "Stable API Version preferred",
+			ResourceType: "Microsoft.Network/virtualNetworks",
+			Expected:     utils.String("2018-11-01"),
+		},
+		{
+			Name:         "Case Insensitive",
+			ResourceType: "microsoft.network/VIRTUALNETWORKS",
+			Expected:     utils.String("2018-11-01"),
+		},
+		{
+			Name:         "Preview API Version when no Stable",
+			ResourceType: "Microsoft.Network/virtualNetworks/subnets",
+			Expected:     utils.String("2018-11-01-preview"),
+		},
+		{
+			Name:         "No API Versions",
+			ResourceType: "Microsoft.Network/publicIPAddresses",
+			Expected:     nil,
+		},
+		{
+			Name:         "Unknown Resource Type",
+			ResourceType: "Microsoft.Network/loadBalancers",
+			Expected:     nil,
+		},
+		{
+			Name:         "Different Namespace",
+			ResourceType: "Microsoft.Compute/virtualMachines",
+			Expected:     nil,
+		},
+		{
+			Name:         "Missing Type",
+			ResourceType: "Microsoft.Network",
+			Expected:     nil,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		actual, err := apiVersionForResourceType(provider, v.ResourceType)
+		if err != nil {
+			if v.Expected == nil {
+				continue
+			}
+
+			t.Fatalf("expected %q but got an error: %+v", *v.Expected, err)
+		}
+
+		if v.Expected == nil {
+			t.Fatalf("expected an error but got %q", *actual)
+		}
+
+		if *actual != *v.Expected {
+			t.Fatalf("expected %q but got %q", *v.Expected, *actual)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/storage/mgmt/storage"
@@ -14,6 +15,9 @@ import (
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/blob/blobs"
 	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/blob/containers"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/queue/queues"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/table/entities"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/table/tables"
 )
 
 type Client struct {
@@ -21,6 +25,10 @@ type Client struct {
 
 	Env      azure.Environment
 	endpoint string
+
+	// the Data Plane clients use the same TLS and Proxy settings as the Resource Manager clients
+	httpClient *http.Client
+	sender     autorest.Sender
 }
 
 func NewClient(options *common.ClientOptions) *Client {
@@ -31,6 +39,8 @@ func NewClient(options *common.ClientOptions) *Client {
 		AccountsClient: &accountsClient,
 		endpoint:       options.ResourceManagerEndpoint,
 		Env:            options.Environment,
+		httpClient:     options.HTTPClient,
+		sender:         options.Sender(),
 	}
 
 	return &client
@@ -54,6 +64,7 @@ func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*
 
 	blobsClient := blobs.NewWithEnvironment(client.Env)
 	blobsClient.Client.Authorizer = storageAuth
+	blobsClient.Client.Sender = client.sender
 	return &blobsClient, nil
 }
 
@@ -70,11 +81,67 @@ func (client Client) ContainersClient(ctx context.Context, account accountDetail
 
 	containersClient := containers.NewWithEnvironment(client.Env)
 	containersClient.Client.Authorizer = storageAuth
+	containersClient.Client.Sender = client.sender
 
 	shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
 	return shim, nil
 }
 
+func (client Client) QueuesClient(ctx context.Context, account accountDetails) (shim.StorageQueuesWrapper, error) {
+	accountKey, err := account.AccountKey(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account Key: %s", err)
+	}
+
+	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKey)
+	if err != nil {
+		return nil, fmt.Errorf("building Authorizer: %+v", err)
+	}
+
+	queuesClient := queues.NewWithEnvironment(client.Env)
+	queuesClient.Client.Authorizer = storageAuth
+	queuesClient.Client.Sender = client.sender
+
+	shim := shim.NewDataPlaneStorageQueueWrapper(&queuesClient)
+	return shim, nil
+}
+
+func (client Client) TableEntityClient(ctx context.Context, account accountDetails) (*entities.Client, error) {
+	accountKey, err := account.AccountKey(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account Key: %s", err)
+	}
+
+	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKeyForTable)
+	if err != nil {
+		return nil, fmt.Errorf("building Authorizer: %+v", err)
+	}
+
+	entitiesClient := entities.NewWithEnvironment(client.Env)
+	entitiesClient.Client.Authorizer = storageAuth
+	entitiesClient.Client.Sender = client.sender
+	return &entitiesClient, nil
+}
+
+func (client Client) TablesClient(ctx context.Context, account accountDetails) (shim.StorageTableWrapper, error) {
+	accountKey, err := account.AccountKey(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account Key: %s", err)
+	}
+
+	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKeyForTable)
+	if err != nil {
+		return nil, fmt.Errorf("building Authorizer: %+v", err)
+	}
+
+	tablesClient := tables.NewWithEnvironment(client.Env)
+	tablesClient.Client.Authorizer = storageAuth
+	tablesClient.Client.Sender = client.sender
+
+	shim := shim.NewDataPlaneStorageTableWrapper(&tablesClient)
+	return shim, nil
+}
+
 func (client Client) GetKeyForStorageAccount(ctx context.Context, resourceGroupName, storageAccountName string) (string, bool, error) {
 	cacheIndex := resourceGroupName + "/" + storageAccountName
 	storageKeyCacheMu.RLock()
@@ -134,6 +201,9 @@ func (client Client) GetBlobStorageClientForStorageAccount(ctx context.Context,
 	if err != nil {
 		return nil, true, fmt.Errorf("creating storage client for storage account %q: %s", storageAccountName, err)
 	}
+	if client.httpClient != nil {
+		storageClient.HTTPClient = client.httpClient
+	}
 
 	blobClient := storageClient.GetBlobService()
 	return &blobClient, true, nil
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/common"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/blob/blobs"
+)
+
+// TestDataPlaneClientRetries ensures that requests made using the Data Plane clients are retried using the `retry` block
+func TestDataPlaneClientRetries(t *testing.T) {
+	var lock sync.Mutex
+	attempts := 0
+
+	// a status code which autorest doesn't retry, so that this is only retried using the `retry` block
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		attempts++
+		if attempts <= 2 {
+			w.WriteHeader(http.StatusInsufficientStorage)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	// the Data Plane endpoints are subdomains of the Storage Endpoint Suffix, so send all requests to the test server
+	httpClient := server.Client()
+	transport := httpClient.Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig.ServerName = "example.com"
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	httpClient.Transport = transport
+
+	options := &common.ClientOptions{
+		Environment: azure.Environment{
+			StorageEndpointSuffix: "example.com",
+		},
+		HTTPClient: httpClient,
+		Retry: common.RetryOptions{
+			MaxAttempts:          3,
+			MinBackoff:           time.Millisecond,
+			MaxBackoff:           time.Millisecond,
+			RetryableStatusCodes: []int{http.StatusInsufficientStorage},
+		},
+		DisableCorrelationRequestID: true,
+	}
+	client := NewClient(options)
+
+	accountKey := "c2VjcmV0"
+	account := accountDetails{
+		name:       "account",
+		accountKey: &accountKey,
+	}
+	blobsClient, err := client.BlobsClient(context.TODO(), account)
+	if err != nil {
+		t.Fatalf("building Blobs Client: %+v", err)
+	}
+
+	if _, err := blobsClient.GetProperties(context.TODO(), "account", "container", "blob", blobs.GetPropertiesInput{}); err != nil {
+		t.Fatalf("retrieving properties: %+v", err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if attempts != 3 {
+		t.Fatalf("expected 3 requests to be sent but got %d", attempts)
+	}
+}
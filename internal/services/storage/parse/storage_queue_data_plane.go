@@ -0,0 +1,69 @@
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceid"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/queue/queues"
+)
+
+var _ resourceid.Formatter = StorageQueueDataPlaneId{}
+
+type StorageQueueDataPlaneId struct {
+	AccountName  string
+	DomainSuffix string
+	Name         string
+}
+
+func (id StorageQueueDataPlaneId) ID() string {
+	return fmt.Sprintf("https://%s.queue.%s/%s", id.AccountName, id.DomainSuffix, id.Name)
+}
+
+func NewStorageQueueDataPlaneId(accountName, domainSuffix, name string) StorageQueueDataPlaneId {
+	return StorageQueueDataPlaneId{
+		AccountName:  accountName,
+		DomainSuffix: domainSuffix,
+		Name:         name,
+	}
+}
+
+func StorageQueueDataPlaneID(id string) (*StorageQueueDataPlaneId, error) {
+	parsed, err := queues.ParseResourceID(id)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.QueueName == "" {
+		return nil, fmt.Errorf("expected a Queue Name in %q but got an empty string", id)
+	}
+
+	domainNameSuffix, err := storageDataPlaneDomainSuffix(id, "queue")
+	if err != nil {
+		return nil, err
+	}
+
+	return &StorageQueueDataPlaneId{
+		AccountName:  parsed.AccountName,
+		DomainSuffix: *domainNameSuffix,
+		Name:         parsed.QueueName,
+	}, nil
+}
+
+// storageDataPlaneDomainSuffix returns the domain suffix for the Data Plane URI, for example
+// `https://account1.queue.local.azurestack.external/queue1` returns `local.azurestack.external`
+func storageDataPlaneDomainSuffix(id, service string) (*string, error) {
+	uri, err := url.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	host := uri.Host
+	hostSegments := strings.Split(host, ".")
+	if len(hostSegments) == 0 {
+		return nil, fmt.Errorf("expected multiple host segments but got 0")
+	}
+	domainNameSuffix := strings.TrimPrefix(host, fmt.Sprintf("%s.%s.", hostSegments[0], service))
+
+	return &domainNameSuffix, nil
+}
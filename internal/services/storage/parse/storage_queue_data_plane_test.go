@@ -0,0 +1,80 @@
+package parse
+
+import (
+	"testing"
+)
+
+func TestStorageQueueDataPlaneIDFormatter(t *testing.T) {
+	actual := NewStorageQueueDataPlaneId("account1", "local.azurestack.external", "queue1").ID()
+	expected := "https://account1.queue.local.azurestack.external/queue1"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+}
+
+func TestStorageQueueDataPlaneID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *StorageQueueDataPlaneId
+	}{
+
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+
+		{
+			// missing Name
+			Input: "https://account1.queue.local.azurestack.external/",
+			Error: true,
+		},
+
+		{
+			// valid
+			Input: "https://account1.queue.local.azurestack.external/queue1",
+			Expected: &StorageQueueDataPlaneId{
+				AccountName:  "account1",
+				DomainSuffix: "local.azurestack.external",
+				Name:         "queue1",
+			},
+		},
+
+		{
+			// valid with a region-scoped domain
+			Input: "https://account1.queue.region1.example.com/queue1",
+			Expected: &StorageQueueDataPlaneId{
+				AccountName:  "account1",
+				DomainSuffix: "region1.example.com",
+				Name:         "queue1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := StorageQueueDataPlaneID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.AccountName != v.Expected.AccountName {
+			t.Fatalf("Expected %q but got %q for AccountName", v.Expected.AccountName, actual.AccountName)
+		}
+		if actual.DomainSuffix != v.Expected.DomainSuffix {
+			t.Fatalf("Expected %q but got %q for DomainSuffix", v.Expected.DomainSuffix, actual.DomainSuffix)
+		}
+		if actual.Name != v.Expected.Name {
+			t.Fatalf("Expected %q but got %q for Name", v.Expected.Name, actual.Name)
+		}
+	}
+}
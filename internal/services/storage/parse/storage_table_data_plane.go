@@ -0,0 +1,46 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceid"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/table/tables"
+)
+
+var _ resourceid.Formatter = StorageTableDataPlaneId{}
+
+type StorageTableDataPlaneId struct {
+	AccountName  string
+	DomainSuffix string
+	Name         string
+}
+
+func (id StorageTableDataPlaneId) ID() string {
+	return fmt.Sprintf("https://%s.table.%s/Tables('%s')", id.AccountName, id.DomainSuffix, id.Name)
+}
+
+func NewStorageTableDataPlaneId(accountName, domainSuffix, name string) StorageTableDataPlaneId {
+	return StorageTableDataPlaneId{
+		AccountName:  accountName,
+		DomainSuffix: domainSuffix,
+		Name:         name,
+	}
+}
+
+func StorageTableDataPlaneID(id string) (*StorageTableDataPlaneId, error) {
+	parsed, err := tables.ParseResourceID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	domainNameSuffix, err := storageDataPlaneDomainSuffix(id, "table")
+	if err != nil {
+		return nil, err
+	}
+
+	return &StorageTableDataPlaneId{
+		AccountName:  parsed.AccountName,
+		DomainSuffix: *domainNameSuffix,
+		Name:         parsed.TableName,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package parse
+
+import (
+	"testing"
+)
+
+func TestStorageTableDataPlaneIDFormatter(t *testing.T) {
+	actual := NewStorageTableDataPlaneId("account1", "local.azurestack.external", "table1").ID()
+	expected := "https://account1.table.local.azurestack.external/Tables('table1')"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+}
+
+func TestStorageTableDataPlaneID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *StorageTableDataPlaneId
+	}{
+
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+
+		{
+			// missing Tables segment
+			Input: "https://account1.table.local.azurestack.external/table1",
+			Error: true,
+		},
+
+		{
+			// valid
+			Input: "https://account1.table.local.azurestack.external/Tables('table1')",
+			Expected: &StorageTableDataPlaneId{
+				AccountName:  "account1",
+				DomainSuffix: "local.azurestack.external",
+				Name:         "table1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := StorageTableDataPlaneID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.AccountName != v.Expected.AccountName {
+			t.Fatalf("Expected %q but got %q for AccountName", v.Expected.AccountName, actual.AccountName)
+		}
+		if actual.DomainSuffix != v.Expected.DomainSuffix {
+			t.Fatalf("Expected %q but got %q for DomainSuffix", v.Expected.DomainSuffix, actual.DomainSuffix)
+		}
+		if actual.Name != v.Expected.Name {
+			t.Fatalf("Expected %q but got %q for Name", v.Expected.Name, actual.Name)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceid"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/table/entities"
+)
+
+var _ resourceid.Formatter = StorageTableEntityDataPlaneId{}
+
+type StorageTableEntityDataPlaneId struct {
+	AccountName  string
+	DomainSuffix string
+	TableName    string
+	PartitionKey string
+	RowKey       string
+}
+
+func (id StorageTableEntityDataPlaneId) ID() string {
+	return fmt.Sprintf("https://%s.table.%s/%s(PartitionKey='%s',RowKey='%s')", id.AccountName, id.DomainSuffix, id.TableName, id.PartitionKey, id.RowKey)
+}
+
+func NewStorageTableEntityDataPlaneId(accountName, domainSuffix, tableName, partitionKey, rowKey string) StorageTableEntityDataPlaneId {
+	return StorageTableEntityDataPlaneId{
+		AccountName:  accountName,
+		DomainSuffix: domainSuffix,
+		TableName:    tableName,
+		PartitionKey: partitionKey,
+		RowKey:       rowKey,
+	}
+}
+
+func StorageTableEntityDataPlaneID(id string) (*StorageTableEntityDataPlaneId, error) {
+	parsed, err := entities.ParseResourceID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	domainNameSuffix, err := storageDataPlaneDomainSuffix(id, "table")
+	if err != nil {
+		return nil, err
+	}
+
+	return &StorageTableEntityDataPlaneId{
+		AccountName:  parsed.AccountName,
+		DomainSuffix: *domainNameSuffix,
+		TableName:    parsed.TableName,
+		PartitionKey: parsed.PartitionKey,
+		RowKey:       parsed.RowKey,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package parse
+
+import (
+	"testing"
+)
+
+func TestStorageTableEntityDataPlaneIDFormatter(t *testing.T) {
+	actual := NewStorageTableEntityDataPlaneId("account1", "local.azurestack.external", "table1", "partition1", "row1").ID()
+	expected := "https://account1.table.local.azurestack.external/table1(PartitionKey='partition1',RowKey='row1')"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+}
+
+func TestStorageTableEntityDataPlaneID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *StorageTableEntityDataPlaneId
+	}{
+
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+
+		{
+			// missing keys
+			Input: "https://account1.table.local.azurestack.external/table1",
+			Error: true,
+		},
+
+		{
+			// valid
+			Input: "https://account1.table.local.azurestack.external/table1(PartitionKey='partition1',RowKey='row1')",
+			Expected: &StorageTableEntityDataPlaneId{
+				AccountName:  "account1",
+				DomainSuffix: "local.azurestack.external",
+				TableName:    "table1",
+				PartitionKey: "partition1",
+				RowKey:       "row1",
+			},
+		},
+
+		{
+			// valid with the keys reversed
+			Input: "https://account1.table.local.azurestack.external/table1(RowKey='row1',PartitionKey='partition1')",
+			Expected: &StorageTableEntityDataPlaneId{
+				AccountName:  "account1",
+				DomainSuffix: "local.azurestack.external",
+				TableName:    "table1",
+				PartitionKey: "partition1",
+				RowKey:       "row1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := StorageTableEntityDataPlaneID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.AccountName != v.Expected.AccountName {
+			t.Fatalf("Expected %q but got %q for AccountName", v.Expected.AccountName, actual.AccountName)
+		}
+		if actual.DomainSuffix != v.Expected.DomainSuffix {
+			t.Fatalf("Expected %q but got %q for DomainSuffix", v.Expected.DomainSuffix, actual.DomainSuffix)
+		}
+		if actual.TableName != v.Expected.TableName {
+			t.Fatalf("Expected %q but got %q for TableName", v.Expected.TableName, actual.TableName)
+		}
+		if actual.PartitionKey != v.Expected.PartitionKey {
+			t.Fatalf("Expected %q but got %q for PartitionKey", v.Expected.PartitionKey, actual.PartitionKey)
+		}
+		if actual.RowKey != v.Expected.RowKey {
+			t.Fatalf("Expected %q but got %q for RowKey", v.Expected.RowKey, actual.RowKey)
+		}
+	}
+}
@@ -21,16 +21,22 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurestack_storage_account":   storageAccountDataSource(),
-		"azurestack_storage_container": storageContainerDataSource(),
+		"azurestack_storage_account":                    storageAccountDataSource(),
+		"azurestack_storage_account_blob_container_sas": storageAccountBlobContainerSasDataSource(),
+		"azurestack_storage_account_sas":                storageAccountSasDataSource(),
+		"azurestack_storage_container":                  storageContainerDataSource(),
 	}
 }
 
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurestack_storage_account":   storageAccount(),
-		"azurestack_storage_blob":      storageBlob(),
-		"azurestack_storage_container": storageContainer(),
+		"azurestack_storage_account":               storageAccount(),
+		"azurestack_storage_account_network_rules": storageAccountNetworkRules(),
+		"azurestack_storage_blob":                  storageBlob(),
+		"azurestack_storage_container":             storageContainer(),
+		"azurestack_storage_queue":                 storageQueue(),
+		"azurestack_storage_table":                 storageTable(),
+		"azurestack_storage_table_entity":          storageTableEntity(),
 	}
 }
@@ -0,0 +1,157 @@
+package sas
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// AccountSASDefaultSignedVersion is the default Storage Service Version used to sign an Account SAS
+	AccountSASDefaultSignedVersion = "2017-07-29"
+
+	// ContainerSASSignedVersion is the Storage Service Version used to sign a Blob Container SAS
+	ContainerSASSignedVersion = "2018-11-09"
+
+	// containerSignedResource is the Signed Resource used for a Blob Container
+	containerSignedResource = "c"
+)
+
+type AccountSASInput struct {
+	AccountName    string
+	AccountKey     string
+	Permissions    string
+	Services       string
+	ResourceTypes  string
+	Start          string
+	Expiry         string
+	SignedProtocol string
+	SignedIP       string
+	SignedVersion  string
+}
+
+// ComputeAccountSASToken computes the Shared Access Signature for a Storage Account
+// see https://docs.microsoft.com/en-us/rest/api/storageservices/create-account-sas
+func ComputeAccountSASToken(input AccountSASInput) (string, error) {
+	stringToSign := strings.Join([]string{
+		input.AccountName,
+		input.Permissions,
+		input.Services,
+		input.ResourceTypes,
+		input.Start,
+		input.Expiry,
+		input.SignedIP,
+		input.SignedProtocol,
+		input.SignedVersion,
+	}, "\n") + "\n"
+
+	signature, err := sign(input.AccountKey, stringToSign)
+	if err != nil {
+		return "", err
+	}
+
+	// the ordering and encoding of these fields matches the SAS Tokens generated by the Portal
+	sasToken := "?sv=" + url.QueryEscape(input.SignedVersion)
+	sasToken += "&ss=" + url.QueryEscape(input.Services)
+	sasToken += "&srt=" + url.QueryEscape(input.ResourceTypes)
+	sasToken += "&sp=" + url.QueryEscape(input.Permissions)
+	sasToken += "&se=" + url.QueryEscape(input.Expiry)
+	sasToken += "&st=" + url.QueryEscape(input.Start)
+	sasToken += "&spr=" + input.SignedProtocol
+	if input.SignedIP != "" {
+		sasToken += "&sip=" + input.SignedIP
+	}
+	sasToken += "&sig=" + url.QueryEscape(signature)
+
+	return sasToken, nil
+}
+
+type ContainerSASInput struct {
+	AccountName        string
+	AccountKey         string
+	ContainerName      string
+	Permissions        string
+	Start              string
+	Expiry             string
+	SignedIdentifier   string
+	SignedIP           string
+	SignedProtocol     string
+	SignedSnapshotTime string
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	ContentLanguage    string
+	ContentType        string
+}
+
+// ComputeContainerSASToken computes the Service Shared Access Signature for a Blob Container
+// see https://docs.microsoft.com/en-us/rest/api/storageservices/create-service-sas
+func ComputeContainerSASToken(input ContainerSASInput) (string, error) {
+	canonicalizedResource := fmt.Sprintf("/blob/%s/%s", input.AccountName, input.ContainerName)
+
+	stringToSign := strings.Join([]string{
+		input.Permissions,
+		input.Start,
+		input.Expiry,
+		canonicalizedResource,
+		input.SignedIdentifier,
+		input.SignedIP,
+		input.SignedProtocol,
+		ContainerSASSignedVersion,
+		containerSignedResource,
+		input.SignedSnapshotTime,
+		input.CacheControl,
+		input.ContentDisposition,
+		input.ContentEncoding,
+		input.ContentLanguage,
+		input.ContentType,
+	}, "\n")
+
+	signature, err := sign(input.AccountKey, stringToSign)
+	if err != nil {
+		return "", err
+	}
+
+	sasToken := "?sv=" + ContainerSASSignedVersion
+	sasToken += "&sr=" + containerSignedResource
+	sasToken += "&st=" + url.QueryEscape(input.Start)
+	sasToken += "&se=" + url.QueryEscape(input.Expiry)
+	sasToken += "&sp=" + input.Permissions
+	sasToken += "&spr=" + input.SignedProtocol
+	if input.SignedIP != "" {
+		sasToken += "&sip=" + input.SignedIP
+	}
+	if input.CacheControl != "" {
+		sasToken += "&rscc=" + url.QueryEscape(input.CacheControl)
+	}
+	if input.ContentDisposition != "" {
+		sasToken += "&rscd=" + url.QueryEscape(input.ContentDisposition)
+	}
+	if input.ContentEncoding != "" {
+		sasToken += "&rsce=" + url.QueryEscape(input.ContentEncoding)
+	}
+	if input.ContentLanguage != "" {
+		sasToken += "&rscl=" + url.QueryEscape(input.ContentLanguage)
+	}
+	if input.ContentType != "" {
+		sasToken += "&rsct=" + url.QueryEscape(input.ContentType)
+	}
+	sasToken += "&sig=" + url.QueryEscape(signature)
+
+	return sasToken, nil
+}
+
+// sign returns the Base64 encoded HMAC-SHA256 of the specified string, using the Base64 encoded Account Key
+func sign(accountKey, stringToSign string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(accountKey)
+	if err != nil {
+		return "", fmt.Errorf("decoding Account Key: %+v", err)
+	}
+
+	hasher := hmac.New(sha256.New, key)
+	hasher.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,217 @@
+package sas
+
+import (
+	"testing"
+)
+
+// the key used below is the Base64 encoding of `0123456789abcdef0123456789abcdef`
+const testAccountKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+// the keys below are for Storage Accounts which have since been deleted, the signatures for these were generated
+// by Azure (and match those used by `github.com/hashicorp/go-azure-helpers/storage`) rather than by this package
+const (
+	knownAccountKey   = "T0ZQouXBDpWud/PlTRHIJH2+VUK8D+fnedEynb9Mx638IYnsMUe4mv1fFjC7t0NayTfFAQJzPZuV1WHFKOzGdg=="
+	knownContainerKey = "y3PNtHAAyjMSRHZ26n/ISyXt1IpXLIqiwAUQ602Un8AJX2JL3MMEbxK7ue45nr9BB0BibegTkQ5rdrgMR5CZkA=="
+)
+
+func TestComputeAccountSASToken(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    AccountSASInput
+		Expected string
+		Error    bool
+	}{
+		{
+			Name: "known signature",
+			Input: AccountSASInput{
+				AccountName:    "azurermtestsa0",
+				AccountKey:     knownAccountKey,
+				Permissions:    "rwac",
+				Services:       "b",
+				ResourceTypes:  "c",
+				Start:          "2018-03-20T04:00:00Z",
+				Expiry:         "2020-03-20T04:00:00Z",
+				SignedProtocol: "https",
+				SignedVersion:  "2017-07-29",
+			},
+			Expected: "?sv=2017-07-29&ss=b&srt=c&sp=rwac&se=2020-03-20T04%3A00%3A00Z&st=2018-03-20T04%3A00%3A00Z&spr=https&sig=SQigK%2FnFA4pv0F0oMLqr6DxUWV4vtFqWi6q3Mf7o9nY%3D",
+		},
+		{
+			Name: "all services and resource types",
+			Input: AccountSASInput{
+				AccountName:    "azurestackaccount",
+				AccountKey:     testAccountKey,
+				Permissions:    "rwdlacup",
+				Services:       "bqtf",
+				ResourceTypes:  "sco",
+				Start:          "2022-03-21T00:00:00Z",
+				Expiry:         "2022-03-22T00:00:00Z",
+				SignedProtocol: "https",
+				SignedVersion:  AccountSASDefaultSignedVersion,
+			},
+			Expected: "?sv=2017-07-29&ss=bqtf&srt=sco&sp=rwdlacup&se=2022-03-22T00%3A00%3A00Z&st=2022-03-21T00%3A00%3A00Z&spr=https&sig=Wu5O2KOQiDEx7bYtIb39EwLd5qfXL7kmzEAssHgYQRs%3D",
+		},
+		{
+			Name: "ip range and http",
+			Input: AccountSASInput{
+				AccountName:    "azurestackaccount",
+				AccountKey:     testAccountKey,
+				Permissions:    "rl",
+				Services:       "b",
+				ResourceTypes:  "o",
+				Start:          "2022-03-21T00:00:00Z",
+				Expiry:         "2022-03-22T00:00:00Z",
+				SignedProtocol: "https,http",
+				SignedIP:       "168.1.5.60-168.1.5.70",
+				SignedVersion:  AccountSASDefaultSignedVersion,
+			},
+			Expected: "?sv=2017-07-29&ss=b&srt=o&sp=rl&se=2022-03-22T00%3A00%3A00Z&st=2022-03-21T00%3A00%3A00Z&spr=https,http&sip=168.1.5.60-168.1.5.70&sig=wp8AItDxsPBm46mEn2wnmFaZoXpe97TxGAtppKhUa8A%3D",
+		},
+		{
+			Name: "start and expiry with an offset",
+			Input: AccountSASInput{
+				AccountName:    "azurestackaccount",
+				AccountKey:     testAccountKey,
+				Permissions:    "rl",
+				Services:       "b",
+				ResourceTypes:  "co",
+				Start:          "2022-03-21T00:00:00+00:00",
+				Expiry:         "2022-03-22T12:30:00+00:00",
+				SignedProtocol: "https",
+				SignedVersion:  AccountSASDefaultSignedVersion,
+			},
+			Expected: "?sv=2017-07-29&ss=b&srt=co&sp=rl&se=2022-03-22T12%3A30%3A00%2B00%3A00&st=2022-03-21T00%3A00%3A00%2B00%3A00&spr=https&sig=fXccYW5%2BEXk%2FIZxTd1ZO0dZYbsY03i6zpiqFS3L4UeM%3D",
+		},
+		{
+			Name: "invalid account key",
+			Input: AccountSASInput{
+				AccountName: "azurestackaccount",
+				AccountKey:  "not-base64!",
+			},
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := ComputeAccountSASToken(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected a value but got an error: %+v", err)
+		}
+		if v.Error {
+			t.Fatal("Expected an error but didn't get one")
+		}
+
+		if actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestComputeContainerSASToken(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    ContainerSASInput
+		Expected string
+		Error    bool
+	}{
+		{
+			Name: "known signature",
+			Input: ContainerSASInput{
+				AccountName:    "azurermblobcontainertest",
+				AccountKey:     knownContainerKey,
+				ContainerName:  "test-container",
+				Permissions:    "rwl",
+				Start:          "2019-03-27",
+				Expiry:         "2019-09-21T09:21Z",
+				SignedProtocol: "https",
+			},
+			Expected: "?sv=2018-11-09&sr=c&st=2019-03-27&se=2019-09-21T09%3A21Z&sp=rwl&spr=https&sig=DnHeyj11jpfGEmdskSIuASIZorLghcjLbKN90n%2B6UO4%3D",
+		},
+		{
+			Name: "known signature with ip address and response headers",
+			Input: ContainerSASInput{
+				AccountName:        "azurermblobcontainertest",
+				AccountKey:         knownContainerKey,
+				ContainerName:      "test-container",
+				Permissions:        "rwl",
+				Start:              "2019-03-27",
+				Expiry:             "2019-09-21T09:21Z",
+				SignedIP:           "93.23.223.54",
+				SignedProtocol:     "https",
+				CacheControl:       "no-cache",
+				ContentDisposition: "attachment",
+				ContentEncoding:    "gzip",
+				ContentLanguage:    "en-US",
+				ContentType:        "text/html; charset=utf-8",
+			},
+			Expected: "?sv=2018-11-09&sr=c&st=2019-03-27&se=2019-09-21T09%3A21Z&sp=rwl&spr=https&sip=93.23.223.54&rscc=no-cache&rscd=attachment&rsce=gzip&rscl=en-US&rsct=text%2Fhtml%3B+charset%3Dutf-8&sig=M2TaUVEGlRVJjNt%2Fc7Eqt2zH6%2BA8dpiLmTXR0ZevEX8%3D",
+		},
+		{
+			Name: "basic",
+			Input: ContainerSASInput{
+				AccountName:    "azurestackaccount",
+				AccountKey:     testAccountKey,
+				ContainerName:  "vhds",
+				Permissions:    "rwdl",
+				Start:          "2022-03-21",
+				Expiry:         "2022-03-22",
+				SignedProtocol: "https",
+			},
+			Expected: "?sv=2018-11-09&sr=c&st=2022-03-21&se=2022-03-22&sp=rwdl&spr=https&sig=mpbbCvRXyRbj3ILze55JSfaGDH4SCybrSrzFIi4g17M%3D",
+		},
+		{
+			Name: "ip address and response headers",
+			Input: ContainerSASInput{
+				AccountName:        "azurestackaccount",
+				AccountKey:         testAccountKey,
+				ContainerName:      "vhds",
+				Permissions:        "r",
+				Start:              "2022-03-21",
+				Expiry:             "2022-03-22",
+				SignedIP:           "168.1.5.65",
+				SignedProtocol:     "https",
+				CacheControl:       "max-age=5",
+				ContentDisposition: "inline",
+				ContentEncoding:    "deflate",
+				ContentLanguage:    "en-US",
+				ContentType:        "application/json",
+			},
+			Expected: "?sv=2018-11-09&sr=c&st=2022-03-21&se=2022-03-22&sp=r&spr=https&sip=168.1.5.65&rscc=max-age%3D5&rscd=inline&rsce=deflate&rscl=en-US&rsct=application%2Fjson&sig=q0CX9O6fzt2ehen7RLMkHdQOCXQqQv4eL12N5f%2Br7j4%3D",
+		},
+		{
+			Name: "invalid account key",
+			Input: ContainerSASInput{
+				AccountName:   "azurestackaccount",
+				AccountKey:    "not-base64!",
+				ContainerName: "vhds",
+			},
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := ComputeContainerSASToken(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected a value but got an error: %+v", err)
+		}
+		if v.Error {
+			t.Fatal("Expected an error but didn't get one")
+		}
+
+		if actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package shim
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/queue/queues"
+)
+
+type DataPlaneStorageQueueWrapper struct {
+	client *queues.Client
+}
+
+func NewDataPlaneStorageQueueWrapper(client *queues.Client) StorageQueuesWrapper {
+	return DataPlaneStorageQueueWrapper{
+		client: client,
+	}
+}
+
+func (w DataPlaneStorageQueueWrapper) Create(ctx context.Context, _, accountName, queueName string, metaData map[string]string) error {
+	_, err := w.client.Create(ctx, accountName, queueName, metaData)
+	return err
+}
+
+func (w DataPlaneStorageQueueWrapper) Delete(ctx context.Context, _, accountName, queueName string) error {
+	resp, err := w.client.Delete(ctx, accountName, queueName)
+	if utils.ResponseWasNotFound(resp) {
+		return nil
+	}
+
+	return err
+}
+
+func (w DataPlaneStorageQueueWrapper) Exists(ctx context.Context, _, accountName, queueName string) (*bool, error) {
+	existing, err := w.client.GetMetaData(ctx, accountName, queueName)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return nil, err
+		}
+	}
+
+	exists := !utils.ResponseWasNotFound(existing.Response)
+	return &exists, nil
+}
+
+func (w DataPlaneStorageQueueWrapper) Get(ctx context.Context, _, accountName, queueName string) (*StorageQueueProperties, error) {
+	props, err := w.client.GetMetaData(ctx, accountName, queueName)
+	if err != nil {
+		if utils.ResponseWasNotFound(props.Response) {
+			return nil, nil // nolint: nilnil
+		}
+
+		return nil, err
+	}
+
+	return &StorageQueueProperties{
+		MetaData: props.MetaData,
+	}, nil
+}
+
+func (w DataPlaneStorageQueueWrapper) UpdateMetaData(ctx context.Context, _, accountName, queueName string, metaData map[string]string) error {
+	_, err := w.client.SetMetaData(ctx, accountName, queueName, metaData)
+	return err
+}
@@ -0,0 +1,15 @@
+package shim
+
+import (
+	"context"
+
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/table/tables"
+)
+
+type StorageTableWrapper interface {
+	Create(ctx context.Context, resourceGroup, accountName, tableName string) error
+	Delete(ctx context.Context, resourceGroup, accountName, tableName string) error
+	Exists(ctx context.Context, resourceGroup, accountName, tableName string) (*bool, error)
+	GetACLs(ctx context.Context, resourceGroup, accountName, tableName string) (*[]tables.SignedIdentifier, error)
+	UpdateACLs(ctx context.Context, resourceGroup, accountName, tableName string, acls []tables.SignedIdentifier) error
+}
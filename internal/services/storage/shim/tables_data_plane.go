@@ -0,0 +1,62 @@
+package shim
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/table/tables"
+)
+
+type DataPlaneStorageTableWrapper struct {
+	client *tables.Client
+}
+
+func NewDataPlaneStorageTableWrapper(client *tables.Client) StorageTableWrapper {
+	return DataPlaneStorageTableWrapper{
+		client: client,
+	}
+}
+
+func (w DataPlaneStorageTableWrapper) Create(ctx context.Context, _, accountName, tableName string) error {
+	_, err := w.client.Create(ctx, accountName, tableName)
+	return err
+}
+
+func (w DataPlaneStorageTableWrapper) Delete(ctx context.Context, _, accountName, tableName string) error {
+	resp, err := w.client.Delete(ctx, accountName, tableName)
+	if utils.ResponseWasNotFound(resp) {
+		return nil
+	}
+
+	return err
+}
+
+func (w DataPlaneStorageTableWrapper) Exists(ctx context.Context, _, accountName, tableName string) (*bool, error) {
+	existing, err := w.client.Exists(ctx, accountName, tableName)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing) {
+			return nil, err
+		}
+	}
+
+	exists := !utils.ResponseWasNotFound(existing)
+	return &exists, nil
+}
+
+func (w DataPlaneStorageTableWrapper) GetACLs(ctx context.Context, _, accountName, tableName string) (*[]tables.SignedIdentifier, error) {
+	acls, err := w.client.GetACL(ctx, accountName, tableName)
+	if err != nil {
+		if utils.ResponseWasNotFound(acls.Response) {
+			return nil, nil // nolint: nilnil
+		}
+
+		return nil, err
+	}
+
+	return &acls.SignedIdentifiers, nil
+}
+
+func (w DataPlaneStorageTableWrapper) UpdateACLs(ctx context.Context, _, accountName, tableName string, acls []tables.SignedIdentifier) error {
+	_, err := w.client.SetACL(ctx, accountName, tableName, acls)
+	return err
+}
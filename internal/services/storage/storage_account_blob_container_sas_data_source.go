@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/sas"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func storageAccountBlobContainerSasDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: storageAccountBlobContainerSasDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+			"container_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageContainerName,
+			},
+
+			"https_only": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"ip_address": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.IsIPv4Address, validation.IsIPv4Range),
+			},
+
+			// Always in UTC and must be ISO-8601 format
+			"start": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			// Always in UTC and must be ISO-8601 format
+			"expiry": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"permissions": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"read": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"add": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"create": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"write": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"delete": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"list": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"cache_control": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"content_disposition": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"content_encoding": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"content_language": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"content_type": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"sas": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func storageAccountBlobContainerSasDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	containerName := d.Get("container_name").(string)
+
+	accountKey, accountExists, err := storageClient.GetKeyForStorageAccount(ctx, resourceGroup, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account Key for Storage Account %q (Resource Group %q): %+v", accountName, resourceGroup, err)
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q (Resource Group %q) was not found", accountName, resourceGroup)
+	}
+
+	input := sas.ContainerSASInput{
+		AccountName:        accountName,
+		AccountKey:         accountKey,
+		ContainerName:      containerName,
+		Permissions:        expandStorageAccountBlobContainerSasPermissions(d.Get("permissions").([]interface{})),
+		Start:              d.Get("start").(string),
+		Expiry:             d.Get("expiry").(string),
+		SignedIP:           d.Get("ip_address").(string),
+		SignedProtocol:     expandStorageSasSignedProtocol(d.Get("https_only").(bool)),
+		CacheControl:       d.Get("cache_control").(string),
+		ContentDisposition: d.Get("content_disposition").(string),
+		ContentEncoding:    d.Get("content_encoding").(string),
+		ContentLanguage:    d.Get("content_language").(string),
+		ContentType:        d.Get("content_type").(string),
+	}
+
+	sasToken, err := sas.ComputeContainerSASToken(input)
+	if err != nil {
+		return fmt.Errorf("computing SAS Token for Container %q (Storage Account %q / Resource Group %q): %+v", containerName, accountName, resourceGroup, err)
+	}
+
+	d.Set("sas", sasToken)
+	tokenHash := sha256.Sum256([]byte(sasToken))
+	d.SetId(hex.EncodeToString(tokenHash[:]))
+
+	return nil
+}
+
+func expandStorageAccountBlobContainerSasPermissions(input []interface{}) string {
+	if len(input) == 0 || input[0] == nil {
+		return ""
+	}
+	raw := input[0].(map[string]interface{})
+
+	// the order of these permissions is significant, see
+	// https://docs.microsoft.com/en-us/rest/api/storageservices/create-service-sas
+	permissions := ""
+	if raw["read"].(bool) {
+		permissions += "r"
+	}
+	if raw["add"].(bool) {
+		permissions += "a"
+	}
+	if raw["create"].(bool) {
+		permissions += "c"
+	}
+	if raw["write"].(bool) {
+		permissions += "w"
+	}
+	if raw["delete"].(bool) {
+		permissions += "d"
+	}
+	if raw["list"].(bool) {
+		permissions += "l"
+	}
+	return permissions
+}
@@ -0,0 +1,262 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/storage/mgmt/storage"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/locks"
+	networkValidate "github.com/hashicorp/terraform-provider-azurestack/internal/services/network/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func storageAccountNetworkRules() *schema.Resource {
+	return &schema.Resource{
+		Create: storageAccountNetworkRulesCreate,
+		Read:   storageAccountNetworkRulesRead,
+		Update: storageAccountNetworkRulesUpdate,
+		Delete: storageAccountNetworkRulesDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.StorageAccountID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageAccountID,
+			},
+
+			"default_action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(storage.DefaultActionAllow),
+					string(storage.DefaultActionDeny),
+				}, false),
+			},
+
+			"bypass": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(storage.AzureServices),
+						string(storage.Logging),
+						string(storage.Metrics),
+						string(storage.None),
+					}, false),
+				},
+				Set: schema.HashString,
+			},
+
+			"ip_rules": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.StorageAccountIpRule,
+				},
+				Set: schema.HashString,
+			},
+
+			"virtual_network_subnet_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: networkValidate.SubnetID,
+				},
+				Set: schema.HashString,
+			},
+		},
+	}
+}
+
+func storageAccountNetworkRulesCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.AccountsClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageAccountID(d.Get("storage_account_id").(string))
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(id.Name, storageAccountResourceName)
+	defer locks.UnlockByName(id.Name, storageAccountResourceName)
+
+	existing, err := client.GetProperties(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("%s was not found", *id)
+		}
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	if props := existing.AccountProperties; props != nil && storageAccountHasNonDefaultNetworkRules(props.NetworkRuleSet) {
+		return tf.ImportAsExistsError("azurestack_storage_account_network_rules", id.ID())
+	}
+
+	if err := storageAccountNetworkRulesUpdateRuleSet(ctx, client, d, *id); err != nil {
+		return err
+	}
+
+	d.SetId(id.ID())
+
+	return storageAccountNetworkRulesRead(d, meta)
+}
+
+func storageAccountNetworkRulesUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.AccountsClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageAccountID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(id.Name, storageAccountResourceName)
+	defer locks.UnlockByName(id.Name, storageAccountResourceName)
+
+	if err := storageAccountNetworkRulesUpdateRuleSet(ctx, client, d, *id); err != nil {
+		return err
+	}
+
+	return storageAccountNetworkRulesRead(d, meta)
+}
+
+func storageAccountNetworkRulesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.AccountsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageAccountID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetProperties(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing Network Rules from state", *id)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("storage_account_id", id.ID())
+
+	if props := resp.AccountProperties; props != nil && props.NetworkRuleSet != nil {
+		rules := props.NetworkRuleSet
+		d.Set("default_action", string(rules.DefaultAction))
+
+		if err := d.Set("bypass", flattenStorageAccountBypass(rules.Bypass)); err != nil {
+			return fmt.Errorf("setting `bypass`: %+v", err)
+		}
+
+		if err := d.Set("ip_rules", flattenStorageAccountIPRules(rules.IPRules)); err != nil {
+			return fmt.Errorf("setting `ip_rules`: %+v", err)
+		}
+
+		if err := d.Set("virtual_network_subnet_ids", flattenStorageAccountVirtualNetworks(rules.VirtualNetworkRules)); err != nil {
+			return fmt.Errorf("setting `virtual_network_subnet_ids`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func storageAccountNetworkRulesDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.AccountsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageAccountID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(id.Name, storageAccountResourceName)
+	defer locks.UnlockByName(id.Name, storageAccountResourceName)
+
+	// the Network Rules can't be removed from a Storage Account, so we reset them to the defaults
+	opts := storage.AccountUpdateParameters{
+		AccountPropertiesUpdateParameters: &storage.AccountPropertiesUpdateParameters{
+			NetworkRuleSet: defaultStorageAccountNetworkRuleSet(),
+		},
+	}
+
+	if resp, err := client.Update(ctx, id.ResourceGroup, id.Name, opts); err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil
+		}
+		return fmt.Errorf("resetting the Network Rules for %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func storageAccountNetworkRulesUpdateRuleSet(ctx context.Context, client *storage.AccountsClient, d *schema.ResourceData, id parse.StorageAccountId) error {
+	opts := storage.AccountUpdateParameters{
+		AccountPropertiesUpdateParameters: &storage.AccountPropertiesUpdateParameters{
+			NetworkRuleSet: expandStorageAccountNetworkRuleSet(
+				d.Get("default_action").(string),
+				d.Get("bypass").(*schema.Set).List(),
+				d.Get("ip_rules").(*schema.Set).List(),
+				d.Get("virtual_network_subnet_ids").(*schema.Set).List(),
+			),
+		},
+	}
+
+	if _, err := client.Update(ctx, id.ResourceGroup, id.Name, opts); err != nil {
+		return fmt.Errorf("updating the Network Rules for %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+// storageAccountHasNonDefaultNetworkRules returns whether the Network Rules for a Storage Account
+// have been changed from the defaults (e.g. are managed by another resource)
+func storageAccountHasNonDefaultNetworkRules(input *storage.NetworkRuleSet) bool {
+	if input == nil {
+		return false
+	}
+
+	if input.IPRules != nil && len(*input.IPRules) > 0 {
+		return true
+	}
+
+	if input.VirtualNetworkRules != nil && len(*input.VirtualNetworkRules) > 0 {
+		return true
+	}
+
+	return input.DefaultAction == storage.DefaultActionDeny
+}
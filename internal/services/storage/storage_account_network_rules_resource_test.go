@@ -0,0 +1,187 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/storage/mgmt/storage"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+type StorageAccountNetworkRulesResource struct{}
+
+func TestAccStorageAccountNetworkRules_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_storage_account_network_rules", "test")
+	r := StorageAccountNetworkRulesResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("default_action").HasValue("Deny"),
+				check.That(data.ResourceName).Key("ip_rules.#").HasValue("1"),
+				check.That(data.ResourceName).Key("virtual_network_subnet_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageAccountNetworkRules_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_storage_account_network_rules", "test")
+	r := StorageAccountNetworkRulesResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccStorageAccountNetworkRules_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_storage_account_network_rules", "test")
+	r := StorageAccountNetworkRulesResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.update(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("ip_rules.#").HasValue("2"),
+				check.That(data.ResourceName).Key("virtual_network_subnet_ids.#").HasValue("0"),
+				check.That(data.ResourceName).Key("bypass.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r StorageAccountNetworkRulesResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.StorageAccountID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Storage.AccountsClient.GetProperties(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return pointer.FromBool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	if props := resp.AccountProperties; props != nil && props.NetworkRuleSet != nil {
+		return pointer.FromBool(props.NetworkRuleSet.DefaultAction == storage.DefaultActionDeny), nil
+	}
+
+	return pointer.FromBool(false), nil
+}
+
+func (r StorageAccountNetworkRulesResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_account_network_rules" "test" {
+  storage_account_id = azurestack_storage_account.test.id
+
+  default_action             = "Deny"
+  ip_rules                   = ["127.0.0.1"]
+  virtual_network_subnet_ids = [azurestack_subnet.test.id]
+}
+`, r.template(data))
+}
+
+func (r StorageAccountNetworkRulesResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_account_network_rules" "import" {
+  storage_account_id = azurestack_storage_account_network_rules.test.storage_account_id
+
+  default_action             = azurestack_storage_account_network_rules.test.default_action
+  ip_rules                   = azurestack_storage_account_network_rules.test.ip_rules
+  virtual_network_subnet_ids = azurestack_storage_account_network_rules.test.virtual_network_subnet_ids
+}
+`, r.basic(data))
+}
+
+func (r StorageAccountNetworkRulesResource) update(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_account_network_rules" "test" {
+  storage_account_id = azurestack_storage_account.test.id
+
+  default_action             = "Deny"
+  ip_rules                   = ["127.0.0.1", "127.0.0.2"]
+  bypass                     = ["Logging", "Metrics"]
+  virtual_network_subnet_ids = []
+}
+`, r.template(data))
+}
+
+func (r StorageAccountNetworkRulesResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurestack_virtual_network" "test" {
+  name                = "acctestvirtnet%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_subnet" "test" {
+  name                 = "acctestsubnet%d"
+  resource_group_name  = azurestack_resource_group.test.name
+  virtual_network_name = azurestack_virtual_network.test.name
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurestack_storage_account" "test" {
+  name                     = "unlikely23exst2acct%s"
+  resource_group_name      = azurestack_resource_group.test.name
+  location                 = azurestack_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  tags = {
+    environment = "production"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomString)
+}
@@ -14,6 +14,8 @@ import (
 
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/locks"
+	networkValidate "github.com/hashicorp/terraform-provider-azurestack/internal/services/network/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/migration"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/parse"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/validate"
@@ -23,7 +25,10 @@ import (
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
 
-const blobStorageAccountDefaultAccessTier = "Hot"
+const (
+	blobStorageAccountDefaultAccessTier = "Hot"
+	storageAccountResourceName          = "azurestack_storage_account"
+)
 
 func storageAccount() *schema.Resource {
 	return &schema.Resource{
@@ -137,6 +142,63 @@ func storageAccount() *schema.Resource {
 				Computed: true,
 			},
 
+			"network_rules": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"default_action": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(storage.DefaultActionAllow),
+								string(storage.DefaultActionDeny),
+							}, false),
+						},
+
+						"bypass": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									string(storage.AzureServices),
+									string(storage.Logging),
+									string(storage.Metrics),
+									string(storage.None),
+								}, false),
+							},
+							Set: schema.HashString,
+						},
+
+						"ip_rules": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validate.StorageAccountIpRule,
+							},
+							Set: schema.HashString,
+						},
+
+						"virtual_network_subnet_ids": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: networkValidate.SubnetID,
+							},
+							Set: schema.HashString,
+						},
+					},
+				},
+			},
+
 			"primary_location": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -266,6 +328,10 @@ func storageAccountCreate(d *schema.ResourceData, meta interface{}) error {
 		parameters.CustomDomain = expandStorageAccountCustomDomain(d)
 	}
 
+	if v, ok := d.GetOk("network_rules"); ok {
+		parameters.NetworkRuleSet = expandStorageAccountNetworkRules(v.([]interface{}))
+	}
+
 	// BlobStorage does not support ZRS
 	if accountKind == string(storage.BlobStorage) {
 		if string(parameters.Sku.Name) == string(storage.StandardZRS) {
@@ -325,6 +391,9 @@ func storageAccountUpdate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	locks.ByName(id.Name, storageAccountResourceName)
+	defer locks.UnlockByName(id.Name, storageAccountResourceName)
+
 	accountTier := d.Get("account_tier").(string)
 	replicationType := d.Get("account_replication_type").(string)
 	storageType := fmt.Sprintf("%s_%s", accountTier, replicationType)
@@ -352,7 +421,7 @@ func storageAccountUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		opts := storage.AccountUpdateParameters{
 			Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
 		}
@@ -416,6 +485,18 @@ func storageAccountUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if d.HasChange("network_rules") {
+		opts := storage.AccountUpdateParameters{
+			AccountPropertiesUpdateParameters: &storage.AccountPropertiesUpdateParameters{
+				NetworkRuleSet: expandStorageAccountNetworkRules(d.Get("network_rules").([]interface{})),
+			},
+		}
+
+		if _, err := client.Update(ctx, id.ResourceGroup, id.Name, opts); err != nil {
+			return fmt.Errorf("updating Azure Storage Account network_rules %q: %+v", id.Name, err)
+		}
+	}
+
 	d.Partial(false)
 	return nil
 }
@@ -474,6 +555,10 @@ func storageAccountRead(d *schema.ResourceData, meta interface{}) error {
 
 		d.Set("enable_https_traffic_only", props.EnableHTTPSTrafficOnly)
 
+		if err := d.Set("network_rules", flattenStorageAccountNetworkRules(props.NetworkRuleSet)); err != nil {
+			return fmt.Errorf("setting `network_rules`: %+v", err)
+		}
+
 		// Computed
 		d.Set("primary_location", props.PrimaryLocation)
 		d.Set("secondary_location", props.SecondaryLocation)
@@ -573,3 +658,136 @@ func flattenStorageAccountCustomDomain(input *storage.CustomDomain) []interface{
 
 	return []interface{}{domain}
 }
+
+func expandStorageAccountNetworkRules(input []interface{}) *storage.NetworkRuleSet {
+	// since `network_rules` is Computed, removing the block keeps the existing Network Rules
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	networkRules := input[0].(map[string]interface{})
+	return expandStorageAccountNetworkRuleSet(
+		networkRules["default_action"].(string),
+		networkRules["bypass"].(*schema.Set).List(),
+		networkRules["ip_rules"].(*schema.Set).List(),
+		networkRules["virtual_network_subnet_ids"].(*schema.Set).List(),
+	)
+}
+
+func expandStorageAccountNetworkRuleSet(defaultAction string, bypass []interface{}, ipRules []interface{}, subnetIds []interface{}) *storage.NetworkRuleSet {
+	return &storage.NetworkRuleSet{
+		DefaultAction:       storage.DefaultAction(defaultAction),
+		Bypass:              expandStorageAccountBypass(bypass),
+		IPRules:             expandStorageAccountIPRules(ipRules),
+		VirtualNetworkRules: expandStorageAccountVirtualNetworks(subnetIds),
+	}
+}
+
+func expandStorageAccountBypass(input []interface{}) storage.Bypass {
+	if len(input) == 0 {
+		return storage.None
+	}
+
+	bypassValues := make([]string, 0)
+	for _, v := range input {
+		bypassValues = append(bypassValues, v.(string))
+	}
+
+	// the API requires the bypass values as a comma separated string
+	return storage.Bypass(strings.Join(bypassValues, ", "))
+}
+
+func expandStorageAccountIPRules(input []interface{}) *[]storage.IPRule {
+	ipRules := make([]storage.IPRule, 0)
+	for _, v := range input {
+		ipRules = append(ipRules, storage.IPRule{
+			IPAddressOrRange: pointer.FromString(v.(string)),
+			Action:           storage.Allow,
+		})
+	}
+
+	return &ipRules
+}
+
+func expandStorageAccountVirtualNetworks(input []interface{}) *[]storage.VirtualNetworkRule {
+	virtualNetworks := make([]storage.VirtualNetworkRule, 0)
+	for _, v := range input {
+		virtualNetworks = append(virtualNetworks, storage.VirtualNetworkRule{
+			VirtualNetworkResourceID: pointer.FromString(v.(string)),
+			Action:                   storage.Allow,
+		})
+	}
+
+	return &virtualNetworks
+}
+
+// defaultStorageAccountNetworkRuleSet returns the Network Rules used by a Storage Account which doesn't restrict
+// access, which since the Network Rules can't be removed from a Storage Account are used to reset them
+func defaultStorageAccountNetworkRuleSet() *storage.NetworkRuleSet {
+	return &storage.NetworkRuleSet{
+		DefaultAction: storage.DefaultActionAllow,
+		Bypass:        storage.AzureServices,
+	}
+}
+
+func flattenStorageAccountNetworkRules(input *storage.NetworkRuleSet) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"default_action":             string(input.DefaultAction),
+			"bypass":                     flattenStorageAccountBypass(input.Bypass),
+			"ip_rules":                   flattenStorageAccountIPRules(input.IPRules),
+			"virtual_network_subnet_ids": flattenStorageAccountVirtualNetworks(input.VirtualNetworkRules),
+		},
+	}
+}
+
+func flattenStorageAccountBypass(input storage.Bypass) []interface{} {
+	bypassValues := make([]interface{}, 0)
+	if input == "" {
+		return bypassValues
+	}
+
+	for _, v := range strings.Split(string(input), ",") {
+		bypassValues = append(bypassValues, strings.TrimSpace(v))
+	}
+
+	return bypassValues
+}
+
+func flattenStorageAccountIPRules(input *[]storage.IPRule) []interface{} {
+	ipRules := make([]interface{}, 0)
+	if input == nil {
+		return ipRules
+	}
+
+	for _, ipRule := range *input {
+		if ipRule.IPAddressOrRange == nil {
+			continue
+		}
+
+		ipRules = append(ipRules, *ipRule.IPAddressOrRange)
+	}
+
+	return ipRules
+}
+
+func flattenStorageAccountVirtualNetworks(input *[]storage.VirtualNetworkRule) []interface{} {
+	virtualNetworks := make([]interface{}, 0)
+	if input == nil {
+		return virtualNetworks
+	}
+
+	for _, virtualNetwork := range *input {
+		if virtualNetwork.VirtualNetworkResourceID == nil {
+			continue
+		}
+
+		virtualNetworks = append(virtualNetworks, *virtualNetwork.VirtualNetworkResourceID)
+	}
+
+	return virtualNetworks
+}
@@ -132,6 +132,44 @@ func TestAccStorageAccount_NonStandardCasing(t *testing.T) {
 	})
 }
 
+func TestAccStorageAccount_networkRules(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_storage_account", "test")
+	r := StorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.networkRules(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("network_rules.0.default_action").HasValue("Deny"),
+				check.That(data.ResourceName).Key("network_rules.0.ip_rules.#").HasValue("1"),
+				check.That(data.ResourceName).Key("network_rules.0.virtual_network_subnet_ids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("network_rules.0.bypass.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.networkRulesUpdate(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("network_rules.0.default_action").HasValue("Deny"),
+				check.That(data.ResourceName).Key("network_rules.0.ip_rules.#").HasValue("2"),
+				check.That(data.ResourceName).Key("network_rules.0.virtual_network_subnet_ids.#").HasValue("0"),
+				check.That(data.ResourceName).Key("network_rules.0.bypass.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.networkRulesReverted(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("network_rules.0.default_action").HasValue("Allow"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (r StorageAccountResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.StorageAccountID(state.ID)
 	if err != nil {
@@ -327,3 +365,102 @@ resource "azurestack_storage_account" "test" {
 }
 `, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
+
+func (r StorageAccountResource) networkRulesTemplate(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurestack_virtual_network" "test" {
+  name                = "acctestvirtnet%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_subnet" "test" {
+  name                 = "acctestsubnet%d"
+  resource_group_name  = azurestack_resource_group.test.name
+  virtual_network_name = azurestack_virtual_network.test.name
+  address_prefix       = "10.0.2.0/24"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
+
+func (r StorageAccountResource) networkRules(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_account" "test" {
+  name                     = "unlikely23exst2acct%s"
+  resource_group_name      = azurestack_resource_group.test.name
+  location                 = azurestack_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  network_rules {
+    default_action             = "Deny"
+    ip_rules                   = ["127.0.0.1"]
+    virtual_network_subnet_ids = [azurestack_subnet.test.id]
+  }
+
+  tags = {
+    environment = "production"
+  }
+}
+`, r.networkRulesTemplate(data), data.RandomString)
+}
+
+func (r StorageAccountResource) networkRulesUpdate(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_account" "test" {
+  name                     = "unlikely23exst2acct%s"
+  resource_group_name      = azurestack_resource_group.test.name
+  location                 = azurestack_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  network_rules {
+    default_action = "Deny"
+    ip_rules       = ["127.0.0.1", "127.0.0.2"]
+    bypass         = ["Logging", "Metrics"]
+  }
+
+  tags = {
+    environment = "production"
+  }
+}
+`, r.networkRulesTemplate(data), data.RandomString)
+}
+
+func (r StorageAccountResource) networkRulesReverted(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_account" "test" {
+  name                     = "unlikely23exst2acct%s"
+  resource_group_name      = azurestack_resource_group.test.name
+  location                 = azurestack_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  network_rules {
+    default_action = "Allow"
+    ip_rules       = []
+    bypass         = ["AzureServices"]
+  }
+
+  tags = {
+    environment = "production"
+  }
+}
+`, r.networkRulesTemplate(data), data.RandomString)
+}
@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/sas"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func storageAccountSasDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: storageAccountSasDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+			"https_only": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"ip_addresses": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.IsIPv4Address, validation.IsIPv4Range),
+			},
+
+			"signed_version": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Default:      sas.AccountSASDefaultSignedVersion,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"resource_types": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"service": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"container": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"object": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"services": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"blob": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"queue": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"table": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"file": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			// Always in UTC and must be ISO-8601 format
+			"start": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			// Always in UTC and must be ISO-8601 format
+			"expiry": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"permissions": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"read": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"write": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"delete": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"list": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"add": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"create": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"update": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"process": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"sas": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func storageAccountSasDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	accountKey, accountExists, err := storageClient.GetKeyForStorageAccount(ctx, resourceGroup, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account Key for Storage Account %q (Resource Group %q): %+v", accountName, resourceGroup, err)
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q (Resource Group %q) was not found", accountName, resourceGroup)
+	}
+
+	input := sas.AccountSASInput{
+		AccountName:    accountName,
+		AccountKey:     accountKey,
+		Permissions:    expandStorageAccountSasPermissions(d.Get("permissions").([]interface{})),
+		Services:       expandStorageAccountSasServices(d.Get("services").([]interface{})),
+		ResourceTypes:  expandStorageAccountSasResourceTypes(d.Get("resource_types").([]interface{})),
+		Start:          d.Get("start").(string),
+		Expiry:         d.Get("expiry").(string),
+		SignedProtocol: expandStorageSasSignedProtocol(d.Get("https_only").(bool)),
+		SignedIP:       d.Get("ip_addresses").(string),
+		SignedVersion:  d.Get("signed_version").(string),
+	}
+
+	sasToken, err := sas.ComputeAccountSASToken(input)
+	if err != nil {
+		return fmt.Errorf("computing SAS Token for Storage Account %q (Resource Group %q): %+v", accountName, resourceGroup, err)
+	}
+
+	d.Set("sas", sasToken)
+	tokenHash := sha256.Sum256([]byte(sasToken))
+	d.SetId(hex.EncodeToString(tokenHash[:]))
+
+	return nil
+}
+
+func expandStorageAccountSasResourceTypes(input []interface{}) string {
+	if len(input) == 0 || input[0] == nil {
+		return ""
+	}
+	raw := input[0].(map[string]interface{})
+
+	resourceTypes := ""
+	if raw["service"].(bool) {
+		resourceTypes += "s"
+	}
+	if raw["container"].(bool) {
+		resourceTypes += "c"
+	}
+	if raw["object"].(bool) {
+		resourceTypes += "o"
+	}
+	return resourceTypes
+}
+
+func expandStorageAccountSasServices(input []interface{}) string {
+	if len(input) == 0 || input[0] == nil {
+		return ""
+	}
+	raw := input[0].(map[string]interface{})
+
+	services := ""
+	if raw["blob"].(bool) {
+		services += "b"
+	}
+	if raw["queue"].(bool) {
+		services += "q"
+	}
+	if raw["table"].(bool) {
+		services += "t"
+	}
+	if raw["file"].(bool) {
+		services += "f"
+	}
+	return services
+}
+
+func expandStorageAccountSasPermissions(input []interface{}) string {
+	if len(input) == 0 || input[0] == nil {
+		return ""
+	}
+	raw := input[0].(map[string]interface{})
+
+	// the order of these permissions is significant, see
+	// https://docs.microsoft.com/en-us/rest/api/storageservices/create-account-sas
+	permissions := ""
+	if raw["read"].(bool) {
+		permissions += "r"
+	}
+	if raw["write"].(bool) {
+		permissions += "w"
+	}
+	if raw["delete"].(bool) {
+		permissions += "d"
+	}
+	if raw["list"].(bool) {
+		permissions += "l"
+	}
+	if raw["add"].(bool) {
+		permissions += "a"
+	}
+	if raw["create"].(bool) {
+		permissions += "c"
+	}
+	if raw["update"].(bool) {
+		permissions += "u"
+	}
+	if raw["process"].(bool) {
+		permissions += "p"
+	}
+	return permissions
+}
+
+func expandStorageSasSignedProtocol(httpsOnly bool) string {
+	if httpsOnly {
+		return "https"
+	}
+	return "https,http"
+}
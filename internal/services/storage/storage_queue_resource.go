@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func storageQueue() *schema.Resource {
+	return &schema.Resource{
+		Create: storageQueueCreate,
+		Read:   storageQueueRead,
+		Update: storageQueueUpdate,
+		Delete: storageQueueDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.StorageQueueDataPlaneID(id)
+			return err
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageQueueName,
+			},
+
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"metadata": MetaDataSchema(),
+		},
+	}
+}
+
+func storageQueueCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	queueName := d.Get("name").(string)
+	accountName := d.Get("storage_account_name").(string)
+
+	metaDataRaw := d.Get("metadata").(map[string]interface{})
+	metaData := ExpandMetaData(metaDataRaw)
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Queue %q: %s", accountName, queueName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", accountName)
+	}
+
+	client, err := storageClient.QueuesClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building storage client: %+v", err)
+	}
+
+	id := parse.NewStorageQueueDataPlaneId(accountName, storageClient.Env.StorageEndpointSuffix, queueName).ID()
+	exists, err := client.Exists(ctx, account.ResourceGroup, accountName, queueName)
+	if err != nil {
+		return err
+	}
+	if exists != nil && *exists {
+		return tf.ImportAsExistsError("azurestack_storage_queue", id)
+	}
+
+	log.Printf("[INFO] Creating Queue %q in Storage Account %q", queueName, accountName)
+	if err := client.Create(ctx, account.ResourceGroup, accountName, queueName, metaData); err != nil {
+		return fmt.Errorf("creating Queue %q (Account %q): %+v", queueName, accountName, err)
+	}
+
+	d.SetId(id)
+	return storageQueueRead(d, meta)
+}
+
+func storageQueueUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageQueueDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Queue %q: %s", id.AccountName, id.Name, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
+	}
+
+	client, err := storageClient.QueuesClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Queues Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
+	}
+
+	if d.HasChange("metadata") {
+		log.Printf("[DEBUG] Updating the MetaData for Queue %q (Storage Account %q / Resource Group %q)..", id.Name, id.AccountName, account.ResourceGroup)
+		metaDataRaw := d.Get("metadata").(map[string]interface{})
+		metaData := ExpandMetaData(metaDataRaw)
+
+		if err := client.UpdateMetaData(ctx, account.ResourceGroup, id.AccountName, id.Name, metaData); err != nil {
+			return fmt.Errorf("updating the MetaData for Queue %q (Storage Account %q / Resource Group %q): %s", id.Name, id.AccountName, account.ResourceGroup, err)
+		}
+
+		log.Printf("[DEBUG] Updated the MetaData for Queue %q (Storage Account %q / Resource Group %q)", id.Name, id.AccountName, account.ResourceGroup)
+	}
+
+	return storageQueueRead(d, meta)
+}
+
+func storageQueueRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageQueueDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Queue %q: %s", id.AccountName, id.Name, err)
+	}
+	if account == nil {
+		log.Printf("[DEBUG] Unable to locate Account %q for Storage Queue %q - assuming removed & removing from state", id.AccountName, id.Name)
+		d.SetId("")
+		return nil
+	}
+
+	client, err := storageClient.QueuesClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Queues Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
+	}
+
+	props, err := client.Get(ctx, account.ResourceGroup, id.AccountName, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving Queue %q (Account %q / Resource Group %q): %s", id.Name, id.AccountName, account.ResourceGroup, err)
+	}
+	if props == nil {
+		log.Printf("[DEBUG] Queue %q was not found in Account %q / Resource Group %q - assuming removed & removing from state", id.Name, id.AccountName, account.ResourceGroup)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", id.Name)
+	d.Set("storage_account_name", id.AccountName)
+
+	if err := d.Set("metadata", FlattenMetaData(props.MetaData)); err != nil {
+		return fmt.Errorf("setting `metadata`: %+v", err)
+	}
+
+	return nil
+}
+
+func storageQueueDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageQueueDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Queue %q: %s", id.AccountName, id.Name, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
+	}
+
+	client, err := storageClient.QueuesClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Queues Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
+	}
+
+	if err := client.Delete(ctx, account.ResourceGroup, id.AccountName, id.Name); err != nil {
+		return fmt.Errorf("deleting Queue %q (Storage Account %q / Resource Group %q): %s", id.Name, id.AccountName, account.ResourceGroup, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+type StorageQueueResource struct{}
+
+func TestAccStorageQueue_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_storage_queue", "test")
+	r := StorageQueueResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageQueue_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_storage_queue", "test")
+	r := StorageQueueResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccStorageQueue_metaData(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_storage_queue", "test")
+	r := StorageQueueResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.metaData(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.metaDataUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r StorageQueueResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.StorageQueueDataPlaneID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := client.Storage.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account %q for Queue %q: %+v", id.AccountName, id.Name, err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("unable to locate Storage Account %q", id.AccountName)
+	}
+
+	queuesClient, err := client.Storage.QueuesClient(ctx, *account)
+	if err != nil {
+		return nil, fmt.Errorf("building Queues Client: %+v", err)
+	}
+	prop, err := queuesClient.Get(ctx, account.ResourceGroup, id.AccountName, id.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Queue %q (Account %q / Resource Group %q): %+v", id.Name, id.AccountName, account.ResourceGroup, err)
+	}
+	return pointer.FromBool(prop != nil), nil
+}
+
+func (r StorageQueueResource) basic(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_queue" "test" {
+  name                 = "mysamplequeue-%d"
+  storage_account_name = azurestack_storage_account.test.name
+}
+`, template, data.RandomInteger)
+}
+
+func (r StorageQueueResource) requiresImport(data acceptance.TestData) string {
+	template := r.basic(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_queue" "import" {
+  name                 = azurestack_storage_queue.test.name
+  storage_account_name = azurestack_storage_queue.test.storage_account_name
+}
+`, template)
+}
+
+func (r StorageQueueResource) metaData(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_queue" "test" {
+  name                 = "mysamplequeue-%d"
+  storage_account_name = azurestack_storage_account.test.name
+
+  metadata = {
+    hello = "world"
+  }
+}
+`, template, data.RandomInteger)
+}
+
+func (r StorageQueueResource) metaDataUpdated(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_queue" "test" {
+  name                 = "mysamplequeue-%d"
+  storage_account_name = azurestack_storage_account.test.name
+
+  metadata = {
+    hello = "world"
+    rick  = "morty"
+  }
+}
+`, template, data.RandomInteger)
+}
+
+func (r StorageQueueResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurestack_resource_group.test.name
+  location                 = azurestack_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  tags = {
+    environment = "staging"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -0,0 +1,274 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/table/entities"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func storageTableEntity() *schema.Resource {
+	return &schema.Resource{
+		Create: storageTableEntityCreate,
+		Read:   storageTableEntityRead,
+		Update: storageTableEntityUpdate,
+		Delete: storageTableEntityDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.StorageTableEntityDataPlaneID(id)
+			return err
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"table_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageTableName,
+			},
+
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"partition_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"row_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"entity": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func storageTableEntityCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	tableName := d.Get("table_name").(string)
+	partitionKey := d.Get("partition_key").(string)
+	rowKey := d.Get("row_key").(string)
+	entity := d.Get("entity").(map[string]interface{})
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", accountName, tableName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", accountName)
+	}
+
+	client, err := storageClient.TableEntityClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Entity Client: %s", err)
+	}
+
+	id := parse.NewStorageTableEntityDataPlaneId(accountName, storageClient.Env.StorageEndpointSuffix, tableName, partitionKey, rowKey).ID()
+	input := entities.GetEntityInput{
+		PartitionKey:  partitionKey,
+		RowKey:        rowKey,
+		MetaDataLevel: entities.NoMetaData,
+	}
+	existing, err := client.Get(ctx, accountName, tableName, input)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing Entity (Partition Key %q / Row Key %q) (Table %q / Storage Account %q / Resource Group %q): %s", partitionKey, rowKey, tableName, accountName, account.ResourceGroup, err)
+		}
+	}
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return tf.ImportAsExistsError("azurestack_storage_table_entity", id)
+	}
+
+	log.Printf("[INFO] Creating Entity (Partition Key %q / Row Key %q) in Table %q (Storage Account %q)", partitionKey, rowKey, tableName, accountName)
+	insertInput := entities.InsertOrMergeEntityInput{
+		PartitionKey: partitionKey,
+		RowKey:       rowKey,
+		Entity:       entity,
+	}
+	if _, err := client.InsertOrMerge(ctx, accountName, tableName, insertInput); err != nil {
+		return fmt.Errorf("creating Entity (Partition Key %q / Row Key %q) (Table %q / Storage Account %q / Resource Group %q): %+v", partitionKey, rowKey, tableName, accountName, account.ResourceGroup, err)
+	}
+
+	d.SetId(id)
+	return storageTableEntityRead(d, meta)
+}
+
+func storageTableEntityUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageTableEntityDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", id.AccountName, id.TableName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
+	}
+
+	client, err := storageClient.TableEntityClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Entity Client: %s", err)
+	}
+
+	if d.HasChange("entity") {
+		log.Printf("[DEBUG] Updating Entity (Partition Key %q / Row Key %q) in Table %q (Storage Account %q)..", id.PartitionKey, id.RowKey, id.TableName, id.AccountName)
+		// replacing (rather than merging) ensures properties removed from the configuration are removed from the Entity
+		input := entities.InsertOrReplaceEntityInput{
+			PartitionKey: id.PartitionKey,
+			RowKey:       id.RowKey,
+			Entity:       d.Get("entity").(map[string]interface{}),
+		}
+		if _, err := client.InsertOrReplace(ctx, id.AccountName, id.TableName, input); err != nil {
+			return fmt.Errorf("updating Entity (Partition Key %q / Row Key %q) (Table %q / Storage Account %q / Resource Group %q): %+v", id.PartitionKey, id.RowKey, id.TableName, id.AccountName, account.ResourceGroup, err)
+		}
+	}
+
+	return storageTableEntityRead(d, meta)
+}
+
+func storageTableEntityRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageTableEntityDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", id.AccountName, id.TableName, err)
+	}
+	if account == nil {
+		log.Printf("[WARN] Unable to determine Resource Group for Storage Table Entity %q (Table %q / Account %q) - assuming removed & removing from state", id.PartitionKey, id.TableName, id.AccountName)
+		d.SetId("")
+		return nil
+	}
+
+	client, err := storageClient.TableEntityClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Entity Client: %s", err)
+	}
+
+	input := entities.GetEntityInput{
+		PartitionKey:  id.PartitionKey,
+		RowKey:        id.RowKey,
+		MetaDataLevel: entities.NoMetaData,
+	}
+
+	result, err := client.Get(ctx, id.AccountName, id.TableName, input)
+	if err != nil {
+		if utils.ResponseWasNotFound(result.Response) {
+			log.Printf("[DEBUG] Entity (Partition Key %q / Row Key %q) was not found in Table %q (Account %q) - assuming removed & removing from state", id.PartitionKey, id.RowKey, id.TableName, id.AccountName)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving Entity (Partition Key %q / Row Key %q) (Table %q / Storage Account %q / Resource Group %q): %s", id.PartitionKey, id.RowKey, id.TableName, id.AccountName, account.ResourceGroup, err)
+	}
+
+	d.Set("storage_account_name", id.AccountName)
+	d.Set("table_name", id.TableName)
+	d.Set("partition_key", id.PartitionKey)
+	d.Set("row_key", id.RowKey)
+
+	if err := d.Set("entity", flattenStorageTableEntity(result.Entity)); err != nil {
+		return fmt.Errorf("setting `entity` for Entity (Partition Key %q / Row Key %q) (Table %q / Storage Account %q / Resource Group %q): %s", id.PartitionKey, id.RowKey, id.TableName, id.AccountName, account.ResourceGroup, err)
+	}
+
+	return nil
+}
+
+func storageTableEntityDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageTableEntityDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", id.AccountName, id.TableName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
+	}
+
+	client, err := storageClient.TableEntityClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Entity Client: %s", err)
+	}
+
+	input := entities.DeleteEntityInput{
+		PartitionKey: id.PartitionKey,
+		RowKey:       id.RowKey,
+	}
+
+	if resp, err := client.Delete(ctx, id.AccountName, id.TableName, input); err != nil {
+		if !utils.ResponseWasNotFound(resp) {
+			return fmt.Errorf("deleting Entity (Partition Key %q / Row Key %q) (Table %q / Storage Account %q / Resource Group %q): %s", id.PartitionKey, id.RowKey, id.TableName, id.AccountName, account.ResourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+// flattenStorageTableEntity removes the system properties returned by the API, which are exposed as separate fields
+func flattenStorageTableEntity(entity map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range entity {
+		if k == "PartitionKey" || k == "RowKey" || k == "Timestamp" {
+			continue
+		}
+
+		result[k] = fmt.Sprintf("%v", v)
+	}
+	return result
+}
@@ -0,0 +1,315 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/table/tables"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func storageTable() *schema.Resource {
+	return &schema.Resource{
+		Create: storageTableCreate,
+		Read:   storageTableRead,
+		Update: storageTableUpdate,
+		Delete: storageTableDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.StorageTableDataPlaneID(id)
+			return err
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageTableName,
+			},
+
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"acl": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 64),
+						},
+
+						"access_policy": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"expiry": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"permissions": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func storageTableCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	tableName := d.Get("name").(string)
+	accountName := d.Get("storage_account_name").(string)
+	acls := expandStorageTableACLs(d.Get("acl").(*schema.Set).List())
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", accountName, tableName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", accountName)
+	}
+
+	client, err := storageClient.TablesClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building storage client: %+v", err)
+	}
+
+	id := parse.NewStorageTableDataPlaneId(accountName, storageClient.Env.StorageEndpointSuffix, tableName).ID()
+	exists, err := client.Exists(ctx, account.ResourceGroup, accountName, tableName)
+	if err != nil {
+		return err
+	}
+	if exists != nil && *exists {
+		return tf.ImportAsExistsError("azurestack_storage_table", id)
+	}
+
+	log.Printf("[INFO] Creating Table %q in Storage Account %q", tableName, accountName)
+	if err := client.Create(ctx, account.ResourceGroup, accountName, tableName); err != nil {
+		return fmt.Errorf("creating Table %q (Account %q): %+v", tableName, accountName, err)
+	}
+
+	d.SetId(id)
+
+	if err := client.UpdateACLs(ctx, account.ResourceGroup, accountName, tableName, acls); err != nil {
+		return fmt.Errorf("setting ACL's for Table %q (Account %q): %+v", tableName, accountName, err)
+	}
+
+	return storageTableRead(d, meta)
+}
+
+func storageTableUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageTableDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", id.AccountName, id.Name, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
+	}
+
+	client, err := storageClient.TablesClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Tables Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
+	}
+
+	if d.HasChange("acl") {
+		log.Printf("[DEBUG] Updating the ACL's for Table %q (Storage Account %q / Resource Group %q)..", id.Name, id.AccountName, account.ResourceGroup)
+		acls := expandStorageTableACLs(d.Get("acl").(*schema.Set).List())
+
+		if err := client.UpdateACLs(ctx, account.ResourceGroup, id.AccountName, id.Name, acls); err != nil {
+			return fmt.Errorf("updating the ACL's for Table %q (Storage Account %q / Resource Group %q): %s", id.Name, id.AccountName, account.ResourceGroup, err)
+		}
+
+		log.Printf("[DEBUG] Updated the ACL's for Table %q (Storage Account %q / Resource Group %q)", id.Name, id.AccountName, account.ResourceGroup)
+	}
+
+	return storageTableRead(d, meta)
+}
+
+func storageTableRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageTableDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", id.AccountName, id.Name, err)
+	}
+	if account == nil {
+		log.Printf("[DEBUG] Unable to locate Account %q for Storage Table %q - assuming removed & removing from state", id.AccountName, id.Name)
+		d.SetId("")
+		return nil
+	}
+
+	client, err := storageClient.TablesClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Tables Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
+	}
+
+	exists, err := client.Exists(ctx, account.ResourceGroup, id.AccountName, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving Table %q (Account %q / Resource Group %q): %s", id.Name, id.AccountName, account.ResourceGroup, err)
+	}
+	if exists == nil || !*exists {
+		log.Printf("[DEBUG] Table %q was not found in Account %q / Resource Group %q - assuming removed & removing from state", id.Name, id.AccountName, account.ResourceGroup)
+		d.SetId("")
+		return nil
+	}
+
+	acls, err := client.GetACLs(ctx, account.ResourceGroup, id.AccountName, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving ACL's for Table %q (Account %q / Resource Group %q): %s", id.Name, id.AccountName, account.ResourceGroup, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("storage_account_name", id.AccountName)
+
+	if err := d.Set("acl", flattenStorageTableACLs(acls)); err != nil {
+		return fmt.Errorf("setting `acl`: %+v", err)
+	}
+
+	return nil
+}
+
+func storageTableDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageTableDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", id.AccountName, id.Name, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
+	}
+
+	client, err := storageClient.TablesClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Tables Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
+	}
+
+	if err := client.Delete(ctx, account.ResourceGroup, id.AccountName, id.Name); err != nil {
+		return fmt.Errorf("deleting Table %q (Storage Account %q / Resource Group %q): %s", id.Name, id.AccountName, account.ResourceGroup, err)
+	}
+
+	return nil
+}
+
+func expandStorageTableACLs(input []interface{}) []tables.SignedIdentifier {
+	results := make([]tables.SignedIdentifier, 0)
+
+	for _, v := range input {
+		if v == nil {
+			continue
+		}
+		raw := v.(map[string]interface{})
+
+		identifier := tables.SignedIdentifier{
+			Id: raw["id"].(string),
+		}
+
+		for _, policyRaw := range raw["access_policy"].([]interface{}) {
+			if policyRaw == nil {
+				continue
+			}
+			policy := policyRaw.(map[string]interface{})
+
+			identifier.AccessPolicy = tables.AccessPolicy{
+				Start:      policy["start"].(string),
+				Expiry:     policy["expiry"].(string),
+				Permission: policy["permissions"].(string),
+			}
+		}
+
+		results = append(results, identifier)
+	}
+
+	return results
+}
+
+func flattenStorageTableACLs(input *[]tables.SignedIdentifier) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, v := range *input {
+		accessPolicies := make([]interface{}, 0)
+		if policy := v.AccessPolicy; policy.Start != "" || policy.Expiry != "" || policy.Permission != "" {
+			accessPolicies = append(accessPolicies, map[string]interface{}{
+				"start":       policy.Start,
+				"expiry":      policy.Expiry,
+				"permissions": policy.Permission,
+			})
+		}
+
+		results = append(results, map[string]interface{}{
+			"id":            v.Id,
+			"access_policy": accessPolicies,
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,36 @@
+package validate
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// StorageAccountIpRule validates that the value is either a single IPv4 Address or an IPv4 CIDR range,
+// noting that the Storage Account API doesn't support address ranges with a prefix of /31 or /32
+func StorageAccountIpRule(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return warnings, errors
+	}
+
+	if !strings.Contains(value, "/") {
+		if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+			errors = append(errors, fmt.Errorf("%q must be a valid IPv4 Address or CIDR range: %q", k, value))
+		}
+		return warnings, errors
+	}
+
+	ip, ipNet, err := net.ParseCIDR(value)
+	if err != nil || ip.To4() == nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid IPv4 Address or CIDR range: %q", k, value))
+		return warnings, errors
+	}
+
+	if prefix, _ := ipNet.Mask.Size(); prefix > 30 {
+		errors = append(errors, fmt.Errorf("%q does not support CIDR ranges with a prefix of /31 or /32 - specify the IPv4 Address instead: %q", k, value))
+	}
+
+	return warnings, errors
+}
@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"testing"
+)
+
+func TestStorageAccountIpRule(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected bool
+	}{
+		{
+			Input:    "",
+			Expected: false,
+		},
+		{
+			Input:    "23.45.1.0",
+			Expected: true,
+		},
+		{
+			Input:    "23.45.1.0/24",
+			Expected: true,
+		},
+		{
+			Input:    "23.45.1.0/30",
+			Expected: true,
+		},
+		{
+			Input:    "23.45.1.0/31",
+			Expected: false,
+		},
+		{
+			Input:    "23.45.1.1/32",
+			Expected: false,
+		},
+		{
+			Input:    "23.45.1",
+			Expected: false,
+		},
+		{
+			Input:    "2001:db8::/32",
+			Expected: false,
+		},
+		{
+			Input:    "2001:db8::1",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		_, errors := StorageAccountIpRule(v.Input, "ip_rules")
+		actual := len(errors) == 0
+		if v.Expected != actual {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+func StorageQueueName(v interface{}, k string) (warnings []string, errors []error) {
+	value := v.(string)
+
+	if !regexp.MustCompile(`^[0-9a-z-]+$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"only lowercase alphanumeric characters and hyphens allowed in %q: %q", k, value))
+	}
+	if len(value) < 3 || len(value) > 63 {
+		errors = append(errors, fmt.Errorf(
+			"%q must be between 3 and 63 characters: %q", k, value))
+	}
+	if strings.HasPrefix(value, "-") || strings.HasSuffix(value, "-") {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot begin or end with a hyphen: %q", k, value))
+	}
+	if strings.Contains(value, "--") {
+		errors = append(errors, fmt.Errorf(
+			"%q does not allow consecutive hyphens: %q", k, value))
+	}
+	return warnings, errors
+}
@@ -0,0 +1,68 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStorageQueueName(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected bool
+	}{
+		{
+			Input:    "",
+			Expected: false,
+		},
+		{
+			Input:    "qu",
+			Expected: false,
+		},
+		{
+			Input:    "queue1",
+			Expected: true,
+		},
+		{
+			Input:    "queue-name",
+			Expected: true,
+		},
+		{
+			Input:    "Queue",
+			Expected: false,
+		},
+		{
+			Input:    "-queue",
+			Expected: false,
+		},
+		{
+			Input:    "queue-",
+			Expected: false,
+		},
+		{
+			Input:    "queue--name",
+			Expected: false,
+		},
+		{
+			Input:    "queue_name",
+			Expected: false,
+		},
+		{
+			Input:    strings.Repeat("q", 63),
+			Expected: true,
+		},
+		{
+			Input:    strings.Repeat("q", 64),
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		_, errors := StorageQueueName(v.Input, "name")
+		actual := len(errors) == 0
+		if v.Expected != actual {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+func StorageTableName(v interface{}, k string) (warnings []string, errors []error) {
+	value := v.(string)
+
+	if strings.EqualFold(value, "tables") {
+		errors = append(errors, fmt.Errorf("%q cannot use the reserved table name %q", k, value))
+	}
+
+	if !regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]{2,62}$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q must begin with a letter, contain only alphanumeric characters and be between 3 and 63 characters: %q", k, value))
+	}
+
+	return warnings, errors
+}
@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStorageTableName(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected bool
+	}{
+		{
+			Input:    "",
+			Expected: false,
+		},
+		{
+			Input:    "ta",
+			Expected: false,
+		},
+		{
+			Input:    "table1",
+			Expected: true,
+		},
+		{
+			Input:    "MyTable",
+			Expected: true,
+		},
+		{
+			Input:    "1table",
+			Expected: false,
+		},
+		{
+			Input:    "table-name",
+			Expected: false,
+		},
+		{
+			Input:    "tables",
+			Expected: false,
+		},
+		{
+			Input:    "Tables",
+			Expected: false,
+		},
+		{
+			Input:    strings.Repeat("t", 63),
+			Expected: true,
+		},
+		{
+			Input:    strings.Repeat("t", 64),
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		_, errors := StorageTableName(v.Input, "name")
+		actual := len(errors) == 0
+		if v.Expected != actual {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}